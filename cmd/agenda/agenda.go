@@ -0,0 +1,105 @@
+package agenda
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/agenda"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
+	"github.com/spf13/cobra"
+)
+
+var (
+	agendaDays int
+	agendaFrom string
+)
+
+var AgendaCmd = &cobra.Command{
+	Use:   "agenda",
+	Short: "Show events and tasks day by day",
+	Long: `Show a day-by-day plan that merges your events and open tasks. Events are
+listed by time, tasks by due date and priority, and overdue tasks come first.
+Cancelled events are left out.
+
+Use --output table or --output markdown for human-readable output; markdown
+works well for stand-up notes.`,
+	Example: `  # Today
+  notion-cli agenda
+
+  # The coming week as Markdown
+  notion-cli agenda --days 7 --output markdown`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		cfg := cmd.GetConfig()
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		if cfg.EventsDatabaseID == "" && cfg.TasksDatabaseID == "" {
+			return output.Error(fmt.Errorf("events or tasks database ID is required"))
+		}
+		if agendaDays < 1 {
+			return output.Error(fmt.Errorf("days must be at least 1"))
+		}
+
+		from, err := client.ParseDate(agendaFrom)
+		if err != nil {
+			return output.Error(fmt.Errorf("invalid start date: %w", err))
+		}
+		start := from.Time
+		last := start.AddDate(0, 0, agendaDays-1)
+
+		var events []notioncli.Event
+		if cfg.EventsDatabaseID != "" {
+			all, err := client.QueryEvents(ctx, cfg.EventsDatabaseID, notioncli.EventQueryOptions{
+				DateAfter:  start.Format("2006-01-02"),
+				DateBefore: last.Format("2006-01-02"),
+				Limit:      1000,
+			})
+			if err != nil {
+				return output.Error(err)
+			}
+			groups := client.Settings().EventStatuses
+			for _, event := range all {
+				if !groups.IsCancelled(event.Status) {
+					events = append(events, event)
+				}
+			}
+		}
+
+		var tasks []notioncli.Task
+		if cfg.TasksDatabaseID != "" {
+			tasks, err = client.QueryTasks(ctx, cfg.TasksDatabaseID, notioncli.TaskQueryOptions{
+				Open:      true,
+				DueBefore: last.Format("2006-01-02"),
+				Limit:     1000,
+			})
+			if err != nil {
+				return output.Error(err)
+			}
+		}
+
+		plan := agenda.Build(events, tasks, start, agendaDays)
+
+		switch strings.ToLower(cmd.GetOutputFormat()) {
+		case "markdown", "md":
+			if err := plan.WriteMarkdown(os.Stdout); err != nil {
+				return output.Error(err)
+			}
+			return nil
+		case "table":
+			return output.Table([]string{"DATE", "TIME", "KIND", "TITLE", "DETAILS"}, plan.Rows())
+		default:
+			return output.JSON(plan)
+		}
+	},
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(AgendaCmd)
+
+	AgendaCmd.Flags().IntVar(&agendaDays, "days", 1, "Number of days to show")
+	AgendaCmd.Flags().StringVar(&agendaFrom, "from", "today", "First day to show")
+}
@@ -0,0 +1,51 @@
+package blocks
+
+import (
+	"context"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	appendMarkdown string
+	appendStdin    bool
+)
+
+var appendCmd = &cobra.Command{
+	Use:   "append <page-id>",
+	Short: "Add Markdown to the end of a page",
+	Long: `Add Markdown to the end of a page, or inside a block such as a toggle.
+Headings, lists, to-dos, quotes, code and rules become blocks of their own;
+see "blocks insert" to add them elsewhere.`,
+	Example: `  notion-cli blocks append "PAGE_ID" --markdown "## Follow-ups
+
+- [ ] Send the notes
+- [ ] Book the next meeting"
+
+  cat notes.md | notion-cli blocks append "PAGE_ID" --stdin`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		md, err := readMarkdown(appendMarkdown, appendStdin)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		blocks, err := client.AppendMarkdown(ctx, args[0], md)
+		if err != nil {
+			return output.Error(err)
+		}
+		return printBlocks(blocks)
+	},
+}
+
+func init() {
+	BlocksCmd.AddCommand(appendCmd)
+
+	appendCmd.Flags().StringVar(&appendMarkdown, "markdown", "", "Content to add, as Markdown")
+	appendCmd.Flags().BoolVar(&appendStdin, "stdin", false, "Read the Markdown from stdin")
+}
@@ -0,0 +1,74 @@
+package blocks
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
+	"github.com/spf13/cobra"
+)
+
+var BlocksCmd = &cobra.Command{
+	Use:   "blocks",
+	Short: "Edit page content block by block",
+	Long: `List, add, change, move and delete the blocks that make up a page's content.
+Blocks are given by ID; "blocks list" shows the IDs of a page's blocks.`,
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(BlocksCmd)
+}
+
+// readMarkdown returns the Markdown given with --markdown, or read from
+// stdin with --stdin
+func readMarkdown(markdown string, stdin bool) (string, error) {
+	if stdin {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read stdin: %w", err)
+		}
+		markdown = string(data)
+	}
+	if strings.TrimSpace(markdown) == "" {
+		return "", fmt.Errorf("content is required (--markdown or --stdin)")
+	}
+	return markdown, nil
+}
+
+// printBlocks prints blocks as JSON, or as an indented table with --output
+// table
+func printBlocks(blocks []notioncli.BlockInfo) error {
+	if strings.ToLower(cmd.GetOutputFormat()) == "table" {
+		var rows [][]string
+		addRows(&rows, blocks, 0)
+		return output.Table([]string{"ID", "TYPE", "TEXT"}, rows)
+	}
+	return output.JSON(blocks)
+}
+
+// printBlock prints one block like printBlocks, as a JSON object
+func printBlock(block *notioncli.BlockInfo) error {
+	if strings.ToLower(cmd.GetOutputFormat()) == "table" {
+		return printBlocks([]notioncli.BlockInfo{*block})
+	}
+	return output.JSON(block)
+}
+
+func addRows(rows *[][]string, blocks []notioncli.BlockInfo, depth int) {
+	for _, b := range blocks {
+		text := strings.ReplaceAll(b.Text, "\n", " ")
+		if b.Checked != nil {
+			box := "[ ] "
+			if *b.Checked {
+				box = "[x] "
+			}
+			text = box + text
+		}
+		*rows = append(*rows, []string{b.ID, b.Type, strings.Repeat("  ", depth) + text})
+		addRows(rows, b.Children, depth+1)
+	}
+}
@@ -0,0 +1,31 @@
+package blocks
+
+import (
+	"context"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var deleteCmd = &cobra.Command{
+	Use:     "delete <block-id>",
+	Short:   "Delete a block",
+	Long:    `Move a block, with the blocks nested in it, to the trash.`,
+	Example: `  notion-cli blocks delete "BLOCK_ID"`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		block, err := client.DeleteBlock(ctx, args[0])
+		if err != nil {
+			return output.Error(err)
+		}
+		return printBlock(block)
+	},
+}
+
+func init() {
+	BlocksCmd.AddCommand(deleteCmd)
+}
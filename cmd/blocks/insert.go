@@ -0,0 +1,52 @@
+package blocks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	insertAfter    string
+	insertMarkdown string
+	insertStdin    bool
+)
+
+var insertCmd = &cobra.Command{
+	Use:   "insert",
+	Short: "Add Markdown after a block",
+	Long:  `Add Markdown right after a block, in the same page or block.`,
+	Example: `  notion-cli blocks insert --after "BLOCK_ID" --markdown "## Risks
+
+Nothing yet."`,
+	Args: cobra.NoArgs,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		if insertAfter == "" {
+			return output.Error(fmt.Errorf("the block to insert after is required (--after)"))
+		}
+		md, err := readMarkdown(insertMarkdown, insertStdin)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		blocks, err := client.InsertMarkdown(ctx, insertAfter, md)
+		if err != nil {
+			return output.Error(err)
+		}
+		return printBlocks(blocks)
+	},
+}
+
+func init() {
+	BlocksCmd.AddCommand(insertCmd)
+
+	insertCmd.Flags().StringVar(&insertAfter, "after", "", "ID of the block to insert after (required)")
+	insertCmd.Flags().StringVar(&insertMarkdown, "markdown", "", "Content to add, as Markdown")
+	insertCmd.Flags().BoolVar(&insertStdin, "stdin", false, "Read the Markdown from stdin")
+}
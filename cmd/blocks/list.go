@@ -0,0 +1,49 @@
+package blocks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list <page-id>",
+	Short: "List the blocks of a page",
+	Long: `List the blocks of a page or block as a tree, with each block's ID, type and
+text, and whether to-dos are checked.
+
+Use --output table for an indented tree or --output markdown for the page's
+content as Markdown; the default is JSON with nested blocks under children.`,
+	Example: `  notion-cli blocks list "PAGE_ID" --output table
+
+  # The ID of the to-do reading "Write tests"
+  notion-cli blocks list "PAGE_ID" | jq -r '.. | objects | select(.text? == "Write tests") | .id'`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		if strings.ToLower(cmd.GetOutputFormat()) == "markdown" {
+			md, err := client.GetPageMarkdown(ctx, args[0])
+			if err != nil {
+				return output.Error(err)
+			}
+			fmt.Println(md)
+			return nil
+		}
+
+		blocks, err := client.ListBlocks(ctx, args[0])
+		if err != nil {
+			return output.Error(err)
+		}
+		return printBlocks(blocks)
+	},
+}
+
+func init() {
+	BlocksCmd.AddCommand(listCmd)
+}
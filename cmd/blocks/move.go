@@ -0,0 +1,50 @@
+package blocks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	moveTo    string
+	moveAfter string
+)
+
+var moveCmd = &cobra.Command{
+	Use:   "move <block-id>",
+	Short: "Move a block to another place",
+	Long: `Move a block, with the blocks nested in it, to the end of a page or block
+(--to) or right after another block (--after).
+
+Notion can't move blocks, so the block is copied and the original deleted:
+the moved block gets a new ID, printed with the result. Blocks without text,
+such as images and child pages, can't be moved.`,
+	Example: `  notion-cli blocks move "BLOCK_ID" --after "OTHER_BLOCK_ID"
+  notion-cli blocks move "BLOCK_ID" --to "PAGE_ID"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		if moveTo == "" && moveAfter == "" {
+			return output.Error(fmt.Errorf("where to move the block is required (--to or --after)"))
+		}
+
+		block, err := client.MoveBlock(ctx, args[0], moveTo, moveAfter)
+		if err != nil {
+			return output.Error(err)
+		}
+		return printBlock(block)
+	},
+}
+
+func init() {
+	BlocksCmd.AddCommand(moveCmd)
+
+	moveCmd.Flags().StringVar(&moveTo, "to", "", "Page or block to move the block into, at the end")
+	moveCmd.Flags().StringVar(&moveAfter, "after", "", "Block to move the block after")
+}
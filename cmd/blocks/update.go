@@ -0,0 +1,57 @@
+package blocks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
+	"github.com/spf13/cobra"
+)
+
+var (
+	updateMarkdown string
+	updateChecked  bool
+)
+
+var updateCmd = &cobra.Command{
+	Use:   "update <block-id>",
+	Short: "Change the text of a block or tick a to-do",
+	Long: `Replace the text of a block, given as inline Markdown, or tick or clear a
+to-do. The block keeps its type; code blocks take the text as it is.`,
+	Example: `  # Tick a checklist item
+  notion-cli blocks update "BLOCK_ID" --checked
+
+  notion-cli blocks update "BLOCK_ID" --markdown "Ship **on Friday**"
+  notion-cli blocks update "BLOCK_ID" --checked=false`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		var update notioncli.BlockUpdate
+		if cobraCmd.Flags().Changed("markdown") {
+			update.Markdown = &updateMarkdown
+		}
+		if cobraCmd.Flags().Changed("checked") {
+			update.Checked = &updateChecked
+		}
+		if update.Markdown == nil && update.Checked == nil {
+			return output.Error(fmt.Errorf("nothing to update (--markdown or --checked)"))
+		}
+
+		block, err := client.UpdateBlock(ctx, args[0], update)
+		if err != nil {
+			return output.Error(err)
+		}
+		return printBlock(block)
+	},
+}
+
+func init() {
+	BlocksCmd.AddCommand(updateCmd)
+
+	updateCmd.Flags().StringVar(&updateMarkdown, "markdown", "", "New text of the block, as inline Markdown")
+	updateCmd.Flags().BoolVar(&updateChecked, "checked", false, "Tick a to-do; --checked=false clears it")
+}
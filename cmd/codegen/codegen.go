@@ -0,0 +1,91 @@
+package codegen
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/codegen"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	codegenDatabases []string
+	codegenPackage   string
+	codegenName      string
+	codegenFile      string
+)
+
+var CodegenCmd = &cobra.Command{
+	Use:   "codegen",
+	Short: "Generate Go types for databases",
+	Long: `Generate Go code for the pages of one or more databases from their schemas.
+Each database gets:
+
+  - a struct with one field per property, plus the page ID and URL
+  - an input struct with the properties pages can set
+  - Decode<Type>, reading a notionapi.Page into the struct
+  - Encode<Type>Input, turning an input into notionapi.Properties
+  - Create<Type> and Update<Type>, using a *notionapi.Client
+
+Types are named after the database ("Tasks" gives Task) unless --name is
+given. The generated file shares helper functions between its databases,
+so generate all the databases of a package in one run.`,
+	Example: `  notion-cli codegen --database "DATABASE_ID" --package tasks
+
+  # Two databases in one package, written to a file
+  notion-cli codegen --database "TASKS_ID" --database "PROJECTS_ID" \
+    --package team --file team/notion_gen.go`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		if len(codegenDatabases) == 0 {
+			return output.Error(fmt.Errorf("at least one database is required (--database)"))
+		}
+		if codegenPackage == "" {
+			return output.Error(fmt.Errorf("a package name is required (--package)"))
+		}
+		if codegenName != "" && len(codegenDatabases) > 1 {
+			return output.Error(fmt.Errorf("--name can only be used with one database"))
+		}
+
+		ms := make([]codegen.Model, 0, len(codegenDatabases))
+		for _, id := range codegenDatabases {
+			schema, err := client.GetSchema(ctx, id)
+			if err != nil {
+				return output.Error(err)
+			}
+			name := codegenName
+			if name == "" {
+				name = codegen.TypeName(schema.Title)
+			}
+			ms = append(ms, codegen.Model{Name: name, Schema: schema})
+		}
+
+		src, err := codegen.GoModels(codegenPackage, ms)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		if codegenFile == "" || codegenFile == "-" {
+			fmt.Print(src)
+			return nil
+		}
+		if err := os.WriteFile(codegenFile, []byte(src), 0o644); err != nil {
+			return output.Error(fmt.Errorf("failed to write file: %w", err))
+		}
+		return nil
+	},
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(CodegenCmd)
+
+	CodegenCmd.Flags().StringSliceVar(&codegenDatabases, "database", nil, "Database ID to generate code for; repeat for several (required)")
+	CodegenCmd.Flags().StringVar(&codegenPackage, "package", "", "Package name of the generated file (required)")
+	CodegenCmd.Flags().StringVar(&codegenName, "name", "", "Type name (default: from the database title)")
+	CodegenCmd.Flags().StringVar(&codegenFile, "file", "", "Write to this file instead of stdout")
+}
@@ -23,6 +23,8 @@ var showCmd = &cobra.Command{
 		fmt.Printf("API Token:      %s\n", maskedToken)
 		fmt.Printf("Database ID:    %s\n", cfg.DatabaseID)
 		fmt.Printf("Default Status: %s\n", cfg.DefaultStatus)
+		fmt.Printf("Timezone:       %s\n", cfg.Location())
+		fmt.Printf("Working Hours:  %s\n", cfg.WorkingHours)
 
 		return nil
 	},
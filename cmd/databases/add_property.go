@@ -0,0 +1,77 @@
+package databases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
+	"github.com/spf13/cobra"
+)
+
+var (
+	addPropertyID         string
+	addPropertyName       string
+	addPropertyType       string
+	addPropertyOptions    []string
+	addPropertyFormat     string
+	addPropertyRelation   string
+	addPropertyExpression string
+)
+
+var addPropertyCmd = &cobra.Command{
+	Use:   "add-property",
+	Short: "Add a property to a database",
+	Long: `Add a property to a database. Types use the Notion API names: rich_text,
+number, select, multi_select, status, date, people, files, checkbox, url,
+email, phone_number, relation, formula, created_time, created_by,
+last_edited_time and last_edited_by.
+
+Status properties get Notion's default options; the API can't set others.`,
+	Example: `  # A select with options
+  notion-cli databases add-property --id "DATABASE_ID" --name "Priority" --type select --options "High,Medium,Low"
+
+  # A relation to the same database
+  notion-cli databases add-property --id "DATABASE_ID" --name "Blocked By" --type relation
+
+  # A percentage
+  notion-cli databases add-property --id "DATABASE_ID" --name "Progress" --type number --format percent`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		id, err := databaseID(addPropertyID)
+		if err != nil {
+			return output.Error(err)
+		}
+		if addPropertyName == "" || addPropertyType == "" {
+			return output.Error(fmt.Errorf("name and type are required"))
+		}
+
+		schema, err := client.AddProperty(ctx, id, addPropertyName, notioncli.PropertySpec{
+			Type:       addPropertyType,
+			Options:    addPropertyOptions,
+			Format:     addPropertyFormat,
+			Database:   addPropertyRelation,
+			Expression: addPropertyExpression,
+		})
+		if err != nil {
+			return output.Error(err)
+		}
+
+		return output.JSON(schema)
+	},
+}
+
+func init() {
+	DatabasesCmd.AddCommand(addPropertyCmd)
+
+	addPropertyCmd.Flags().StringVar(&addPropertyID, "id", "", "Database ID (defaults to configured database)")
+	addPropertyCmd.Flags().StringVar(&addPropertyName, "name", "", "Property name (required)")
+	addPropertyCmd.Flags().StringVar(&addPropertyType, "type", "", "Property type, e.g. select, date, relation (required)")
+	addPropertyCmd.Flags().StringSliceVar(&addPropertyOptions, "options", []string{}, "Options of a select or multi_select property (comma-separated)")
+	addPropertyCmd.Flags().StringVar(&addPropertyFormat, "format", "", "Number format, e.g. number, percent, dollar (default: number)")
+	addPropertyCmd.Flags().StringVar(&addPropertyRelation, "relation", "", "Database a relation points to (default: this database)")
+	addPropertyCmd.Flags().StringVar(&addPropertyExpression, "expression", "", "Formula expression")
+}
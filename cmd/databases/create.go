@@ -0,0 +1,78 @@
+package databases
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	createFrom   string
+	createParent string
+	createTitle  string
+)
+
+var createCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a database from a schema file",
+	Long: `Create a database from a YAML schema file listing its title, parent page and
+properties. docs/schemas has files for the posts, tasks and events databases
+the setup guides describe.
+
+The Notion API can't set status options, so status properties start with
+Notion's defaults; the options to add by hand are listed in manual_steps.`,
+	Example: `  # The tasks database, in a page shared with the integration
+  notion-cli databases create --from docs/schemas/tasks.yaml --parent "PAGE_ID"
+
+  # schema.yaml:
+  #   title: Reading list
+  #   parent: PAGE_ID
+  #   properties:
+  #     Title: {type: title}
+  #     Author: {type: rich_text}
+  #     Shelf: {type: select, options: [To read, Reading, Read]}
+  notion-cli databases create --from schema.yaml`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		if createFrom == "" {
+			return output.Error(fmt.Errorf("a schema file is required (--from)"))
+		}
+		data, err := os.ReadFile(createFrom)
+		if err != nil {
+			return output.Error(fmt.Errorf("failed to read schema file: %w", err))
+		}
+		var spec notioncli.DatabaseSpec
+		if err := yaml.Unmarshal(data, &spec); err != nil {
+			return output.Error(fmt.Errorf("failed to parse schema file: %w", err))
+		}
+		if createParent != "" {
+			spec.Parent = createParent
+		}
+		if createTitle != "" {
+			spec.Title = createTitle
+		}
+
+		created, err := client.CreateDatabase(ctx, spec)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		return output.JSON(created)
+	},
+}
+
+func init() {
+	DatabasesCmd.AddCommand(createCmd)
+
+	createCmd.Flags().StringVar(&createFrom, "from", "", "YAML schema file (required)")
+	createCmd.Flags().StringVar(&createParent, "parent", "", "Page to create the database in (overrides the file)")
+	createCmd.Flags().StringVar(&createTitle, "title", "", "Database title (overrides the file)")
+}
@@ -1,6 +1,8 @@
 package databases
 
 import (
+	"fmt"
+
 	"github.com/jontk/notion-cli/cmd"
 	"github.com/spf13/cobra"
 )
@@ -8,9 +10,20 @@ import (
 var DatabasesCmd = &cobra.Command{
 	Use:   "databases",
 	Short: "Manage databases in Notion",
-	Long:  `List and inspect databases in your Notion workspace.`,
+	Long:  `List, inspect, create and change the schema of databases in your Notion workspace.`,
 }
 
 func init() {
 	cmd.RootCmd.AddCommand(DatabasesCmd)
 }
+
+// databaseID returns the database given with --id, or the configured one
+func databaseID(id string) (string, error) {
+	if id == "" {
+		id = cmd.GetConfig().DatabaseID
+	}
+	if id == "" {
+		return "", fmt.Errorf("database ID is required")
+	}
+	return id, nil
+}
@@ -4,21 +4,34 @@ import (
 	"context"
 
 	"github.com/jontk/notion-cli/cmd"
-	"github.com/jontk/notion-cli/internal/notion"
 	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
 	"github.com/spf13/cobra"
 )
 
+var (
+	listQuery string
+	listSort  string
+)
+
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all databases",
-	Long:  `List all databases accessible to your Notion integration.`,
+	Long: `List all databases accessible to your Notion integration. Every page of
+results is fetched, however many databases the workspace has.`,
+	Example: `  # All databases
+  notion-cli databases list
+
+  # Databases with "tasks" in the title, most recently edited first
+  notion-cli databases list --query tasks --sort last_edited`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
-		cfg := cmd.GetConfig()
-		client := notion.NewClient(cfg.APIToken)
+		client := cmd.NewClient()
 		ctx := context.Background()
 
-		databases, err := client.ListDatabases(ctx)
+		databases, err := client.ListDatabases(ctx, notioncli.DatabaseListOptions{
+			Query: listQuery,
+			Sort:  listSort,
+		})
 		if err != nil {
 			return output.Error(err)
 		}
@@ -29,4 +42,7 @@ var listCmd = &cobra.Command{
 
 func init() {
 	DatabasesCmd.AddCommand(listCmd)
+
+	listCmd.Flags().StringVar(&listQuery, "query", "", "Only databases whose title contains this text")
+	listCmd.Flags().StringVar(&listSort, "sort", "", "Sort order: last_edited (default: Notion's relevance order)")
 }
@@ -0,0 +1,83 @@
+package databases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	optionID       string
+	optionProperty string
+	optionName     string
+	optionColor    string
+)
+
+var addOptionCmd = &cobra.Command{
+	Use:   "add-option",
+	Short: "Add an option to a select or multi-select property",
+	Long: `Add an option to a select or multi-select property. Status options can't
+be changed through the Notion API.`,
+	Example: `  notion-cli databases add-option --id "DATABASE_ID" --property "Category" --option "Health" --color green`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		id, err := databaseID(optionID)
+		if err != nil {
+			return output.Error(err)
+		}
+		if optionProperty == "" || optionName == "" {
+			return output.Error(fmt.Errorf("property and option are required"))
+		}
+
+		schema, err := client.AddOption(ctx, id, optionProperty, optionName, optionColor)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		return output.JSON(schema)
+	},
+}
+
+var removeOptionCmd = &cobra.Command{
+	Use:   "remove-option",
+	Short: "Remove an option from a select or multi-select property",
+	Long: `Remove an option from a select or multi-select property. Notion clears it
+from every page that has it.`,
+	Example: `  notion-cli databases remove-option --id "DATABASE_ID" --property "Category" --option "Health"`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		id, err := databaseID(optionID)
+		if err != nil {
+			return output.Error(err)
+		}
+		if optionProperty == "" || optionName == "" {
+			return output.Error(fmt.Errorf("property and option are required"))
+		}
+
+		schema, err := client.RemoveOption(ctx, id, optionProperty, optionName)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		return output.JSON(schema)
+	},
+}
+
+func init() {
+	DatabasesCmd.AddCommand(addOptionCmd)
+	DatabasesCmd.AddCommand(removeOptionCmd)
+
+	for _, c := range []*cobra.Command{addOptionCmd, removeOptionCmd} {
+		c.Flags().StringVar(&optionID, "id", "", "Database ID (defaults to configured database)")
+		c.Flags().StringVar(&optionProperty, "property", "", "Property name (required)")
+		c.Flags().StringVar(&optionName, "option", "", "Option name (required)")
+	}
+	addOptionCmd.Flags().StringVar(&optionColor, "color", "", "Option color: default, gray, brown, orange, yellow, green, blue, purple, pink, red")
+}
@@ -0,0 +1,198 @@
+package databases
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	schemaFile       string
+	planCheck        bool
+	allowDestructive bool
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show how the workspace differs from notion-schema.yaml",
+	Long: `Compare the databases described in notion-schema.yaml with the workspace and
+list the changes "databases apply" would make. Nothing is changed.
+
+The file names each database. posts, tasks and events default to the
+configured databases, so the same file can be checked against staging and
+production by switching --config; other databases give an id, which may use
+environment variables:
+
+  databases:
+    tasks:
+      properties:
+        Title: {type: title}
+        Priority: {type: select, options: [High, Medium, Low]}
+    reading:
+      id: ${READING_DATABASE_ID}
+      properties:
+        Name: {type: title}
+        Task: {type: relation, database: tasks}
+
+Properties missing from the file are removed. Select options and number
+formats are only compared when the file gives them; relation targets and
+formulas always are. Changes that lose values on existing
+pages are marked destructive. A relation's database may be the name of
+another database in the file.`,
+	Example: `  notion-cli databases plan
+  notion-cli databases plan --output table
+
+  # In CI: fail when a workspace has drifted from the file
+  notion-cli databases plan --config staging.yaml --check`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		file, ids, err := loadSchemaFile(schemaFile)
+		if err != nil {
+			return output.Error(err)
+		}
+		changes, err := client.PlanSchema(ctx, file, ids)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		if err := printChanges(changes); err != nil {
+			return err
+		}
+		if planCheck && len(changes) > 0 {
+			return output.Error(fmt.Errorf("the workspace differs from %s in %d changes", schemaFile, len(changes)))
+		}
+		return nil
+	},
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Change the workspace to match notion-schema.yaml",
+	Long: `Make the changes "databases plan" lists. Destructive changes (removing a
+property or option, or changing a property's type) are refused unless
+--allow-destructive is given. Status options can't be changed through the
+API and are listed in manual_steps instead.
+
+Renaming a property in the file plans a removal and an addition; use
+"databases rename-property" first to keep its values.`,
+	Example: `  notion-cli databases plan --output table
+  notion-cli databases apply
+
+  notion-cli databases apply --file schemas/notion-schema.yaml --allow-destructive`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		file, ids, err := loadSchemaFile(schemaFile)
+		if err != nil {
+			return output.Error(err)
+		}
+		changes, err := client.PlanSchema(ctx, file, ids)
+		if err != nil {
+			return output.Error(err)
+		}
+		applied, err := client.ApplySchema(ctx, changes, allowDestructive)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		if strings.ToLower(cmd.GetOutputFormat()) == "table" {
+			if err := changeTable(applied.Applied); err != nil {
+				return err
+			}
+			for _, step := range applied.ManualSteps {
+				fmt.Printf("manual: %s\n", step)
+			}
+			return nil
+		}
+		return output.JSON(applied)
+	},
+}
+
+// loadSchemaFile reads a schema file and resolves the ID of each database
+func loadSchemaFile(path string) (notioncli.SchemaFile, map[string]string, error) {
+	var file notioncli.SchemaFile
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return file, nil, fmt.Errorf("failed to read schema file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return file, nil, fmt.Errorf("failed to parse schema file: %w", err)
+	}
+	if len(file.Databases) == 0 {
+		return file, nil, fmt.Errorf("%s lists no databases", path)
+	}
+
+	cfg := cmd.GetConfig()
+	configured := map[string]string{
+		"posts":  cfg.DatabaseID,
+		"tasks":  cfg.TasksDatabaseID,
+		"events": cfg.EventsDatabaseID,
+	}
+	ids := make(map[string]string, len(file.Databases))
+	for name, spec := range file.Databases {
+		id := os.ExpandEnv(spec.ID)
+		if id == "" {
+			id = configured[name]
+		}
+		if id == "" {
+			return file, nil, fmt.Errorf("database %q needs an id in %s", name, path)
+		}
+		ids[name] = id
+	}
+	return file, ids, nil
+}
+
+func printChanges(changes []notioncli.SchemaChange) error {
+	if strings.ToLower(cmd.GetOutputFormat()) == "table" {
+		return changeTable(changes)
+	}
+	if changes == nil {
+		changes = []notioncli.SchemaChange{}
+	}
+	return output.JSON(changes)
+}
+
+func changeTable(changes []notioncli.SchemaChange) error {
+	rows := make([][]string, 0, len(changes))
+	for _, ch := range changes {
+		var detail string
+		switch ch.Action {
+		case "add_property":
+			detail = ch.To
+		case "rename_property", "change_type", "update_property":
+			detail = ch.From + " -> " + ch.To
+		case "remove_property":
+			detail = ch.From
+		case "add_option", "remove_option":
+			detail = ch.Option
+		case "manual":
+			detail = ch.Detail
+		}
+		if ch.Destructive {
+			detail += " (destructive)"
+		}
+		rows = append(rows, []string{ch.Database, ch.Action, ch.Property, detail})
+	}
+	return output.Table([]string{"DATABASE", "ACTION", "PROPERTY", "CHANGE"}, rows)
+}
+
+func init() {
+	DatabasesCmd.AddCommand(planCmd)
+	DatabasesCmd.AddCommand(applyCmd)
+
+	for _, c := range []*cobra.Command{planCmd, applyCmd} {
+		c.Flags().StringVar(&schemaFile, "file", "notion-schema.yaml", "Schema file describing the databases")
+	}
+	planCmd.Flags().BoolVar(&planCheck, "check", false, "Exit with an error when there are changes")
+	applyCmd.Flags().BoolVar(&allowDestructive, "allow-destructive", false, "Also make changes that lose values on existing pages")
+}
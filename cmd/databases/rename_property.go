@@ -0,0 +1,50 @@
+package databases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	renamePropertyID   string
+	renamePropertyName string
+	renamePropertyTo   string
+)
+
+var renamePropertyCmd = &cobra.Command{
+	Use:     "rename-property",
+	Short:   "Rename a database property",
+	Long:    `Rename a database property. Pages keep their values.`,
+	Example: `  notion-cli databases rename-property --id "DATABASE_ID" --name "Name" --to "Title"`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		id, err := databaseID(renamePropertyID)
+		if err != nil {
+			return output.Error(err)
+		}
+		if renamePropertyName == "" || renamePropertyTo == "" {
+			return output.Error(fmt.Errorf("name and new name are required"))
+		}
+
+		schema, err := client.RenameProperty(ctx, id, renamePropertyName, renamePropertyTo)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		return output.JSON(schema)
+	},
+}
+
+func init() {
+	DatabasesCmd.AddCommand(renamePropertyCmd)
+
+	renamePropertyCmd.Flags().StringVar(&renamePropertyID, "id", "", "Database ID (defaults to configured database)")
+	renamePropertyCmd.Flags().StringVar(&renamePropertyName, "name", "", "Current property name (required)")
+	renamePropertyCmd.Flags().StringVar(&renamePropertyTo, "to", "", "New property name (required)")
+}
@@ -3,45 +3,131 @@ package databases
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/jontk/notion-cli/cmd"
-	"github.com/jontk/notion-cli/internal/notion"
+	"github.com/jontk/notion-cli/internal/codegen"
 	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
 	"github.com/spf13/cobra"
 )
 
-var schemaID string
+var (
+	schemaID     string
+	schemaFormat string
+	schemaName   string
+)
 
 var schemaCmd = &cobra.Command{
 	Use:   "schema",
 	Short: "Get database schema",
-	Long:  `Retrieve the schema (properties and their types) of a Notion database.`,
+	Long: `Retrieve the schema of a Notion database: its title, description, icon and
+parent, and each property's ID and type with its options and status groups,
+number format, relation target, rollup or formula.
+
+--format typescript and --format go print a type with one field per
+property instead, named after the database ("Tasks" gives Task).`,
+	Example: `  notion-cli databases schema --id "DATABASE_ID"
+  notion-cli databases schema --format table
+
+  # Typed definitions for a database's pages
+  notion-cli databases schema --id "DATABASE_ID" --format typescript > task.ts
+  notion-cli databases schema --id "DATABASE_ID" --format go --name Reading`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
-		cfg := cmd.GetConfig()
-		client := notion.NewClient(cfg.APIToken)
+		client := cmd.NewClient()
 		ctx := context.Background()
 
-		// Use config database ID if not provided
-		databaseID := schemaID
-		if databaseID == "" {
-			databaseID = cfg.DatabaseID
+		id, err := databaseID(schemaID)
+		if err != nil {
+			return output.Error(err)
 		}
 
-		if databaseID == "" {
-			return output.Error(fmt.Errorf("database ID is required"))
+		format := strings.ToLower(schemaFormat)
+		if format == "" {
+			format = "json"
+			if strings.ToLower(cmd.GetOutputFormat()) == "table" {
+				format = "table"
+			}
+		}
+		switch format {
+		case "json", "table", "typescript", "go":
+		default:
+			return output.Error(fmt.Errorf("unknown format %q: use json, table, typescript or go", schemaFormat))
 		}
 
-		schema, err := client.GetSchema(ctx, databaseID)
+		schema, err := client.GetSchema(ctx, id)
 		if err != nil {
 			return output.Error(err)
 		}
 
+		name := schemaName
+		if name == "" {
+			name = codegen.TypeName(schema.Title)
+		}
+		switch format {
+		case "table":
+			return schemaTable(schema)
+		case "typescript":
+			fmt.Print(codegen.TypeScript(name, schema))
+			return nil
+		case "go":
+			fmt.Print(codegen.GoStruct(name, schema))
+			return nil
+		}
 		return output.JSON(schema)
 	},
 }
 
+// schemaTable prints a database's properties, one per row
+func schemaTable(schema *notioncli.Schema) error {
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		prop := schema.Properties[name]
+		rows = append(rows, []string{name, prop.Type, prop.ID, propertyDetails(prop)})
+	}
+	return output.Table([]string{"PROPERTY", "TYPE", "ID", "DETAILS"}, rows)
+}
+
+// propertyDetails summarizes the configuration of a property
+func propertyDetails(prop notioncli.PropertyInfo) string {
+	switch {
+	case len(prop.Groups) > 0:
+		groups := make([]string, 0, len(prop.Groups))
+		for _, g := range prop.Groups {
+			groups = append(groups, g.Name+": "+strings.Join(g.Options, ", "))
+		}
+		return strings.Join(groups, "; ")
+	case len(prop.Options) > 0:
+		options := make([]string, 0, len(prop.Options))
+		for _, o := range prop.Options {
+			options = append(options, o.Name)
+		}
+		return strings.Join(options, ", ")
+	case prop.Format != "":
+		return prop.Format
+	case prop.Relation != nil:
+		details := "to " + prop.Relation.DatabaseID
+		if prop.Relation.SyncedProperty != "" {
+			details += fmt.Sprintf(" (synced with %q)", prop.Relation.SyncedProperty)
+		}
+		return details
+	case prop.Rollup != nil:
+		return fmt.Sprintf("%s of %s.%s", prop.Rollup.Function, prop.Rollup.Relation, prop.Rollup.Property)
+	}
+	return prop.Expression
+}
+
 func init() {
 	DatabasesCmd.AddCommand(schemaCmd)
 
 	schemaCmd.Flags().StringVar(&schemaID, "id", "", "Database ID (defaults to configured database)")
+	schemaCmd.Flags().StringVar(&schemaFormat, "format", "", "Output format: json, table, typescript, go (default: json, or table with --output table)")
+	schemaCmd.Flags().StringVar(&schemaName, "name", "", "Type name for typescript and go (default: from the database title)")
 }
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/jontk/notion-cli/cmd"
-	"github.com/jontk/notion-cli/internal/notion"
 	"github.com/jontk/notion-cli/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -18,8 +17,7 @@ var cancelCmd = &cobra.Command{
 	Long:  `Mark an event as cancelled in your Notion calendar database.`,
 	Example: `  notion-cli events cancel --id "EVENT_ID"`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
-		cfg := cmd.GetConfig()
-		client := notion.NewClient(cfg.APIToken)
+		client := cmd.NewClient()
 		ctx := context.Background()
 
 		if cancelID == "" {
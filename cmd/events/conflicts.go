@@ -0,0 +1,51 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	conflictsFrom string
+	conflictsTo   string
+)
+
+var conflictsCmd = &cobra.Command{
+	Use:   "conflicts",
+	Short: "Report double-booked attendees",
+	Long: `List overlapping events per attendee, based on the Attendees property.
+Cancelled and all-day events are ignored; events without an end are taken to
+last 30 minutes.`,
+	Example: `  # This week
+  notion-cli events conflicts --from monday --to sunday
+
+  # Next two weeks
+  notion-cli events conflicts --from today --to +2w`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		cfg := cmd.GetConfig()
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		if cfg.EventsDatabaseID == "" {
+			return output.Error(fmt.Errorf("events database ID is required"))
+		}
+
+		report, err := client.FindConflicts(ctx, cfg.EventsDatabaseID, conflictsFrom, conflictsTo)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		return output.JSON(report)
+	},
+}
+
+func init() {
+	EventsCmd.AddCommand(conflictsCmd)
+
+	conflictsCmd.Flags().StringVar(&conflictsFrom, "from", "today", "Start of the period to check")
+	conflictsCmd.Flags().StringVar(&conflictsTo, "to", "+2w", "End of the period to check")
+}
@@ -8,21 +8,25 @@ import (
 	"os"
 
 	"github.com/jontk/notion-cli/cmd"
-	"github.com/jontk/notion-cli/internal/models"
-	"github.com/jontk/notion-cli/internal/notion"
 	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
 	"github.com/spf13/cobra"
 )
 
 var (
-	createTitle     string
-	createDate      string
-	createType      string
-	createLocation  string
-	createAttendees []string
-	createStatus    string
-	createNotes     string
-	createStdin     bool
+	createTitle         string
+	createDate          string
+	createEnd           string
+	createDuration      string
+	createAllDay        bool
+	createType          string
+	createLocation      string
+	createAttendees     []string
+	createStatus        string
+	createNotes         string
+	createRepeat        string
+	createStdin         bool
+	createAllowConflict bool
 )
 
 var createCmd = &cobra.Command{
@@ -32,6 +36,20 @@ var createCmd = &cobra.Command{
 	Example: `  # Simple event
   notion-cli events create --title "Team Meeting" --date "2024-03-20 14:00"
 
+  # Relative date
+  notion-cli events create --title "1:1 with Sam" --date "next tuesday at 10:00"
+
+  # With an end time or duration
+  notion-cli events create --title "Workshop" --date "2024-03-20 09:00" --end "12:30"
+  notion-cli events create --title "Standup" --date "tomorrow 9:15" --duration 15m
+
+  # Multi-day, all-day event
+  notion-cli events create --title "Offsite" --date "2024-04-08" --end "2024-04-10" --all-day
+
+  # Weekly on Tuesdays and Thursdays
+  notion-cli events create --title "Standup" --date "2024-03-19 09:15" --duration 15m \
+    --repeat "FREQ=WEEKLY;BYDAY=TU,TH"
+
   # Full event details
   notion-cli events create \
     --title "Product Launch" \
@@ -42,19 +60,22 @@ var createCmd = &cobra.Command{
     --status "Scheduled" \
     --notes "Q2 product release event"
 
+  # Double-book on purpose
+  notion-cli events create --title "Focus time" --date "2024-03-20 14:00" --duration 2h --allow-conflict
+
   # From stdin
   echo '{"title":"Doctor Appointment","date":"2024-03-25 09:30","type":"Personal"}' | \
     notion-cli events create --stdin`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		cfg := cmd.GetConfig()
-		client := notion.NewClient(cfg.APIToken)
+		client := cmd.NewClient()
 		ctx := context.Background()
 
 		if cfg.EventsDatabaseID == "" {
 			return output.Error(fmt.Errorf("events database ID is required"))
 		}
 
-		var input models.EventInput
+		var input notioncli.EventInput
 
 		if createStdin {
 			data, err := io.ReadAll(os.Stdin)
@@ -72,14 +93,28 @@ var createCmd = &cobra.Command{
 				return output.Error(fmt.Errorf("date is required"))
 			}
 
-			input = models.EventInput{
+			input = notioncli.EventInput{
 				Title:     createTitle,
 				Date:      createDate,
+				End:       createEnd,
+				Duration:  createDuration,
+				AllDay:    createAllDay,
 				Type:      createType,
 				Location:  createLocation,
 				Attendees: createAttendees,
 				Status:    createStatus,
 				Notes:     createNotes,
+				Repeat:    createRepeat,
+			}
+		}
+
+		if !createAllowConflict {
+			conflicts, err := client.CheckConflicts(ctx, cfg.EventsDatabaseID, "", input)
+			if err != nil {
+				return output.Error(err)
+			}
+			if len(conflicts) > 0 {
+				return output.Error(&notioncli.ConflictError{Conflicts: conflicts})
 			}
 		}
 
@@ -96,11 +131,16 @@ func init() {
 	EventsCmd.AddCommand(createCmd)
 
 	createCmd.Flags().StringVar(&createTitle, "title", "", "Event title (required)")
-	createCmd.Flags().StringVar(&createDate, "date", "", "Event date and time (YYYY-MM-DD HH:MM)")
+	createCmd.Flags().StringVar(&createDate, "date", "", "Event date and time (YYYY-MM-DD HH:MM, RFC 3339, 'tomorrow 14:00', 'next friday at 3pm')")
+	createCmd.Flags().StringVar(&createEnd, "end", "", "End time (HH:MM) or end date/time for multi-day events")
+	createCmd.Flags().StringVar(&createDuration, "duration", "", "Event length, e.g. 30m, 1h30m, 2d (alternative to --end)")
+	createCmd.Flags().BoolVar(&createAllDay, "all-day", false, "Create an all-day event (ignores the time of day)")
 	createCmd.Flags().StringVar(&createType, "type", "", "Event type")
 	createCmd.Flags().StringVar(&createLocation, "location", "", "Event location")
 	createCmd.Flags().StringSliceVar(&createAttendees, "attendees", []string{}, "Event attendees (comma-separated)")
 	createCmd.Flags().StringVar(&createStatus, "status", "", "Event status")
 	createCmd.Flags().StringVar(&createNotes, "notes", "", "Event notes")
+	createCmd.Flags().StringVar(&createRepeat, "repeat", "", "Recurrence rule (RRULE), e.g. FREQ=WEEKLY;BYDAY=MO,WE")
 	createCmd.Flags().BoolVar(&createStdin, "stdin", false, "Read EventInput JSON from stdin")
+	createCmd.Flags().BoolVar(&createAllowConflict, "allow-conflict", false, "Create the event even if it overlaps existing events")
 }
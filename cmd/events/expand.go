@@ -0,0 +1,62 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
+	"github.com/spf13/cobra"
+)
+
+var (
+	expandID    string
+	expandUntil string
+)
+
+var expandCmd = &cobra.Command{
+	Use:   "expand",
+	Short: "Create upcoming occurrences of recurring events",
+	Long: `Create the upcoming occurrences of recurring events as their own pages, from
+today until --until. Occurrences link back to the recurring event through the
+Series property, so running the command again only adds the missing ones.
+
+Without --id every recurring event in the database is expanded.`,
+	Example: `  # Expand all recurring events for the next four weeks
+  notion-cli events expand
+
+  # Expand one event until the end of the year
+  notion-cli events expand --id "EVENT_ID" --until eoy`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		cfg := cmd.GetConfig()
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		var created []notioncli.Event
+		var err error
+		if expandID != "" {
+			created, err = client.ExpandEvent(ctx, expandID, expandUntil)
+		} else {
+			if cfg.EventsDatabaseID == "" {
+				return output.Error(fmt.Errorf("events database ID is required"))
+			}
+			created, err = client.ExpandEvents(ctx, cfg.EventsDatabaseID, expandUntil)
+		}
+		if err != nil {
+			return output.Error(err)
+		}
+
+		if created == nil {
+			created = []notioncli.Event{}
+		}
+		return output.JSON(created)
+	},
+}
+
+func init() {
+	EventsCmd.AddCommand(expandCmd)
+
+	expandCmd.Flags().StringVar(&expandID, "id", "", "Recurring event ID (default: all recurring events)")
+	expandCmd.Flags().StringVar(&expandUntil, "until", "+4w", "Create occurrences up to this date")
+}
@@ -0,0 +1,88 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat string
+	exportFile   string
+	exportName   string
+	exportType   string
+	exportStatus string
+	exportFrom   string
+	exportTo     string
+	exportLimit  int
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export events as an iCalendar file",
+	Long: `Export events from your Notion calendar database in iCalendar (RFC 5545)
+format, for use in other calendar applications. Each event's page ID becomes
+its UID, so importing the file again updates the same events.`,
+	Example: `  # Export everything to a file
+  notion-cli events export --format ics --file calendar.ics
+
+  # Upcoming work events to stdout
+  notion-cli events export --type "Work" --from today --to +1mo`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		cfg := cmd.GetConfig()
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		if cfg.EventsDatabaseID == "" {
+			return output.Error(fmt.Errorf("events database ID is required"))
+		}
+		if exportFormat != "ics" {
+			return output.Error(fmt.Errorf("unsupported format %q: only ics is available", exportFormat))
+		}
+
+		events, err := client.QueryEvents(ctx, cfg.EventsDatabaseID, notioncli.EventQueryOptions{
+			Type:       exportType,
+			Status:     exportStatus,
+			DateAfter:  exportFrom,
+			DateBefore: exportTo,
+			Limit:      exportLimit,
+		})
+		if err != nil {
+			return output.Error(err)
+		}
+
+		var w io.Writer = os.Stdout
+		if exportFile != "" && exportFile != "-" {
+			f, err := os.Create(exportFile)
+			if err != nil {
+				return output.Error(fmt.Errorf("failed to create file: %w", err))
+			}
+			defer f.Close()
+			w = f
+		}
+
+		if err := client.WriteICS(w, exportName, events); err != nil {
+			return output.Error(err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	EventsCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVar(&exportFormat, "format", "ics", "Export format (ics)")
+	exportCmd.Flags().StringVar(&exportFile, "file", "", "Write to this file instead of stdout")
+	exportCmd.Flags().StringVar(&exportName, "name", "Notion Events", "Calendar name shown by calendar applications")
+	exportCmd.Flags().StringVar(&exportType, "type", "", "Only events of this type")
+	exportCmd.Flags().StringVar(&exportStatus, "status", "", "Only events with this status")
+	exportCmd.Flags().StringVar(&exportFrom, "from", "", "Only events on or after this date")
+	exportCmd.Flags().StringVar(&exportTo, "to", "", "Only events on or before this date")
+	exportCmd.Flags().IntVar(&exportLimit, "limit", 1000, "Maximum number of events")
+}
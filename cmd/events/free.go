@@ -0,0 +1,67 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
+	"github.com/spf13/cobra"
+)
+
+var (
+	freeDate     string
+	freeDuration string
+	freeBetween  string
+	freeAttendee string
+)
+
+var freeCmd = &cobra.Command{
+	Use:   "free",
+	Short: "Find free time slots",
+	Long: `List the gaps between events on a day that are long enough for a meeting.
+The search covers your working hours (working_hours in the config, 09:00-17:00
+by default) unless --between is given. With --attendee only that attendee's
+events count as busy.`,
+	Example: `  # 30-minute slots today
+  notion-cli events free
+
+  # An hour with Alice next Tuesday afternoon
+  notion-cli events free --date "next tuesday" --duration 1h --between 13:00-18:00 --attendee Alice`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		cfg := cmd.GetConfig()
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		if cfg.EventsDatabaseID == "" {
+			return output.Error(fmt.Errorf("events database ID is required"))
+		}
+
+		between := freeBetween
+		if between == "" {
+			between = cfg.WorkingHours
+		}
+
+		slots, err := client.FindFreeSlots(ctx, cfg.EventsDatabaseID, notioncli.FreeSlotOptions{
+			Date:     freeDate,
+			Duration: freeDuration,
+			Between:  between,
+			Attendee: freeAttendee,
+		})
+		if err != nil {
+			return output.Error(err)
+		}
+
+		return output.JSON(slots)
+	},
+}
+
+func init() {
+	EventsCmd.AddCommand(freeCmd)
+
+	freeCmd.Flags().StringVar(&freeDate, "date", "today", "Day to search")
+	freeCmd.Flags().StringVar(&freeDuration, "duration", "30m", "Minimum length of a slot")
+	freeCmd.Flags().StringVar(&freeBetween, "between", "", "Hours to search, e.g. 09:00-17:00 (default: working_hours from config)")
+	freeCmd.Flags().StringVar(&freeAttendee, "attendee", "", "Only consider events with this attendee")
+}
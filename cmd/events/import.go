@@ -0,0 +1,67 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var importFile string
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import events from an iCalendar file",
+	Long: `Create or update events from an iCalendar (.ics) file. Events are matched by
+UID: files exported with 'events export' update the events they came from, and
+UIDs from other calendars are remembered in the UID property (if the database
+has one) so importing the same file again updates instead of duplicating.`,
+	Example: `  notion-cli events import --file calendar.ics
+
+  # From stdin
+  curl -s https://example.com/team.ics | notion-cli events import --file -`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		cfg := cmd.GetConfig()
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		if cfg.EventsDatabaseID == "" {
+			return output.Error(fmt.Errorf("events database ID is required"))
+		}
+		if importFile == "" {
+			return output.Error(fmt.Errorf("file is required"))
+		}
+
+		var r io.Reader = os.Stdin
+		if importFile != "-" {
+			f, err := os.Open(importFile)
+			if err != nil {
+				return output.Error(fmt.Errorf("failed to open file: %w", err))
+			}
+			defer f.Close()
+			r = f
+		}
+
+		results, err := client.ImportICS(ctx, cfg.EventsDatabaseID, r)
+		if results != nil {
+			if jsonErr := output.JSON(results); jsonErr != nil {
+				return jsonErr
+			}
+		}
+		if err != nil {
+			return output.Error(err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	EventsCmd.AddCommand(importCmd)
+
+	importCmd.Flags().StringVar(&importFile, "file", "", "iCalendar file to import, or - for stdin (required)")
+	importCmd.MarkFlagRequired("file")
+}
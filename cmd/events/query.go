@@ -5,14 +5,16 @@ import (
 	"fmt"
 
 	"github.com/jontk/notion-cli/cmd"
-	"github.com/jontk/notion-cli/internal/notion"
 	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
 	"github.com/spf13/cobra"
 )
 
 var (
 	queryType   string
 	queryStatus string
+	queryFrom   string
+	queryTo     string
 	queryLimit  int
 )
 
@@ -29,21 +31,27 @@ var queryCmd = &cobra.Command{
   # Scheduled events
   notion-cli events query --status "Scheduled"
 
+  # Events in a date range
+  notion-cli events query --from "today" --to "+2w"
+  notion-cli events query --from "2026-W43" --to "2026-W44-7"
+
   # Limited results
   notion-cli events query --limit 20`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		cfg := cmd.GetConfig()
-		client := notion.NewClient(cfg.APIToken)
+		client := cmd.NewClient()
 		ctx := context.Background()
 
 		if cfg.EventsDatabaseID == "" {
 			return output.Error(fmt.Errorf("events database ID is required"))
 		}
 
-		opts := notion.EventQueryOptions{
-			Type:   queryType,
-			Status: queryStatus,
-			Limit:  queryLimit,
+		opts := notioncli.EventQueryOptions{
+			Type:       queryType,
+			Status:     queryStatus,
+			DateAfter:  queryFrom,
+			DateBefore: queryTo,
+			Limit:      queryLimit,
 		}
 
 		events, err := client.QueryEvents(ctx, cfg.EventsDatabaseID, opts)
@@ -60,5 +68,7 @@ func init() {
 
 	queryCmd.Flags().StringVar(&queryType, "type", "", "Filter by event type")
 	queryCmd.Flags().StringVar(&queryStatus, "status", "", "Filter by status (Scheduled, Completed, Cancelled)")
+	queryCmd.Flags().StringVar(&queryFrom, "from", "", "Only events on or after this date (YYYY-MM-DD, 'today', 'monday', ...)")
+	queryCmd.Flags().StringVar(&queryTo, "to", "", "Only events on or before this date (YYYY-MM-DD, 'eow', '+2w', ...)")
 	queryCmd.Flags().IntVar(&queryLimit, "limit", 100, "Maximum number of results")
 }
@@ -0,0 +1,124 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/feed"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr  string
+	serveName  string
+	serveFrom  string
+	serveTo    string
+	serveCache time.Duration
+	serveLimit int
+)
+
+var serveICSCmd = &cobra.Command{
+	Use:   "serve-ics",
+	Short: "Serve events as a subscribable iCalendar feed",
+	Long: `Run a local HTTP server that serves your Notion events as an iCalendar feed.
+Calendar applications can subscribe to the feed URL and will pick up changes
+on their next refresh.
+
+The feed is generated from the events database on request and cached for
+--cache. Responses carry an ETag, so unchanged feeds are answered with
+304 Not Modified. Add type and status query parameters to the URL to
+subscribe to part of the calendar.`,
+	Example: `  notion-cli events serve-ics --addr :8088
+
+  # Subscribe to:
+  #   http://localhost:8088/events.ics
+  #   http://localhost:8088/events.ics?type=Work
+  #   http://localhost:8088/events.ics?status=Scheduled`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		cfg := cmd.GetConfig()
+		client := cmd.NewClient()
+
+		if cfg.EventsDatabaseID == "" {
+			return output.Error(fmt.Errorf("events database ID is required"))
+		}
+
+		render := func(ctx context.Context, filter feed.Filter) ([]byte, error) {
+			events, err := client.QueryEvents(ctx, cfg.EventsDatabaseID, notioncli.EventQueryOptions{
+				Type:       filter.Type,
+				Status:     filter.Status,
+				DateAfter:  serveFrom,
+				DateBefore: serveTo,
+				Limit:      serveLimit,
+			})
+			if err != nil {
+				return nil, err
+			}
+			var buf bytes.Buffer
+			if err := client.WriteICS(&buf, serveName, events); err != nil {
+				return nil, err
+			}
+			return buf.Bytes(), nil
+		}
+
+		logger := log.New(os.Stderr, "serve-ics: ", log.LstdFlags)
+		server := &http.Server{
+			Addr:              serveAddr,
+			Handler:           feed.NewHandler(render, serveCache, logger),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- server.ListenAndServe()
+		}()
+		logger.Printf("serving %s on http://%s/events.ics", serveName, displayAddr(serveAddr))
+
+		select {
+		case err := <-errCh:
+			if !errors.Is(err, http.ErrServerClosed) {
+				return output.Error(fmt.Errorf("feed server failed: %w", err))
+			}
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := server.Shutdown(shutdownCtx); err != nil {
+				return output.Error(fmt.Errorf("failed to stop feed server: %w", err))
+			}
+		}
+		return nil
+	},
+}
+
+// displayAddr turns a listen address such as ":8088" into a host clients
+// can use
+func displayAddr(addr string) string {
+	if len(addr) > 0 && addr[0] == ':' {
+		return "localhost" + addr
+	}
+	return addr
+}
+
+func init() {
+	EventsCmd.AddCommand(serveICSCmd)
+
+	serveICSCmd.Flags().StringVar(&serveAddr, "addr", ":8088", "Address to listen on")
+	serveICSCmd.Flags().StringVar(&serveName, "name", "Notion Events", "Calendar name shown by calendar applications")
+	serveICSCmd.Flags().StringVar(&serveFrom, "from", "", "Only serve events on or after this date, e.g. -3mo (resolved on every request)")
+	serveICSCmd.Flags().StringVar(&serveTo, "to", "", "Only serve events on or before this date")
+	serveICSCmd.Flags().DurationVar(&serveCache, "cache", 5*time.Minute, "How long a generated feed is reused")
+	serveICSCmd.Flags().IntVar(&serveLimit, "limit", 2000, "Maximum number of events per feed")
+}
@@ -0,0 +1,50 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	skipID   string
+	skipDate string
+)
+
+var skipCmd = &cobra.Command{
+	Use:   "skip",
+	Short: "Skip one occurrence of a recurring event",
+	Long: `Add an exception for one day to a recurring event. The occurrence for that day
+is archived if it was already created and is not created again by expand.`,
+	Example: `  notion-cli events skip --id "EVENT_ID" --date "2024-12-24"`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		if skipID == "" {
+			return output.Error(fmt.Errorf("event ID is required"))
+		}
+		if skipDate == "" {
+			return output.Error(fmt.Errorf("date is required"))
+		}
+
+		event, err := client.SkipOccurrence(ctx, skipID, skipDate)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		return output.JSON(event)
+	},
+}
+
+func init() {
+	EventsCmd.AddCommand(skipCmd)
+
+	skipCmd.Flags().StringVar(&skipID, "id", "", "Recurring event ID (required)")
+	skipCmd.Flags().StringVar(&skipDate, "date", "", "Day of the occurrence to skip (required)")
+	skipCmd.MarkFlagRequired("id")
+	skipCmd.MarkFlagRequired("date")
+}
@@ -8,22 +8,26 @@ import (
 	"os"
 
 	"github.com/jontk/notion-cli/cmd"
-	"github.com/jontk/notion-cli/internal/models"
-	"github.com/jontk/notion-cli/internal/notion"
 	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
 	"github.com/spf13/cobra"
 )
 
 var (
-	updateID        string
-	updateTitle     string
-	updateDate      string
-	updateType      string
-	updateLocation  string
-	updateAttendees []string
-	updateStatus    string
-	updateNotes     string
-	updateStdin     bool
+	updateID            string
+	updateTitle         string
+	updateDate          string
+	updateEnd           string
+	updateDuration      string
+	updateAllDay        bool
+	updateType          string
+	updateLocation      string
+	updateAttendees     []string
+	updateStatus        string
+	updateNotes         string
+	updateRepeat        string
+	updateStdin         bool
+	updateAllowConflict bool
 )
 
 var updateCmd = &cobra.Command{
@@ -36,18 +40,21 @@ var updateCmd = &cobra.Command{
   # Update date and location
   notion-cli events update --id "EVENT_ID" --date "2024-03-26 15:00" --location "Room B"
 
+  # Extend an event
+  notion-cli events update --id "EVENT_ID" --duration 90m
+
   # Update from stdin
   echo '{"status":"Cancelled"}' | notion-cli events update --id "EVENT_ID" --stdin`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		cfg := cmd.GetConfig()
-		client := notion.NewClient(cfg.APIToken)
+		client := cmd.NewClient()
 		ctx := context.Background()
 
 		if updateID == "" {
 			return output.Error(fmt.Errorf("event ID is required"))
 		}
 
-		var input models.EventInput
+		var input notioncli.EventInput
 
 		if updateStdin {
 			data, err := io.ReadAll(os.Stdin)
@@ -58,7 +65,7 @@ var updateCmd = &cobra.Command{
 				return output.Error(fmt.Errorf("failed to parse JSON: %w", err))
 			}
 		} else {
-			input = models.EventInput{}
+			input = notioncli.EventInput{}
 			hasChanges := false
 
 			if cobraCmd.Flags().Changed("title") {
@@ -69,6 +76,18 @@ var updateCmd = &cobra.Command{
 				input.Date = updateDate
 				hasChanges = true
 			}
+			if cobraCmd.Flags().Changed("end") {
+				input.End = updateEnd
+				hasChanges = true
+			}
+			if cobraCmd.Flags().Changed("duration") {
+				input.Duration = updateDuration
+				hasChanges = true
+			}
+			if cobraCmd.Flags().Changed("all-day") {
+				input.AllDay = updateAllDay
+				hasChanges = true
+			}
 			if cobraCmd.Flags().Changed("type") {
 				input.Type = updateType
 				hasChanges = true
@@ -89,12 +108,27 @@ var updateCmd = &cobra.Command{
 				input.Notes = updateNotes
 				hasChanges = true
 			}
+			if cobraCmd.Flags().Changed("repeat") {
+				input.Repeat = updateRepeat
+				hasChanges = true
+			}
 
 			if !hasChanges {
 				return output.Error(fmt.Errorf("no fields specified for update"))
 			}
 		}
 
+		timingChanged := input.Date != "" || input.End != "" || input.Duration != "" || input.AllDay
+		if timingChanged && !updateAllowConflict && cfg.EventsDatabaseID != "" {
+			conflicts, err := client.CheckConflicts(ctx, cfg.EventsDatabaseID, updateID, input)
+			if err != nil {
+				return output.Error(err)
+			}
+			if len(conflicts) > 0 {
+				return output.Error(&notioncli.ConflictError{Conflicts: conflicts})
+			}
+		}
+
 		event, err := client.UpdateEvent(ctx, updateID, input)
 		if err != nil {
 			return output.Error(err)
@@ -109,12 +143,17 @@ func init() {
 
 	updateCmd.Flags().StringVar(&updateID, "id", "", "Event ID (required)")
 	updateCmd.Flags().StringVar(&updateTitle, "title", "", "New event title")
-	updateCmd.Flags().StringVar(&updateDate, "date", "", "New event date and time (YYYY-MM-DD HH:MM)")
+	updateCmd.Flags().StringVar(&updateDate, "date", "", "New event date and time (YYYY-MM-DD HH:MM, RFC 3339, 'tomorrow 14:00', ...)")
+	updateCmd.Flags().StringVar(&updateEnd, "end", "", "New end time (HH:MM) or end date/time")
+	updateCmd.Flags().StringVar(&updateDuration, "duration", "", "New event length, e.g. 30m, 1h30m, 2d")
+	updateCmd.Flags().BoolVar(&updateAllDay, "all-day", false, "Make this an all-day event")
 	updateCmd.Flags().StringVar(&updateType, "type", "", "New event type")
 	updateCmd.Flags().StringVar(&updateLocation, "location", "", "New location")
 	updateCmd.Flags().StringSliceVar(&updateAttendees, "attendees", []string{}, "New attendees")
 	updateCmd.Flags().StringVar(&updateStatus, "status", "", "New status")
 	updateCmd.Flags().StringVar(&updateNotes, "notes", "", "New notes")
+	updateCmd.Flags().StringVar(&updateRepeat, "repeat", "", "New recurrence rule (RRULE)")
+	updateCmd.Flags().BoolVar(&updateAllowConflict, "allow-conflict", false, "Move the event even if it then overlaps other events")
 	updateCmd.Flags().BoolVar(&updateStdin, "stdin", false, "Read EventInput JSON from stdin")
 
 	updateCmd.MarkFlagRequired("id")
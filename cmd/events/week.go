@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/jontk/notion-cli/cmd"
-	"github.com/jontk/notion-cli/internal/notion"
 	"github.com/jontk/notion-cli/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -13,10 +12,10 @@ import (
 var weekCmd = &cobra.Command{
 	Use:   "week",
 	Short: "Show this week's events",
-	Long:  `Show all events scheduled for the current week (Monday-Sunday).`,
+	Long:  `Show all events scheduled for the current week (Monday-Sunday), including multi-day events that overlap it.`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		cfg := cmd.GetConfig()
-		client := notion.NewClient(cfg.APIToken)
+		client := cmd.NewClient()
 		ctx := context.Background()
 
 		if cfg.EventsDatabaseID == "" {
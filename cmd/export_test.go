@@ -0,0 +1,11 @@
+package cmd
+
+import "github.com/jontk/notion-cli/pkg/notioncli"
+
+// SetClientOptions sets the options every command's Notion client is
+// created with and returns a func restoring the previous ones
+func SetClientOptions(opts ...notioncli.Option) func() {
+	prev := clientOptions
+	clientOptions = opts
+	return func() { clientOptions = prev }
+}
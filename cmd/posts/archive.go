@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/jontk/notion-cli/cmd"
-	"github.com/jontk/notion-cli/internal/notion"
 	"github.com/jontk/notion-cli/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -17,8 +16,7 @@ var archiveCmd = &cobra.Command{
 	Short: "Archive a post",
 	Long:  `Archive a post in your Notion database.`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
-		cfg := cmd.GetConfig()
-		client := notion.NewClient(cfg.APIToken)
+		client := cmd.NewClient()
 		ctx := context.Background()
 
 		if archiveID == "" {
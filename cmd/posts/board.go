@@ -0,0 +1,97 @@
+package posts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/board"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
+	"github.com/spf13/cobra"
+)
+
+var (
+	boardPillar   string
+	boardLimit    int
+	boardMaxCards int
+	boardWidth    int
+	boardASCII    bool
+)
+
+var boardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "Show the content pipeline as a kanban board",
+	Long: `Show posts in one column per status, in the order of the Status options in
+Notion (Idea, Outline, Draft, ...). Each column shows its card count, and
+columns over their WIP limit (wip_limits.posts in the config) are flagged.
+
+Use --output json to get the columns as JSON instead of the drawn board.`,
+	Example: `  notion-cli posts board
+
+  # One pillar only
+  notion-cli posts board --pillar "Go Tools"`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		cfg := cmd.GetConfig()
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		if cfg.DatabaseID == "" {
+			return output.Error(fmt.Errorf("database ID is required. Set NOTION_DATABASE_ID or run 'notion-cli config init'"))
+		}
+
+		order, err := client.StatusOptions(ctx, cfg.DatabaseID)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		posts, err := client.QueryPosts(ctx, cfg.DatabaseID, notioncli.QueryOptions{
+			Pillar: boardPillar,
+			Sort:   "last_edited_time",
+			Order:  "descending",
+			Limit:  boardLimit,
+		})
+		if err != nil {
+			return output.Error(err)
+		}
+
+		cards := make([]board.Card, 0, len(posts))
+		for _, p := range posts {
+			var details []string
+			for _, d := range []string{p.Pillar, p.PublishDate} {
+				if d != "" {
+					details = append(details, d)
+				}
+			}
+			cards = append(cards, board.Card{
+				ID:      p.ID,
+				Title:   p.Title,
+				Details: details,
+				Status:  p.Status,
+			})
+		}
+
+		b := board.Build("Posts", order, cards, cfg.WIPLimits["posts"], nil)
+
+		if cobraCmd.Flags().Changed("output") && strings.ToLower(cmd.GetOutputFormat()) == "json" {
+			return output.JSON(b)
+		}
+		width := boardWidth
+		if width <= 0 {
+			width = board.DefaultWidth()
+		}
+		return b.Write(os.Stdout, board.Options{Width: width, MaxCards: boardMaxCards, ASCII: boardASCII})
+	},
+}
+
+func init() {
+	PostsCmd.AddCommand(boardCmd)
+
+	boardCmd.Flags().StringVar(&boardPillar, "pillar", "", "Only posts in this content pillar")
+	boardCmd.Flags().IntVar(&boardLimit, "limit", 500, "Maximum number of posts to load")
+	boardCmd.Flags().IntVar(&boardMaxCards, "max-cards", 10, "Cards shown per column before the rest are counted (0 for all)")
+	boardCmd.Flags().IntVar(&boardWidth, "width", 0, "Board width in characters (default: $COLUMNS or 120)")
+	boardCmd.Flags().BoolVar(&boardASCII, "ascii", false, "Draw with plain ASCII characters")
+}
@@ -8,9 +8,8 @@ import (
 	"os"
 
 	"github.com/jontk/notion-cli/cmd"
-	"github.com/jontk/notion-cli/internal/models"
-	"github.com/jontk/notion-cli/internal/notion"
 	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
 	"github.com/spf13/cobra"
 )
 
@@ -46,10 +45,10 @@ var createCmd = &cobra.Command{
     notion-cli posts create --stdin`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		cfg := cmd.GetConfig()
-		client := notion.NewClient(cfg.APIToken)
+		client := cmd.NewClient()
 		ctx := context.Background()
 
-		var input models.PostInput
+		var input notioncli.PostInput
 
 		if createStdin {
 			data, err := io.ReadAll(os.Stdin)
@@ -63,7 +62,7 @@ var createCmd = &cobra.Command{
 			if createTitle == "" {
 				return output.Error(fmt.Errorf("title is required"))
 			}
-			input = models.PostInput{
+			input = notioncli.PostInput{
 				Title:         createTitle,
 				Content:       createContent,
 				Status:        createStatus,
@@ -100,7 +99,7 @@ func init() {
 	createCmd.Flags().StringVar(&createStatus, "status", "", "Status: Idea, Outline, Draft, Review, Published, Distributed (default: Draft)")
 	createCmd.Flags().IntVar(&createWeek, "week", 0, "Week number in the content calendar (1-12)")
 	createCmd.Flags().StringVar(&createPillar, "pillar", "", "Content pillar: 'SLURM & HPC', 'Go Tools', 'Infrastructure', 'Career & AI'")
-	createCmd.Flags().StringVar(&createDueDate, "due-date", "", "Target publish date in YYYY-MM-DD format")
+	createCmd.Flags().StringVar(&createDueDate, "due-date", "", "Target publish date (YYYY-MM-DD, 'next monday', '+2w', ...)")
 	createCmd.Flags().StringSliceVar(&createDistributedTo, "distributed-to", []string{}, "Platforms distributed to: LinkedIn,Twitter,Dev.to,Hacker News,Reddit")
 	createCmd.Flags().StringSliceVar(&createHashtags, "hashtags", []string{}, "Hashtags (comma-separated)")
 	createCmd.Flags().BoolVar(&createStdin, "stdin", false, "Read PostInput JSON from stdin (for AI/automation workflows)")
@@ -5,8 +5,8 @@ import (
 	"fmt"
 
 	"github.com/jontk/notion-cli/cmd"
-	"github.com/jontk/notion-cli/internal/notion"
 	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
 	"github.com/spf13/cobra"
 )
 
@@ -44,14 +44,14 @@ var queryCmd = &cobra.Command{
   notion-cli posts query --sort "last_edited_time" --order "descending"`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		cfg := cmd.GetConfig()
-		client := notion.NewClient(cfg.APIToken)
+		client := cmd.NewClient()
 		ctx := context.Background()
 
 		if cfg.DatabaseID == "" {
 			return output.Error(fmt.Errorf("database ID is required. Set NOTION_DATABASE_ID or run 'notion-cli config init'"))
 		}
 
-		opts := notion.QueryOptions{
+		opts := notioncli.QueryOptions{
 			Status:        queryStatus,
 			Pillar:        queryPillar,
 			DistributedTo: queryDistributedTo,
@@ -8,9 +8,8 @@ import (
 	"os"
 
 	"github.com/jontk/notion-cli/cmd"
-	"github.com/jontk/notion-cli/internal/models"
-	"github.com/jontk/notion-cli/internal/notion"
 	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
 	"github.com/spf13/cobra"
 )
 
@@ -56,15 +55,14 @@ var updateCmd = &cobra.Command{
   # Update from stdin
   echo '{"status":"Review"}' | notion-cli posts update --id "PAGE_ID" --stdin`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
-		cfg := cmd.GetConfig()
-		client := notion.NewClient(cfg.APIToken)
+		client := cmd.NewClient()
 		ctx := context.Background()
 
 		if updateID == "" {
 			return output.Error(fmt.Errorf("post ID is required"))
 		}
 
-		var input models.PostInput
+		var input notioncli.PostInput
 
 		if updateStdin {
 			data, err := io.ReadAll(os.Stdin)
@@ -75,7 +73,7 @@ var updateCmd = &cobra.Command{
 				return output.Error(fmt.Errorf("failed to parse JSON: %w", err))
 			}
 		} else {
-			input = models.PostInput{}
+			input = notioncli.PostInput{}
 			hasChanges := false
 
 			if cobraCmd.Flags().Changed("title") {
@@ -162,11 +160,11 @@ func init() {
 	updateCmd.Flags().StringVar(&updateStatus, "status", "", "Status: Idea, Outline, Draft, Review, Published, Distributed")
 	updateCmd.Flags().IntVar(&updateWeek, "week", 0, "Week number in the content calendar")
 	updateCmd.Flags().StringVar(&updatePillar, "pillar", "", "Content pillar")
-	updateCmd.Flags().StringVar(&updatePublishDate, "publish-date", "", "Target publish date (YYYY-MM-DD)")
-	updateCmd.Flags().StringVar(&updatePublishedDate, "published-date", "", "Actual publish date (YYYY-MM-DD)")
+	updateCmd.Flags().StringVar(&updatePublishDate, "publish-date", "", "Target publish date (YYYY-MM-DD, 'next monday', '+2w', ...)")
+	updateCmd.Flags().StringVar(&updatePublishedDate, "published-date", "", "Actual publish date (YYYY-MM-DD, 'today', ...)")
 	updateCmd.Flags().StringVar(&updateBlogURL, "blog-url", "", "Blog post URL")
 	updateCmd.Flags().StringSliceVar(&updateDistributedTo, "distributed-to", []string{}, "Platforms distributed to (comma-separated)")
-	updateCmd.Flags().StringVar(&updateDistributedDate, "distributed-date", "", "Distribution date (YYYY-MM-DD)")
+	updateCmd.Flags().StringVar(&updateDistributedDate, "distributed-date", "", "Distribution date (YYYY-MM-DD, 'today', ...)")
 	updateCmd.Flags().StringVar(&updateLinkedInDraft, "linkedin-draft", "", "LinkedIn post draft")
 	updateCmd.Flags().StringVar(&updateTwitterThread, "twitter-thread", "", "Twitter thread draft")
 	updateCmd.Flags().StringVar(&updateHNTitle, "hn-title", "", "Hacker News title")
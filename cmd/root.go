@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/jontk/notion-cli/internal/config"
 	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -15,6 +18,10 @@ var (
 	outputFormat string
 	cfg          *config.Config
 	version      = "0.3.0"
+
+	// clientOptions are passed to every Notion client the commands create;
+	// tests use them to point the commands at a fake server
+	clientOptions []notioncli.Option
 )
 
 var RootCmd = &cobra.Command{
@@ -39,7 +46,9 @@ func Execute() error {
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.notion-cli.yaml)")
-	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "json", "output format (json|table)")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "json", "output format (json|table|markdown)")
+	rootCmd.PersistentFlags().String("tz", "", "timezone for reading and displaying dates, e.g. Europe/London (default is the configured or system timezone)")
+	viper.BindPFlag("timezone", rootCmd.PersistentFlags().Lookup("tz"))
 }
 
 func initConfig() error {
@@ -77,6 +86,38 @@ func GetConfig() *config.Config {
 	return cfg
 }
 
+// NewClient returns a Notion client configured from the loaded config
+func NewClient() *notioncli.Client {
+	opts := []notioncli.Option{
+		notioncli.WithLocation(cfg.Location()),
+		notioncli.WithSettings(notioncli.Settings{
+			RepeatProperty:    cfg.RecurrenceProperty,
+			SeriesProperty:    cfg.SeriesProperty,
+			DefaultTaskStatus: cfg.DefaultTaskStatus,
+			UIDProperty:       cfg.UIDProperty,
+			ParentProperty:    cfg.ParentProperty,
+			BlockedByProperty: cfg.BlockedByProperty,
+			AssigneeProperty:  cfg.AssigneeProperty,
+			Me:                cfg.Me,
+			TaskStatuses:      notioncli.StatusGroups(cfg.TaskStatuses),
+			EventStatuses:     notioncli.StatusGroups(cfg.EventStatuses),
+		}),
+		notioncli.WithUserCache(userCachePath(), cfg.UserCacheTTL),
+	}
+	return notioncli.New(cfg.APIToken, append(opts, clientOptions...)...)
+}
+
+// userCachePath returns where the workspace user list is cached. The file is
+// named after the token so that different workspaces don't share it.
+func userCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(cfg.APIToken))
+	return filepath.Join(dir, "notion-cli", fmt.Sprintf("users-%x.json", sum[:8]))
+}
+
 func GetOutputFormat() string {
 	return outputFormat
 }
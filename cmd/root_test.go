@@ -0,0 +1,751 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jontk/notion-cli/cmd"
+	_ "github.com/jontk/notion-cli/cmd/agenda"
+	_ "github.com/jontk/notion-cli/cmd/blocks"
+	_ "github.com/jontk/notion-cli/cmd/codegen"
+	_ "github.com/jontk/notion-cli/cmd/config"
+	_ "github.com/jontk/notion-cli/cmd/databases"
+	_ "github.com/jontk/notion-cli/cmd/events"
+	_ "github.com/jontk/notion-cli/cmd/posts"
+	_ "github.com/jontk/notion-cli/cmd/search"
+	_ "github.com/jontk/notion-cli/cmd/tasks"
+	_ "github.com/jontk/notion-cli/cmd/tui"
+	_ "github.com/jontk/notion-cli/cmd/users"
+	"github.com/jontk/notion-cli/internal/notiontest"
+	"github.com/jontk/notion-cli/pkg/notioncli"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const testToken = "secret_test"
+
+// workspace is a fake Notion workspace with the three databases the CLI
+// works with and a config file pointing at them
+type workspace struct {
+	srv    *notiontest.Server
+	tasks  string
+	events string
+	posts  string
+	config string
+}
+
+func newWorkspace(t *testing.T) *workspace {
+	t.Helper()
+	srv := notiontest.NewServer()
+	srv.Token = testToken
+	w := &workspace{
+		srv:    srv,
+		tasks:  srv.AddDatabase("Tasks", notiontest.TasksSchema()),
+		events: srv.AddDatabase("Events", notiontest.EventsSchema()),
+		posts:  srv.AddDatabase("Posts", notiontest.PostsSchema()),
+	}
+
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(dir, "cache"))
+	w.config = filepath.Join(dir, "config.yaml")
+	config := fmt.Sprintf("api_token: %s\ndatabase_id: %s\ntasks_database_id: %s\nevents_database_id: %s\ntimezone: UTC\n",
+		testToken, w.posts, w.tasks, w.events)
+	if err := os.WriteFile(w.config, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(cmd.SetClientOptions(notioncli.WithHTTPClient(srv.HTTPClient())))
+	return w
+}
+
+// run executes the CLI with the given arguments and returns what it wrote
+// to stdout and stderr
+func (w *workspace) run(t *testing.T, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+	resetFlags(cmd.RootCmd)
+	cmd.RootCmd.SetArgs(append([]string{"--config", w.config}, args...))
+
+	stdout, stderr = capture(t, func() {
+		err = cmd.Execute()
+	})
+	return stdout, stderr, err
+}
+
+// mustRun is run for commands expected to succeed; it decodes their JSON
+// output into v
+func (w *workspace) mustRun(t *testing.T, v any, args ...string) {
+	t.Helper()
+	stdout, stderr, err := w.run(t, args...)
+	if err != nil {
+		t.Fatalf("notion-cli %s: %v\nstderr: %s", strings.Join(args, " "), err, stderr)
+	}
+	if err := json.Unmarshal([]byte(stdout), v); err != nil {
+		t.Fatalf("notion-cli %s printed %q: %v", strings.Join(args, " "), stdout, err)
+	}
+}
+
+// capture runs f with os.Stdout and os.Stderr redirected to pipes
+func capture(t *testing.T, f func()) (string, string) {
+	t.Helper()
+	read := func(target **os.File) (func() string, error) {
+		r, w, err := os.Pipe()
+		if err != nil {
+			return nil, err
+		}
+		orig := *target
+		*target = w
+		done := make(chan string)
+		go func() {
+			data, _ := io.ReadAll(r)
+			done <- string(data)
+		}()
+		return func() string {
+			*target = orig
+			w.Close()
+			return <-done
+		}, nil
+	}
+
+	stdout, err := read(&os.Stdout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr, err := read(&os.Stderr)
+	if err != nil {
+		stdout()
+		t.Fatal(err)
+	}
+	f()
+	return stdout(), stderr()
+}
+
+// resetFlags puts every flag back to its default. Cobra keeps flag values
+// in package variables, so they would otherwise leak between runs.
+func resetFlags(c *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if s, ok := f.Value.(pflag.SliceValue); ok {
+			s.Replace(nil)
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	c.Flags().VisitAll(reset)
+	c.PersistentFlags().VisitAll(reset)
+	for _, sub := range c.Commands() {
+		resetFlags(sub)
+	}
+}
+
+type task struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Status   string   `json:"status"`
+	Priority string   `json:"priority"`
+	DueDate  string   `json:"due_date"`
+	Tags     []string `json:"tags"`
+}
+
+func TestTasksCommands(t *testing.T) {
+	w := newWorkspace(t)
+
+	var created task
+	w.mustRun(t, &created, "tasks", "create", "--title", "Write report", "--priority", "High",
+		"--due", "2026-10-20", "--tags", "urgent,review")
+	if created.Title != "Write report" || created.Status != "Todo" || created.Priority != "High" ||
+		created.DueDate != "2026-10-20" || strings.Join(created.Tags, ",") != "urgent,review" {
+		t.Errorf("tasks create printed %+v", created)
+	}
+
+	var updated task
+	w.mustRun(t, &updated, "tasks", "update", "--id", created.ID, "--status", "In Progress")
+	if updated.Status != "In Progress" || updated.Priority != "High" {
+		t.Errorf("tasks update printed %+v", updated)
+	}
+
+	var got task
+	w.mustRun(t, &got, "tasks", "get", "--id", created.ID)
+	if got.ID != created.ID || got.Status != "In Progress" {
+		t.Errorf("tasks get printed %+v", got)
+	}
+
+	var other task
+	w.mustRun(t, &other, "tasks", "create", "--title", "Buy milk", "--priority", "Low")
+
+	var tasks []task
+	w.mustRun(t, &tasks, "tasks", "query", "--priority", "High")
+	if len(tasks) != 1 || tasks[0].ID != created.ID {
+		t.Errorf("tasks query --priority High printed %+v", tasks)
+	}
+}
+
+func TestEventsCommands(t *testing.T) {
+	w := newWorkspace(t)
+
+	var created struct {
+		ID              string `json:"id"`
+		Start           string `json:"start"`
+		DurationMinutes int    `json:"duration_minutes"`
+	}
+	w.mustRun(t, &created, "events", "create", "--title", "Planning", "--date", "2026-10-20 09:30",
+		"--duration", "1h", "--type", "Meeting")
+	if created.Start != "2026-10-20T09:30:00Z" || created.DurationMinutes != 60 {
+		t.Errorf("events create printed %+v", created)
+	}
+
+	var events []struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	}
+	w.mustRun(t, &events, "events", "query", "--from", "2026-10-20", "--to", "2026-10-20")
+	if len(events) != 1 || events[0].ID != created.ID {
+		t.Errorf("events query printed %+v", events)
+	}
+}
+
+func TestEventsConflictCommands(t *testing.T) {
+	w := newWorkspace(t)
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	w.mustRun(t, &created, "events", "create", "--title", "Standup", "--date", "2026-10-20 09:00",
+		"--duration", "30m", "--attendees", "Ada")
+
+	_, stderr, err := w.run(t, "events", "create", "--title", "Pairing", "--date", "2026-10-20 09:15",
+		"--duration", "45m", "--attendees", "Ada,Grace")
+	if err == nil || !strings.Contains(stderr, "--allow-conflict") {
+		t.Fatalf("overlapping events create: %v\n%s", err, stderr)
+	}
+	w.mustRun(t, &created, "events", "create", "--title", "Review", "--date", "2026-10-20 09:15",
+		"--duration", "45m", "--attendees", "Grace")
+	w.mustRun(t, &created, "events", "create", "--title", "Pairing", "--date", "2026-10-20 09:15",
+		"--duration", "45m", "--attendees", "Ada", "--allow-conflict")
+
+	var report []struct {
+		Attendee  string `json:"attendee"`
+		Conflicts []struct {
+			Start  string `json:"start"`
+			End    string `json:"end"`
+			Events []struct {
+				Title string `json:"title"`
+			} `json:"events"`
+		} `json:"conflicts"`
+	}
+	w.mustRun(t, &report, "events", "conflicts", "--from", "2026-10-20", "--to", "2026-10-20")
+	if len(report) != 1 || report[0].Attendee != "Ada" || len(report[0].Conflicts) != 1 ||
+		report[0].Conflicts[0].Start != "2026-10-20T09:15:00Z" || report[0].Conflicts[0].End != "2026-10-20T09:30:00Z" {
+		t.Errorf("events conflicts printed %+v, want Ada's Standup and Pairing", report)
+	}
+}
+
+func TestEventsFreeCommand(t *testing.T) {
+	w := newWorkspace(t)
+	w.srv.AddPage(w.events, map[string]any{"Title": "Standup", "Date": "2030-06-03T09:00:00Z"})
+
+	type slot struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+	}
+	var slots []slot
+	w.mustRun(t, &slots, "events", "free", "--date", "2030-06-03", "--duration", "1h")
+	if len(slots) != 1 || slots[0].Start != "2030-06-03T09:30:00Z" || slots[0].End != "2030-06-03T17:00:00Z" {
+		t.Errorf("events free in the default working hours printed %+v", slots)
+	}
+
+	f, err := os.OpenFile(w.config, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprintln(f, "working_hours: 08:00-12:00")
+	f.Close()
+
+	w.mustRun(t, &slots, "events", "free", "--date", "2030-06-03", "--duration", "1h")
+	if len(slots) != 2 || slots[0].Start != "2030-06-03T08:00:00Z" || slots[1].End != "2030-06-03T12:00:00Z" {
+		t.Errorf("events free in the configured working hours printed %+v", slots)
+	}
+	w.mustRun(t, &slots, "events", "free", "--date", "2030-06-03", "--duration", "1h", "--between", "18:00-20:00")
+	if len(slots) != 1 || slots[0].Start != "2030-06-03T18:00:00Z" || slots[0].End != "2030-06-03T20:00:00Z" {
+		t.Errorf("events free --between printed %+v, want it to override working_hours", slots)
+	}
+}
+
+func TestAgendaCommand(t *testing.T) {
+	w := newWorkspace(t)
+	w.srv.AddPage(w.events, map[string]any{"Title": "Planning", "Date": "2030-06-03T09:00:00Z", "Status": "Scheduled"})
+	w.srv.AddPage(w.events, map[string]any{"Title": "Retro", "Date": "2030-06-03T15:00:00Z", "Status": "Cancelled"})
+	w.srv.AddPage(w.events, map[string]any{"Title": "Demo", "Date": "2030-06-03T16:00:00Z", "Status": "Completed"})
+
+	events := func() []string {
+		var plan struct {
+			Days []struct {
+				Events []struct {
+					Title string `json:"title"`
+				} `json:"events"`
+			} `json:"days"`
+		}
+		w.mustRun(t, &plan, "agenda", "--from", "2030-06-03")
+		var titles []string
+		for _, e := range plan.Days[0].Events {
+			titles = append(titles, e.Title)
+		}
+		return titles
+	}
+	if got := strings.Join(events(), ","); got != "Planning,Demo" {
+		t.Errorf("agenda events = %s, want Planning,Demo without the cancelled Retro", got)
+	}
+
+	f, err := os.OpenFile(w.config, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprintln(f, "status_groups:\n  events:\n    cancelled: [Completed]")
+	f.Close()
+	if got := strings.Join(events(), ","); got != "Planning,Retro" {
+		t.Errorf("agenda events = %s, want Planning,Retro with Completed configured as cancelled", got)
+	}
+}
+
+func TestPostsCommands(t *testing.T) {
+	w := newWorkspace(t)
+
+	var created struct {
+		ID      string `json:"id"`
+		Title   string `json:"title"`
+		Content string `json:"content"`
+		Pillar  string `json:"pillar"`
+	}
+	w.mustRun(t, &created, "posts", "create", "--title", "Fakes", "--content", "Hello.", "--pillar", "Go Tools", "--status", "Draft")
+	if created.Title != "Fakes" || created.Content != "Hello." || created.Pillar != "Go Tools" {
+		t.Errorf("posts create printed %+v", created)
+	}
+
+	var posts []struct {
+		ID string `json:"id"`
+	}
+	w.mustRun(t, &posts, "posts", "query", "--status", "Draft")
+	if len(posts) != 1 || posts[0].ID != created.ID {
+		t.Errorf("posts query printed %+v", posts)
+	}
+}
+
+func TestDatabasesCommands(t *testing.T) {
+	w := newWorkspace(t)
+
+	var dbs []struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	}
+	w.mustRun(t, &dbs, "databases", "list")
+	if len(dbs) != 3 {
+		t.Errorf("databases list printed %+v, want 3 databases", dbs)
+	}
+
+	var schema struct {
+		Properties map[string]struct {
+			Type string `json:"type"`
+		} `json:"properties"`
+	}
+	w.mustRun(t, &schema, "databases", "schema", "--id", w.tasks)
+	if schema.Properties["Status"].Type != "status" || schema.Properties["Due Date"].Type != "date" {
+		t.Errorf("databases schema printed %+v", schema)
+	}
+
+	for format, want := range map[string]string{
+		"go":         "type Task struct {",
+		"typescript": "export interface Task {",
+		"table":      "PROPERTY",
+	} {
+		stdout, stderr, err := w.run(t, "databases", "schema", "--id", w.tasks, "--format", format)
+		if err != nil || !strings.Contains(stdout, want) {
+			t.Errorf("databases schema --format %s printed %q (%v, %s)", format, stdout, err, stderr)
+		}
+	}
+	if _, _, err := w.run(t, "databases", "schema", "--id", w.tasks, "--format", "xml"); err == nil {
+		t.Error("databases schema --format xml succeeded")
+	}
+}
+
+func TestDatabasesSchemaCommands(t *testing.T) {
+	w := newWorkspace(t)
+	parent := w.srv.AddWorkspacePage("Team")
+
+	var created struct {
+		ID          string   `json:"id"`
+		Title       string   `json:"title"`
+		ManualSteps []string `json:"manual_steps"`
+	}
+	w.mustRun(t, &created, "databases", "create", "--from", "../docs/schemas/tasks.yaml", "--parent", parent, "--title", "Team tasks")
+	if created.Title != "Team tasks" || len(created.ManualSteps) != 1 {
+		t.Errorf("databases create printed %+v", created)
+	}
+
+	type schema struct {
+		Properties map[string]struct {
+			Type    string `json:"type"`
+			Options []struct {
+				Name string `json:"name"`
+			} `json:"options"`
+		} `json:"properties"`
+	}
+	var s schema
+	w.mustRun(t, &s, "databases", "add-property", "--id", created.ID, "--name", "Estimate", "--type", "number")
+	if s.Properties["Estimate"].Type != "number" || s.Properties["Parent"].Type != "relation" {
+		t.Errorf("databases add-property printed %+v", s)
+	}
+
+	s = schema{}
+	w.mustRun(t, &s, "databases", "rename-property", "--id", created.ID, "--name", "Notes", "--to", "Details")
+	if _, ok := s.Properties["Notes"]; ok || s.Properties["Details"].Type != "rich_text" {
+		t.Errorf("databases rename-property printed %+v", s)
+	}
+
+	s = schema{}
+	w.mustRun(t, &s, "databases", "add-option", "--id", created.ID, "--property", "Category", "--option", "Errands", "--color", "green")
+	if got := s.Properties["Category"].Options; len(got) != 5 || got[4].Name != "Errands" {
+		t.Errorf("databases add-option left options %v", got)
+	}
+
+	s = schema{}
+	w.mustRun(t, &s, "databases", "remove-option", "--id", created.ID, "--property", "Category", "--option", "Home")
+	if got := s.Properties["Category"].Options; len(got) != 4 {
+		t.Errorf("databases remove-option left options %v", got)
+	}
+
+	if _, _, err := w.run(t, "databases", "add-option", "--id", created.ID, "--property", "Status", "--option", "Waiting"); err == nil {
+		t.Error("databases add-option on a status property succeeded")
+	}
+}
+
+func TestDatabasesPlanAndApply(t *testing.T) {
+	w := newWorkspace(t)
+	file := "../docs/schemas/notion-schema.yaml"
+
+	var changes []struct {
+		Database    string `json:"database"`
+		Action      string `json:"action"`
+		Property    string `json:"property"`
+		Option      string `json:"option"`
+		Destructive bool   `json:"destructive"`
+	}
+	w.mustRun(t, &changes, "databases", "plan", "--file", file)
+	if len(changes) == 0 {
+		t.Fatal("databases plan found no changes between the test workspace and the documented schema")
+	}
+	for _, ch := range changes {
+		if ch.Action != "add_option" || ch.Destructive {
+			t.Errorf("databases plan printed %+v, want only added options", ch)
+		}
+	}
+
+	if _, _, err := w.run(t, "databases", "plan", "--file", file, "--check"); err == nil {
+		t.Error("databases plan --check succeeded with changes pending")
+	}
+
+	var applied struct {
+		Applied []json.RawMessage `json:"applied"`
+	}
+	w.mustRun(t, &applied, "databases", "apply", "--file", file)
+	if len(applied.Applied) != len(changes) {
+		t.Errorf("databases apply made %d changes, want %d", len(applied.Applied), len(changes))
+	}
+
+	if _, stderr, err := w.run(t, "databases", "plan", "--file", file, "--check"); err != nil {
+		t.Errorf("databases plan --check after apply: %v\n%s", err, stderr)
+	}
+}
+
+func TestCodegenCommand(t *testing.T) {
+	w := newWorkspace(t)
+
+	stdout, stderr, err := w.run(t, "codegen", "--database", w.tasks, "--database", w.events, "--package", "team")
+	if err != nil {
+		t.Fatalf("codegen: %v\n%s", err, stderr)
+	}
+	for _, want := range []string{"package team", "func DecodeTask(", "func CreateEvent("} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("codegen output lacks %q", want)
+		}
+	}
+
+	file := filepath.Join(t.TempDir(), "tasks_gen.go")
+	if _, stderr, err := w.run(t, "codegen", "--database", w.tasks, "--package", "tasks", "--name", "Todo", "--file", file); err != nil {
+		t.Fatalf("codegen --file: %v\n%s", err, stderr)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil || !strings.Contains(string(data), "type Todo struct {") {
+		t.Errorf("codegen --file wrote %q (%v)", data, err)
+	}
+
+	if _, _, err := w.run(t, "codegen", "--database", w.tasks); err == nil {
+		t.Error("codegen without --package succeeded")
+	}
+}
+
+func TestBlocksCommands(t *testing.T) {
+	w := newWorkspace(t)
+	page := w.srv.AddWorkspacePage("Release plan")
+
+	type block struct {
+		ID       string  `json:"id"`
+		Type     string  `json:"type"`
+		Text     string  `json:"text"`
+		Checked  *bool   `json:"checked"`
+		Children []block `json:"children"`
+	}
+
+	var added []block
+	w.mustRun(t, &added, "blocks", "append", page, "--markdown", "## Checklist\n\n- [ ] Tag the release\n- [ ] Announce it")
+	if len(added) != 3 || added[1].Type != "to_do" {
+		t.Fatalf("appended %+v, want a heading and two to-dos", added)
+	}
+
+	var updated block
+	w.mustRun(t, &updated, "blocks", "update", added[1].ID, "--checked")
+	if updated.Checked == nil || !*updated.Checked {
+		t.Errorf("updated block = %+v, want it checked", updated)
+	}
+	if _, _, err := w.run(t, "blocks", "update", added[1].ID); err == nil {
+		t.Error("blocks update without changes succeeded")
+	}
+
+	var inserted []block
+	w.mustRun(t, &inserted, "blocks", "insert", "--after", added[0].ID, "--markdown", "Before Friday.")
+	if len(inserted) != 1 || inserted[0].Text != "Before Friday." {
+		t.Errorf("inserted %+v", inserted)
+	}
+
+	var moved block
+	w.mustRun(t, &moved, "blocks", "move", added[2].ID, "--after", inserted[0].ID)
+	var deleted block
+	w.mustRun(t, &deleted, "blocks", "delete", added[0].ID)
+
+	var blocks []block
+	w.mustRun(t, &blocks, "blocks", "list", page)
+	var got []string
+	for _, b := range blocks {
+		got = append(got, b.Text)
+	}
+	if want := "Before Friday.|Announce it|Tag the release"; strings.Join(got, "|") != want {
+		t.Errorf("blocks = %q, want %q", strings.Join(got, "|"), want)
+	}
+
+	stdout, stderr, err := w.run(t, "blocks", "list", page, "--output", "markdown")
+	if err != nil {
+		t.Fatalf("blocks list --output markdown: %v\n%s", err, stderr)
+	}
+	if want := "Before Friday.\n\n- [ ] Announce it\n- [x] Tag the release\n"; stdout != want {
+		t.Errorf("markdown = %q, want %q", stdout, want)
+	}
+
+	stdout, _, err = w.run(t, "blocks", "list", page, "--output", "table")
+	if err != nil || !strings.Contains(stdout, "[x] Tag the release") {
+		t.Errorf("table output = %q (%v)", stdout, err)
+	}
+}
+
+func TestTaskRelationCommands(t *testing.T) {
+	w := newWorkspace(t)
+	design := w.srv.AddPage(w.tasks, map[string]any{"Title": "Design", "Status": "Todo"})
+	build := w.srv.AddPage(w.tasks, map[string]any{"Title": "Build", "Status": "Todo"})
+	w.srv.AddPage(w.tasks, map[string]any{"Title": "Tests", "Status": "Todo", "Parent": build})
+
+	var linked struct {
+		BlockedBy []string `json:"blocked_by"`
+	}
+	w.mustRun(t, &linked, "tasks", "link", "--id", design, "--blocks", build)
+	if len(linked.BlockedBy) != 1 {
+		t.Errorf("tasks link printed %+v", linked)
+	}
+
+	_, stderr, err := w.run(t, "tasks", "complete", "--id", build)
+	if err == nil || !strings.Contains(stderr, `\"Design\" (Todo)`) {
+		t.Errorf("tasks complete of a blocked task: %v\n%s", err, stderr)
+	}
+	var completed task
+	w.mustRun(t, &completed, "tasks", "complete", "--id", build, "--force")
+	if completed.Status != "Done" {
+		t.Errorf("tasks complete --force printed %+v", completed)
+	}
+
+	stdout, stderr, err := w.run(t, "tasks", "tree", build, "--output", "markdown")
+	if err != nil {
+		t.Fatalf("tasks tree: %v\n%s", err, stderr)
+	}
+	if want := "- [x] Build [Done]\n  - [ ] Tests [Todo]\n"; stdout != want {
+		t.Errorf("tasks tree = %q, want %q", stdout, want)
+	}
+}
+
+func TestTaskChecklistCommands(t *testing.T) {
+	w := newWorkspace(t)
+	task := w.srv.AddPage(w.tasks, map[string]any{"Title": "Release", "Status": "Todo"})
+	w.srv.AddBlocks(task,
+		notiontest.ToDo("Tag the release", false),
+		notiontest.ToDo("Announce it", false),
+	)
+
+	type checklist struct {
+		Progress string `json:"progress"`
+		Items    []struct {
+			Index   int    `json:"index"`
+			Text    string `json:"text"`
+			Checked bool   `json:"checked"`
+		} `json:"items"`
+	}
+
+	var list checklist
+	w.mustRun(t, &list, "tasks", "checklist", task)
+	if len(list.Items) != 2 || list.Progress != "0/2" {
+		t.Fatalf("checklist = %+v, want two open items", list)
+	}
+
+	w.mustRun(t, &list, "tasks", "check", task, "--match", "announce")
+	if !list.Items[1].Checked || list.Progress != "1/2" {
+		t.Errorf("after --match: %+v", list)
+	}
+	w.mustRun(t, &list, "tasks", "check", task, "--item", "1")
+	w.mustRun(t, &list, "tasks", "check", task, "--item", "2", "--uncheck")
+	if list.Progress != "1/2" {
+		t.Errorf("progress = %q, want 1/2", list.Progress)
+	}
+	for _, args := range [][]string{
+		{"tasks", "check", task},
+		{"tasks", "check", task, "--item", "1", "--match", "Tag"},
+		{"tasks", "check", task, "--item", "0"},
+	} {
+		if _, _, err := w.run(t, args...); err == nil {
+			t.Errorf("%v succeeded", args)
+		}
+	}
+
+	stdout, stderr, err := w.run(t, "tasks", "checklist", task, "--output", "markdown")
+	if err != nil {
+		t.Fatalf("tasks checklist --output markdown: %v\n%s", err, stderr)
+	}
+	if want := "- [x] Tag the release\n- [ ] Announce it\n"; stdout != want {
+		t.Errorf("markdown = %q, want %q", stdout, want)
+	}
+
+	var got struct {
+		ChecklistProgress string `json:"checklist_progress"`
+	}
+	w.mustRun(t, &got, "tasks", "get", "--id", task)
+	if got.ChecklistProgress != "1/2" {
+		t.Errorf("tasks get checklist_progress = %q, want 1/2", got.ChecklistProgress)
+	}
+	var tasks []struct {
+		ChecklistProgress string `json:"checklist_progress"`
+	}
+	w.mustRun(t, &tasks, "tasks", "query", "--checklist")
+	if len(tasks) != 1 || tasks[0].ChecklistProgress != "1/2" {
+		t.Errorf("tasks query --checklist = %+v", tasks)
+	}
+}
+
+func TestSearchCommand(t *testing.T) {
+	w := newWorkspace(t)
+	w.srv.AddWorkspacePage("Task ideas")
+
+	var results []struct {
+		ID     string `json:"id"`
+		Object string `json:"object"`
+		Title  string `json:"title"`
+	}
+	w.mustRun(t, &results, "search", "task", "--type", "page")
+	if len(results) != 1 || results[0].Title != "Task ideas" || results[0].Object != "page" {
+		t.Errorf("search task --type page printed %+v", results)
+	}
+
+	w.mustRun(t, &results, "search", "task", "--type", "database")
+	if len(results) != 1 || results[0].ID != w.tasks {
+		t.Errorf("search task --type database printed %+v", results)
+	}
+}
+
+func TestUsersCommands(t *testing.T) {
+	w := newWorkspace(t)
+	ada := w.srv.AddUser("Ada Lovelace", "ada@example.com")
+	f, err := os.OpenFile(w.config, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprintln(f, "me: ada@example.com")
+	f.Close()
+
+	var users []struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	w.mustRun(t, &users, "users", "list")
+	found := false
+	for _, u := range users {
+		found = found || (u.ID == ada && u.Email == "ada@example.com")
+	}
+	if !found {
+		t.Errorf("users list printed %+v, want Ada", users)
+	}
+	stdout, _, err := w.run(t, "users", "list", "--output", "table")
+	if err != nil || !strings.Contains(stdout, "Ada Lovelace") || !strings.Contains(stdout, "EMAIL") {
+		t.Errorf("users list --output table printed %q (%v)", stdout, err)
+	}
+
+	type assigned struct {
+		ID        string   `json:"id"`
+		Assignees []string `json:"assignees"`
+	}
+	var mine assigned
+	w.mustRun(t, &mine, "tasks", "create", "--title", "Write report", "--assignee", "me")
+	if strings.Join(mine.Assignees, ",") != "Ada Lovelace" {
+		t.Errorf("tasks create --assignee me printed %+v", mine)
+	}
+
+	// Grace joins after the user list was cached on disk
+	w.srv.AddUser("Grace Hopper", "grace@example.com")
+	var theirs assigned
+	w.mustRun(t, &theirs, "tasks", "create", "--title", "Review report", "--assignee", "grace@example.com")
+	if strings.Join(theirs.Assignees, ",") != "Grace Hopper" {
+		t.Errorf("tasks create --assignee for a new user printed %+v", theirs)
+	}
+
+	var tasks []assigned
+	w.mustRun(t, &tasks, "tasks", "query", "--assignee", "me")
+	if len(tasks) != 1 || tasks[0].ID != mine.ID {
+		t.Errorf("tasks query --assignee me printed %+v, want only %s", tasks, mine.ID)
+	}
+}
+
+func TestCommandErrors(t *testing.T) {
+	w := newWorkspace(t)
+
+	stdout, stderr, err := w.run(t, "tasks", "create", "--title", "Ship it", "--status", "Shipped")
+	if err == nil {
+		t.Fatal("tasks create with an unknown status succeeded")
+	}
+	if stdout != "" {
+		t.Errorf("stdout = %q, want nothing", stdout)
+	}
+	var resp struct {
+		Error string `json:"error"`
+	}
+	// Cobra follows the JSON error with its own message and the usage
+	if json.NewDecoder(strings.NewReader(stderr)).Decode(&resp) != nil || !strings.Contains(resp.Error, "Shipped") {
+		t.Errorf("stderr = %q, want a JSON error naming the status", stderr)
+	}
+
+	if _, _, err := w.run(t, "tasks", "get", "--id", "0f5ae1d6-0000-4000-8000-00000000ffff"); err == nil {
+		t.Error("tasks get of a missing task succeeded")
+	}
+
+	w.srv.Token = "secret_other"
+	if _, _, err := w.run(t, "databases", "list"); err == nil || !strings.Contains(err.Error(), "API token is invalid") {
+		t.Errorf("databases list with a revoked token: error = %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package search
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchType  string
+	searchSort  string
+	searchLimit int
+)
+
+var SearchCmd = &cobra.Command{
+	Use:   "search <text>",
+	Short: "Search pages and databases by title",
+	Long: `Search the pages and databases shared with your integration by title.
+Each result lists its title, what it sits in (a database, a page or the
+workspace), its icon and its URL.`,
+	Example: `  # Anything mentioning "roadmap"
+  notion-cli search roadmap
+
+  # Only databases, most recently edited first
+  notion-cli search tasks --type database --sort last_edited
+
+  notion-cli search "weekly review" --output table`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		results, err := client.Search(ctx, notioncli.SearchOptions{
+			Query: strings.Join(args, " "),
+			Type:  searchType,
+			Sort:  searchSort,
+			Limit: searchLimit,
+		})
+		if err != nil {
+			return output.Error(err)
+		}
+
+		if strings.ToLower(cmd.GetOutputFormat()) == "table" {
+			rows := make([][]string, 0, len(results))
+			for _, r := range results {
+				parent := r.ParentType
+				if r.ParentID != "" {
+					parent += " " + r.ParentID
+				}
+				rows = append(rows, []string{r.Icon, r.Title, r.Object, parent, r.URL})
+			}
+			return output.Table([]string{"ICON", "TITLE", "TYPE", "PARENT", "URL"}, rows)
+		}
+		return output.JSON(results)
+	},
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(SearchCmd)
+
+	SearchCmd.Flags().StringVar(&searchType, "type", "", "Only return pages or databases: page, database")
+	SearchCmd.Flags().StringVar(&searchSort, "sort", "", "Sort order: last_edited (default: Notion's relevance order)")
+	SearchCmd.Flags().IntVar(&searchLimit, "limit", 0, "Maximum number of results (default: all)")
+}
@@ -0,0 +1,108 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/board"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
+	"github.com/spf13/cobra"
+)
+
+var (
+	boardOpen     bool
+	boardAssignee string
+	boardCategory string
+	boardLimit    int
+	boardMaxCards int
+	boardWidth    int
+	boardASCII    bool
+)
+
+var boardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "Show tasks as a kanban board",
+	Long: `Show tasks in one column per status, in the order of the Status options in
+Notion. Each column shows its card count, and columns over their WIP limit
+(wip_limits.tasks in the config) are flagged.
+
+Use --output json to get the columns as JSON instead of the drawn board.`,
+	Example: `  notion-cli tasks board
+
+  # Only the work still to do, assigned to me
+  notion-cli tasks board --open --assignee me
+
+  # Plain ASCII, narrow terminal
+  notion-cli tasks board --ascii --width 80 --max-cards 5`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		cfg := cmd.GetConfig()
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		if cfg.TasksDatabaseID == "" {
+			return output.Error(fmt.Errorf("tasks database ID is required"))
+		}
+
+		order, err := client.StatusOptions(ctx, cfg.TasksDatabaseID)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		tasks, err := client.QueryTasks(ctx, cfg.TasksDatabaseID, notioncli.TaskQueryOptions{
+			Open:     boardOpen,
+			Assignee: boardAssignee,
+			Category: boardCategory,
+			Limit:    boardLimit,
+		})
+		if err != nil {
+			return output.Error(err)
+		}
+
+		cards := make([]board.Card, 0, len(tasks))
+		for _, t := range tasks {
+			var details []string
+			for _, d := range []string{t.Priority, t.DueDate, strings.Join(t.Assignees, ", ")} {
+				if d != "" {
+					details = append(details, d)
+				}
+			}
+			cards = append(cards, board.Card{
+				ID:      t.ID,
+				Title:   t.Title,
+				Details: details,
+				Status:  t.Status,
+			})
+		}
+
+		var only []string
+		if boardOpen {
+			only = client.Settings().TaskStatuses.Active()
+		}
+		b := board.Build("Tasks", order, cards, cfg.WIPLimits["tasks"], only)
+
+		if cobraCmd.Flags().Changed("output") && strings.ToLower(cmd.GetOutputFormat()) == "json" {
+			return output.JSON(b)
+		}
+		width := boardWidth
+		if width <= 0 {
+			width = board.DefaultWidth()
+		}
+		return b.Write(os.Stdout, board.Options{Width: width, MaxCards: boardMaxCards, ASCII: boardASCII})
+	},
+}
+
+func init() {
+	TasksCmd.AddCommand(boardCmd)
+
+	boardCmd.Flags().BoolVar(&boardOpen, "open", false, "Only show open and in-progress columns")
+	boardCmd.Flags().StringVar(&boardAssignee, "assignee", "", "Only tasks assigned to this user (email, name or 'me')")
+	boardCmd.Flags().StringVar(&boardCategory, "category", "", "Only tasks in this category")
+	boardCmd.Flags().IntVar(&boardLimit, "limit", 500, "Maximum number of tasks to load")
+	boardCmd.Flags().IntVar(&boardMaxCards, "max-cards", 10, "Cards shown per column before the rest are counted (0 for all)")
+	boardCmd.Flags().IntVar(&boardWidth, "width", 0, "Board width in characters (default: $COLUMNS or 120)")
+	boardCmd.Flags().BoolVar(&boardASCII, "ascii", false, "Draw with plain ASCII characters")
+}
@@ -0,0 +1,69 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkID      string
+	checkItem    int
+	checkMatch   string
+	checkUncheck bool
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check [id]",
+	Short: "Tick an item of a task's checklist",
+	Long: `Tick one to-do in the body of a task page, chosen by its number in
+tasks checklist (--item) or by part of its text (--match, case-insensitive).
+A match must pick out a single item, unless one item's text is exactly the
+match. Use --uncheck to clear the item instead.
+
+Prints the checklist after the change.`,
+	Example: `  notion-cli tasks check "TASK_ID" --item 2
+
+  notion-cli tasks check "TASK_ID" --match "release notes"
+
+  # Undo
+  notion-cli tasks check "TASK_ID" --item 2 --uncheck`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		id := checkID
+		if len(args) == 1 {
+			id = args[0]
+		}
+		if id == "" {
+			return output.Error(fmt.Errorf("task ID is required"))
+		}
+		itemSet := cobraCmd.Flags().Changed("item")
+		if itemSet == (checkMatch != "") {
+			return output.Error(fmt.Errorf("give exactly one of --item or --match"))
+		}
+		if itemSet && checkItem < 1 {
+			return output.Error(fmt.Errorf("--item counts from 1"))
+		}
+
+		checklist, err := client.CheckTaskItem(ctx, id, checkItem, checkMatch, !checkUncheck)
+		if err != nil {
+			return output.Error(err)
+		}
+		return printChecklist(checklist)
+	},
+}
+
+func init() {
+	TasksCmd.AddCommand(checkCmd)
+
+	checkCmd.Flags().StringVar(&checkID, "id", "", "Task ID (or give it as an argument)")
+	checkCmd.Flags().IntVar(&checkItem, "item", 0, "Number of the item, as listed by tasks checklist")
+	checkCmd.Flags().StringVar(&checkMatch, "match", "", "Text of the item, or part of it")
+	checkCmd.Flags().BoolVar(&checkUncheck, "uncheck", false, "Clear the item instead of ticking it")
+}
@@ -0,0 +1,81 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
+	"github.com/spf13/cobra"
+)
+
+var checklistID string
+
+var checklistCmd = &cobra.Command{
+	Use:   "checklist [id]",
+	Short: "List the to-dos in a task's page",
+	Long: `List the to-do blocks in the body of a task page with their checked state,
+numbered in page order. Nested to-dos are included.
+
+The numbers are what tasks check --item takes. Use --output table or
+--output markdown for a readable list; the default is JSON with the
+checklist's progress, as in "3/5".`,
+	Example: `  notion-cli tasks checklist "TASK_ID" --output table
+
+  notion-cli tasks checklist --id "TASK_ID"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		id := checklistID
+		if len(args) == 1 {
+			id = args[0]
+		}
+		if id == "" {
+			return output.Error(fmt.Errorf("task ID is required"))
+		}
+
+		checklist, err := client.TaskChecklist(ctx, id)
+		if err != nil {
+			return output.Error(err)
+		}
+		return printChecklist(checklist)
+	},
+}
+
+// printChecklist writes a checklist in the requested output format
+func printChecklist(checklist *notioncli.Checklist) error {
+	switch strings.ToLower(cmd.GetOutputFormat()) {
+	case "markdown", "md":
+		for _, item := range checklist.Items {
+			box := "[ ]"
+			if item.Checked {
+				box = "[x]"
+			}
+			fmt.Printf("- %s %s\n", box, item.Text)
+		}
+		return nil
+	case "table":
+		rows := make([][]string, 0, len(checklist.Items))
+		for _, item := range checklist.Items {
+			done := ""
+			if item.Checked {
+				done = "x"
+			}
+			rows = append(rows, []string{strconv.Itoa(item.Index), done, item.Text})
+		}
+		return output.Table([]string{"#", "DONE", "ITEM"}, rows)
+	default:
+		return output.JSON(checklist)
+	}
+}
+
+func init() {
+	TasksCmd.AddCommand(checklistCmd)
+
+	checklistCmd.Flags().StringVar(&checklistID, "id", "", "Task ID (or give it as an argument)")
+}
@@ -5,28 +5,36 @@ import (
 	"fmt"
 
 	"github.com/jontk/notion-cli/cmd"
-	"github.com/jontk/notion-cli/internal/notion"
 	"github.com/jontk/notion-cli/internal/output"
 	"github.com/spf13/cobra"
 )
 
-var completeID string
+var (
+	completeID    string
+	completeForce bool
+)
 
 var completeCmd = &cobra.Command{
 	Use:   "complete",
 	Short: "Mark a task as complete",
-	Long:  `Mark a task as complete (Done status) in your Notion database.`,
-	Example: `  notion-cli tasks complete --id "TASK_ID"`,
+	Long: `Mark a task as complete (Done status) in your Notion database.
+
+A task blocked by tasks that are still open is not completed unless --force is
+given. If the task repeats, the next instance is created with the following
+due date and its ID is returned as next_id.`,
+	Example: `  notion-cli tasks complete --id "TASK_ID"
+
+  # Complete it even though its blockers are still open
+  notion-cli tasks complete --id "TASK_ID" --force`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
-		cfg := cmd.GetConfig()
-		client := notion.NewClient(cfg.APIToken)
+		client := cmd.NewClient()
 		ctx := context.Background()
 
 		if completeID == "" {
 			return output.Error(fmt.Errorf("task ID is required"))
 		}
 
-		task, err := client.CompleteTask(ctx, completeID)
+		task, err := client.CompleteTask(ctx, completeID, completeForce)
 		if err != nil {
 			return output.Error(err)
 		}
@@ -39,5 +47,6 @@ func init() {
 	TasksCmd.AddCommand(completeCmd)
 
 	completeCmd.Flags().StringVar(&completeID, "id", "", "Task ID (required)")
+	completeCmd.Flags().BoolVar(&completeForce, "force", false, "Complete the task even if its blockers are still open")
 	completeCmd.MarkFlagRequired("id")
 }
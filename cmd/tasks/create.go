@@ -8,9 +8,8 @@ import (
 	"os"
 
 	"github.com/jontk/notion-cli/cmd"
-	"github.com/jontk/notion-cli/internal/models"
-	"github.com/jontk/notion-cli/internal/notion"
 	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +21,9 @@ var (
 	createCategory string
 	createTags     []string
 	createNotes    string
+	createRepeat   string
+	createParent   string
+	createAssignee []string
 	createStdin    bool
 )
 
@@ -32,6 +34,9 @@ var createCmd = &cobra.Command{
 	Example: `  # Simple task
   notion-cli tasks create --title "Buy milk"
 
+  # Repeats every other Friday
+  notion-cli tasks create --title "Submit timesheet" --due "friday" --repeat "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR"
+
   # With all fields
   notion-cli tasks create \
     --title "Review PR #123" \
@@ -40,15 +45,24 @@ var createCmd = &cobra.Command{
     --due "2024-03-20" \
     --tags "urgent,review"
 
+  # Assigned to a teammate
+  notion-cli tasks create --title "Review budget" --assignee "alex@example.com"
+
+  # Sub-task of another task
+  notion-cli tasks create --title "Write tests" --parent "TASK_ID"
+
+  # Relative due date
+  notion-cli tasks create --title "Send invoice" --due "next friday"
+
   # From stdin
   echo '{"title":"Call dentist","category":"Personal"}' | \
     notion-cli tasks create --stdin`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		cfg := cmd.GetConfig()
-		client := notion.NewClient(cfg.APIToken)
+		client := cmd.NewClient()
 		ctx := context.Background()
 
-		var input models.TaskInput
+		var input notioncli.TaskInput
 
 		if createStdin {
 			data, err := io.ReadAll(os.Stdin)
@@ -63,14 +77,17 @@ var createCmd = &cobra.Command{
 				return output.Error(fmt.Errorf("title is required"))
 			}
 
-			input = models.TaskInput{
-				Title:    createTitle,
-				Status:   createStatus,
-				Priority: createPriority,
-				DueDate:  createDue,
-				Category: createCategory,
-				Tags:     createTags,
-				Notes:    createNotes,
+			input = notioncli.TaskInput{
+				Title:     createTitle,
+				Status:    createStatus,
+				Priority:  createPriority,
+				DueDate:   createDue,
+				Category:  createCategory,
+				Tags:      createTags,
+				Notes:     createNotes,
+				Repeat:    createRepeat,
+				ParentID:  createParent,
+				Assignees: createAssignee,
 			}
 		}
 
@@ -101,9 +118,12 @@ func init() {
 	createCmd.Flags().StringVar(&createTitle, "title", "", "Task title (required unless using --stdin)")
 	createCmd.Flags().StringVar(&createStatus, "status", "", "Task status (default: Todo)")
 	createCmd.Flags().StringVar(&createPriority, "priority", "", "Priority: High, Medium, Low (default: Medium)")
-	createCmd.Flags().StringVar(&createDue, "due", "", "Due date in YYYY-MM-DD format or 'today', 'tomorrow'")
+	createCmd.Flags().StringVar(&createDue, "due", "", "Due date: YYYY-MM-DD, 'today', 'tomorrow', 'next friday', '+3d', 'in 2 weeks', 'eow'")
 	createCmd.Flags().StringVar(&createCategory, "category", "", "Category: Work, Personal, Shopping, Project, etc.")
 	createCmd.Flags().StringSliceVar(&createTags, "tags", []string{}, "Tags (comma-separated)")
 	createCmd.Flags().StringVar(&createNotes, "notes", "", "Additional notes")
+	createCmd.Flags().StringVar(&createRepeat, "repeat", "", "Recurrence rule (RRULE), e.g. FREQ=WEEKLY;BYDAY=FR; needs a due date")
+	createCmd.Flags().StringVar(&createParent, "parent", "", "Parent task ID, making this a sub-task")
+	createCmd.Flags().StringSliceVar(&createAssignee, "assignee", []string{}, "Assignees by email, name or 'me' (comma-separated)")
 	createCmd.Flags().BoolVar(&createStdin, "stdin", false, "Read TaskInput JSON from stdin")
 }
@@ -0,0 +1,50 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dueOn  string
+	dueAll bool
+)
+
+var dueCmd = &cobra.Command{
+	Use:   "due",
+	Short: "Show tasks due on a given day",
+	Long:  `Show the open tasks due on a given day. Use --all to include completed ones.`,
+	Example: `  notion-cli tasks due --on tomorrow
+  notion-cli tasks due --on 2026-10-23 --all`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		cfg := cmd.GetConfig()
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		if cfg.TasksDatabaseID == "" {
+			return output.Error(fmt.Errorf("tasks database ID is required"))
+		}
+		if dueOn == "" {
+			return output.Error(fmt.Errorf("date is required"))
+		}
+
+		tasks, err := client.GetTasksDueOn(ctx, cfg.TasksDatabaseID, dueOn, dueAll)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		return output.JSON(tasks)
+	},
+}
+
+func init() {
+	TasksCmd.AddCommand(dueCmd)
+
+	dueCmd.Flags().StringVar(&dueOn, "on", "", "Due date (required)")
+	dueCmd.Flags().BoolVar(&dueAll, "all", false, "Include tasks that are no longer open")
+	dueCmd.MarkFlagRequired("on")
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/jontk/notion-cli/cmd"
-	"github.com/jontk/notion-cli/internal/notion"
 	"github.com/jontk/notion-cli/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -15,10 +14,12 @@ var getID string
 var getCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get a single task by ID",
-	Long:  `Retrieve a single task from your Notion database by its ID.`,
+	Long: `Retrieve a single task from your Notion database by its ID.
+
+The output includes checklist_progress, counting the checked to-dos in the
+task's page, as in "3/5".`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
-		cfg := cmd.GetConfig()
-		client := notion.NewClient(cfg.APIToken)
+		client := cmd.NewClient()
 		ctx := context.Background()
 
 		if getID == "" {
@@ -29,6 +30,11 @@ var getCmd = &cobra.Command{
 		if err != nil {
 			return output.Error(err)
 		}
+		checklist, err := client.TaskChecklist(ctx, task.ID)
+		if err != nil {
+			return output.Error(err)
+		}
+		task.ChecklistProgress = checklist.Progress
 
 		return output.JSON(task)
 	},
@@ -0,0 +1,54 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	linkID     string
+	linkBlocks string
+	linkRemove bool
+)
+
+var linkCmd = &cobra.Command{
+	Use:   "link",
+	Short: "Record that one task blocks another",
+	Long: `Record that a task blocks another one by adding it to the other task's
+Blocked By relation. The blocked task cannot be completed until its blockers
+are done or cancelled. The updated blocked task is returned.`,
+	Example: `  # TASK_A has to be finished before TASK_B
+  notion-cli tasks link --id "TASK_A" --blocks "TASK_B"
+
+  # Remove the link again
+  notion-cli tasks link --id "TASK_A" --blocks "TASK_B" --remove`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		if linkID == "" || linkBlocks == "" {
+			return output.Error(fmt.Errorf("both --id and --blocks are required"))
+		}
+
+		task, err := client.LinkBlocker(ctx, linkID, linkBlocks, linkRemove)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		return output.JSON(task)
+	},
+}
+
+func init() {
+	TasksCmd.AddCommand(linkCmd)
+
+	linkCmd.Flags().StringVar(&linkID, "id", "", "ID of the blocking task (required)")
+	linkCmd.Flags().StringVar(&linkBlocks, "blocks", "", "ID of the task it blocks (required)")
+	linkCmd.Flags().BoolVar(&linkRemove, "remove", false, "Remove the link instead of adding it")
+	linkCmd.MarkFlagRequired("id")
+	linkCmd.MarkFlagRequired("blocks")
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/jontk/notion-cli/cmd"
-	"github.com/jontk/notion-cli/internal/notion"
 	"github.com/jontk/notion-cli/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -13,10 +12,10 @@ import (
 var overdueCmd = &cobra.Command{
 	Use:   "overdue",
 	Short: "Show overdue tasks",
-	Long:  `Show all tasks that are past their due date and still incomplete.`,
+	Long:  `Show all tasks that are past their due date and still open.`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		cfg := cmd.GetConfig()
-		client := notion.NewClient(cfg.APIToken)
+		client := cmd.NewClient()
 		ctx := context.Background()
 
 		if cfg.TasksDatabaseID == "" {
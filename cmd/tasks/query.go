@@ -5,16 +5,21 @@ import (
 	"fmt"
 
 	"github.com/jontk/notion-cli/cmd"
-	"github.com/jontk/notion-cli/internal/notion"
 	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
 	"github.com/spf13/cobra"
 )
 
 var (
-	queryStatus   string
-	queryPriority string
-	queryCategory string
-	queryLimit    int
+	queryStatus    string
+	queryPriority  string
+	queryCategory  string
+	queryDueBefore string
+	queryDueAfter  string
+	queryOpen      bool
+	queryAssignee  string
+	queryChecklist bool
+	queryLimit     int
 )
 
 var queryCmd = &cobra.Command{
@@ -31,21 +36,35 @@ var queryCmd = &cobra.Command{
   notion-cli tasks query --category "Work"
 
   # Todo items
-  notion-cli tasks query --status "Todo" --limit 10`,
+  notion-cli tasks query --status "Todo" --limit 10
+
+  # My open tasks
+  notion-cli tasks query --assignee me --open
+
+  # Open tasks due this month
+  notion-cli tasks query --open --due-after today --due-before eom
+
+  # Open tasks with how far along their checklists are
+  notion-cli tasks query --open --checklist`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		cfg := cmd.GetConfig()
-		client := notion.NewClient(cfg.APIToken)
+		client := cmd.NewClient()
 		ctx := context.Background()
 
 		if cfg.TasksDatabaseID == "" {
 			return output.Error(fmt.Errorf("tasks database ID is required"))
 		}
 
-		opts := notion.TaskQueryOptions{
-			Status:   queryStatus,
-			Priority: queryPriority,
-			Category: queryCategory,
-			Limit:    queryLimit,
+		opts := notioncli.TaskQueryOptions{
+			Status:    queryStatus,
+			Priority:  queryPriority,
+			Category:  queryCategory,
+			DueBefore: queryDueBefore,
+			DueAfter:  queryDueAfter,
+			Open:      queryOpen,
+			Assignee:  queryAssignee,
+			Checklist: queryChecklist,
+			Limit:     queryLimit,
 		}
 
 		tasks, err := client.QueryTasks(ctx, cfg.TasksDatabaseID, opts)
@@ -63,5 +82,10 @@ func init() {
 	queryCmd.Flags().StringVar(&queryStatus, "status", "", "Filter by status (Todo, In Progress, Done, Blocked)")
 	queryCmd.Flags().StringVar(&queryPriority, "priority", "", "Filter by priority (High, Medium, Low)")
 	queryCmd.Flags().StringVar(&queryCategory, "category", "", "Filter by category")
+	queryCmd.Flags().StringVar(&queryDueBefore, "due-before", "", "Only tasks due on or before this date")
+	queryCmd.Flags().StringVar(&queryDueAfter, "due-after", "", "Only tasks due on or after this date")
+	queryCmd.Flags().BoolVar(&queryOpen, "open", false, "Only tasks with an open or in-progress status (see status_groups)")
+	queryCmd.Flags().StringVar(&queryAssignee, "assignee", "", "Filter by assignee: email, name or 'me'")
+	queryCmd.Flags().BoolVar(&queryChecklist, "checklist", false, "Include checklist_progress (reads each task's page)")
 	queryCmd.Flags().IntVar(&queryLimit, "limit", 100, "Maximum number of results")
 }
@@ -0,0 +1,62 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
+	"github.com/spf13/cobra"
+)
+
+var rollID string
+
+var rollCmd = &cobra.Command{
+	Use:   "roll",
+	Short: "Create the next instance of recurring tasks",
+	Long: `Create the next instance of a recurring task. Without --id, every completed
+recurring task that has no next instance yet is rolled forward. Instances that
+already exist are left alone, so the command is safe to run repeatedly.`,
+	Example: `  # Roll one task
+  notion-cli tasks roll --id "TASK_ID"
+
+  # Catch up on all completed recurring tasks
+  notion-cli tasks roll`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		cfg := cmd.GetConfig()
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		if rollID != "" {
+			task, _, err := client.RollTask(ctx, rollID)
+			if err != nil {
+				return output.Error(err)
+			}
+			if task == nil {
+				return output.Error(fmt.Errorf("task %s has no further occurrences", rollID))
+			}
+			return output.JSON(task)
+		}
+
+		if cfg.TasksDatabaseID == "" {
+			return output.Error(fmt.Errorf("tasks database ID is required"))
+		}
+
+		created, err := client.RollCompletedTasks(ctx, cfg.TasksDatabaseID)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		if created == nil {
+			created = []notioncli.Task{}
+		}
+		return output.JSON(created)
+	},
+}
+
+func init() {
+	TasksCmd.AddCommand(rollCmd)
+
+	rollCmd.Flags().StringVar(&rollID, "id", "", "Task ID (default: all completed recurring tasks)")
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/jontk/notion-cli/cmd"
-	"github.com/jontk/notion-cli/internal/notion"
 	"github.com/jontk/notion-cli/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -13,10 +12,10 @@ import (
 var todayCmd = &cobra.Command{
 	Use:   "today",
 	Short: "Show tasks due today",
-	Long:  `Show all tasks that are due today or overdue.`,
+	Long:  `Show all open tasks that are due today or overdue.`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		cfg := cmd.GetConfig()
-		client := notion.NewClient(cfg.APIToken)
+		client := cmd.NewClient()
 		ctx := context.Background()
 
 		if cfg.TasksDatabaseID == "" {
@@ -0,0 +1,64 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var treeID string
+
+var treeCmd = &cobra.Command{
+	Use:   "tree [id]",
+	Short: "Show a task with its sub-tasks",
+	Long: `Show the sub-task hierarchy below a task, following the Parent relation.
+
+Use --output table for an indented tree or --output markdown for a nested
+checklist; the default is JSON with each task's children nested under it.`,
+	Example: `  notion-cli tasks tree "TASK_ID" --output table
+
+  notion-cli tasks tree --id "TASK_ID" --output markdown`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		cfg := cmd.GetConfig()
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		id := treeID
+		if len(args) == 1 {
+			id = args[0]
+		}
+		if id == "" {
+			return output.Error(fmt.Errorf("task ID is required"))
+		}
+		if cfg.TasksDatabaseID == "" {
+			return output.Error(fmt.Errorf("tasks database ID is required"))
+		}
+
+		tree, err := client.TaskTree(ctx, cfg.TasksDatabaseID, id)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		groups := client.Settings().TaskStatuses
+		switch strings.ToLower(cmd.GetOutputFormat()) {
+		case "markdown", "md":
+			return tree.WriteTree(os.Stdout, groups, true)
+		case "table":
+			return tree.WriteTree(os.Stdout, groups, false)
+		default:
+			return output.JSON(tree)
+		}
+	},
+}
+
+func init() {
+	TasksCmd.AddCommand(treeCmd)
+
+	treeCmd.Flags().StringVar(&treeID, "id", "", "Task ID (or give it as an argument)")
+}
@@ -0,0 +1,45 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var upcomingDays int
+
+var upcomingCmd = &cobra.Command{
+	Use:   "upcoming",
+	Short: "Show tasks due soon",
+	Long:  `Show open tasks due from today through the given number of days ahead.`,
+	Example: `  notion-cli tasks upcoming
+  notion-cli tasks upcoming --days 14`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		cfg := cmd.GetConfig()
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		if cfg.TasksDatabaseID == "" {
+			return output.Error(fmt.Errorf("tasks database ID is required"))
+		}
+		if upcomingDays < 0 {
+			return output.Error(fmt.Errorf("days must not be negative"))
+		}
+
+		tasks, err := client.GetUpcomingTasks(ctx, cfg.TasksDatabaseID, upcomingDays)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		return output.JSON(tasks)
+	},
+}
+
+func init() {
+	TasksCmd.AddCommand(upcomingCmd)
+
+	upcomingCmd.Flags().IntVar(&upcomingDays, "days", 7, "Number of days to look ahead")
+}
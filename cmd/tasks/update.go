@@ -8,9 +8,8 @@ import (
 	"os"
 
 	"github.com/jontk/notion-cli/cmd"
-	"github.com/jontk/notion-cli/internal/models"
-	"github.com/jontk/notion-cli/internal/notion"
 	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/pkg/notioncli"
 	"github.com/spf13/cobra"
 )
 
@@ -23,6 +22,9 @@ var (
 	updateCategory string
 	updateTags     []string
 	updateNotes    string
+	updateRepeat   string
+	updateParent   string
+	updateAssignee []string
 	updateStdin    bool
 )
 
@@ -36,18 +38,20 @@ var updateCmd = &cobra.Command{
   # Update priority and due date
   notion-cli tasks update --id "TASK_ID" --priority "High" --due "2024-03-25"
 
+  # Push the due date back a week
+  notion-cli tasks update --id "TASK_ID" --due "+1w"
+
   # Update from stdin
   echo '{"status":"In Progress"}' | notion-cli tasks update --id "TASK_ID" --stdin`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
-		cfg := cmd.GetConfig()
-		client := notion.NewClient(cfg.APIToken)
+		client := cmd.NewClient()
 		ctx := context.Background()
 
 		if updateID == "" {
 			return output.Error(fmt.Errorf("task ID is required"))
 		}
 
-		var input models.TaskInput
+		var input notioncli.TaskInput
 
 		if updateStdin {
 			data, err := io.ReadAll(os.Stdin)
@@ -58,7 +62,7 @@ var updateCmd = &cobra.Command{
 				return output.Error(fmt.Errorf("failed to parse JSON: %w", err))
 			}
 		} else {
-			input = models.TaskInput{}
+			input = notioncli.TaskInput{}
 			hasChanges := false
 
 			if cobraCmd.Flags().Changed("title") {
@@ -89,6 +93,18 @@ var updateCmd = &cobra.Command{
 				input.Notes = updateNotes
 				hasChanges = true
 			}
+			if cobraCmd.Flags().Changed("repeat") {
+				input.Repeat = updateRepeat
+				hasChanges = true
+			}
+			if cobraCmd.Flags().Changed("assignee") {
+				input.Assignees = updateAssignee
+				hasChanges = true
+			}
+			if cobraCmd.Flags().Changed("parent") {
+				input.ParentID = updateParent
+				hasChanges = true
+			}
 
 			if !hasChanges {
 				return output.Error(fmt.Errorf("no fields specified for update"))
@@ -111,10 +127,13 @@ func init() {
 	updateCmd.Flags().StringVar(&updateTitle, "title", "", "New task title")
 	updateCmd.Flags().StringVar(&updateStatus, "status", "", "New status")
 	updateCmd.Flags().StringVar(&updatePriority, "priority", "", "New priority")
-	updateCmd.Flags().StringVar(&updateDue, "due", "", "New due date (YYYY-MM-DD)")
+	updateCmd.Flags().StringVar(&updateDue, "due", "", "New due date (YYYY-MM-DD, 'tomorrow', 'next friday', '+3d', ...)")
 	updateCmd.Flags().StringVar(&updateCategory, "category", "", "New category")
 	updateCmd.Flags().StringSliceVar(&updateTags, "tags", []string{}, "New tags")
 	updateCmd.Flags().StringVar(&updateNotes, "notes", "", "New notes")
+	updateCmd.Flags().StringVar(&updateRepeat, "repeat", "", "New recurrence rule (RRULE)")
+	updateCmd.Flags().StringVar(&updateParent, "parent", "", "New parent task ID")
+	updateCmd.Flags().StringSliceVar(&updateAssignee, "assignee", []string{}, "New assignees by email, name or 'me' (comma-separated)")
 	updateCmd.Flags().BoolVar(&updateStdin, "stdin", false, "Read TaskInput JSON from stdin")
 
 	updateCmd.MarkFlagRequired("id")
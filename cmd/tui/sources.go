@@ -0,0 +1,189 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jontk/notion-cli/internal/tui"
+	"github.com/jontk/notion-cli/pkg/notioncli"
+)
+
+// taskSource shows the tasks database
+type taskSource struct {
+	client     *notioncli.Client
+	databaseID string
+	statuses   []string
+	priorities []string
+}
+
+func (s *taskSource) Name() string         { return "Tasks" }
+func (s *taskSource) Statuses() []string   { return s.statuses }
+func (s *taskSource) Priorities() []string { return s.priorities }
+
+func (s *taskSource) Load(ctx context.Context) ([]tui.Item, error) {
+	tasks, err := s.client.QueryTasks(ctx, s.databaseID, notioncli.TaskQueryOptions{Limit: 500})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]tui.Item, 0, len(tasks))
+	for _, t := range tasks {
+		items = append(items, taskItem(t))
+	}
+	return items, nil
+}
+
+func (s *taskSource) SetStatus(ctx context.Context, id, status string) (tui.Item, error) {
+	task, err := s.client.UpdateTask(ctx, id, notioncli.TaskInput{Status: status})
+	if err != nil {
+		return tui.Item{}, err
+	}
+	return taskItem(*task), nil
+}
+
+func (s *taskSource) SetPriority(ctx context.Context, id, priority string) (tui.Item, error) {
+	task, err := s.client.UpdateTask(ctx, id, notioncli.TaskInput{Priority: priority})
+	if err != nil {
+		return tui.Item{}, err
+	}
+	return taskItem(*task), nil
+}
+
+func taskItem(t notioncli.Task) tui.Item {
+	return tui.Item{
+		ID:       t.ID,
+		Title:    t.Title,
+		Status:   t.Status,
+		Priority: t.Priority,
+		URL:      t.URL,
+		Fields: []tui.Field{
+			{Label: "Due", Value: t.DueDate},
+			{Label: "Category", Value: t.Category},
+			{Label: "Tags", Value: strings.Join(t.Tags, ", ")},
+			{Label: "Assignees", Value: strings.Join(t.Assignees, ", ")},
+			{Label: "Repeat", Value: t.Repeat},
+			{Label: "ID", Value: t.ID},
+		},
+		Body: t.Notes,
+	}
+}
+
+// postSource shows the content pipeline
+type postSource struct {
+	client     *notioncli.Client
+	databaseID string
+	statuses   []string
+}
+
+func (s *postSource) Name() string         { return "Posts" }
+func (s *postSource) Statuses() []string   { return s.statuses }
+func (s *postSource) Priorities() []string { return nil }
+
+func (s *postSource) Load(ctx context.Context) ([]tui.Item, error) {
+	posts, err := s.client.QueryPosts(ctx, s.databaseID, notioncli.QueryOptions{
+		Sort:  "last_edited_time",
+		Order: "descending",
+		Limit: 100,
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]tui.Item, 0, len(posts))
+	for _, p := range posts {
+		items = append(items, postItem(p))
+	}
+	return items, nil
+}
+
+func (s *postSource) SetStatus(ctx context.Context, id, status string) (tui.Item, error) {
+	post, err := s.client.UpdatePost(ctx, id, notioncli.PostInput{Status: status})
+	if err != nil {
+		return tui.Item{}, err
+	}
+	return postItem(*post), nil
+}
+
+func (s *postSource) SetPriority(ctx context.Context, id, priority string) (tui.Item, error) {
+	return tui.Item{}, fmt.Errorf("posts have no priority")
+}
+
+func postItem(p notioncli.Post) tui.Item {
+	week := ""
+	if p.Week > 0 {
+		week = fmt.Sprint(p.Week)
+	}
+	return tui.Item{
+		ID:     p.ID,
+		Title:  p.Title,
+		Status: p.Status,
+		URL:    p.URL,
+		Fields: []tui.Field{
+			{Label: "Pillar", Value: p.Pillar},
+			{Label: "Week", Value: week},
+			{Label: "Publish", Value: p.PublishDate},
+			{Label: "Published", Value: p.PublishedDate},
+			{Label: "Blog URL", Value: p.BlogURL},
+			{Label: "Distributed", Value: strings.Join(p.DistributedTo, ", ")},
+			{Label: "ID", Value: p.ID},
+		},
+		Body: p.Content,
+	}
+}
+
+// eventSource shows the events of the coming weeks
+type eventSource struct {
+	client     *notioncli.Client
+	databaseID string
+	statuses   []string
+}
+
+func (s *eventSource) Name() string         { return "Events" }
+func (s *eventSource) Statuses() []string   { return s.statuses }
+func (s *eventSource) Priorities() []string { return nil }
+
+func (s *eventSource) Load(ctx context.Context) ([]tui.Item, error) {
+	events, err := s.client.QueryEvents(ctx, s.databaseID, notioncli.EventQueryOptions{
+		DateAfter:  "today",
+		DateBefore: "+30d",
+		Limit:      500,
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]tui.Item, 0, len(events))
+	for _, e := range events {
+		items = append(items, eventItem(e))
+	}
+	return items, nil
+}
+
+func (s *eventSource) SetStatus(ctx context.Context, id, status string) (tui.Item, error) {
+	event, err := s.client.UpdateEvent(ctx, id, notioncli.EventInput{Status: status})
+	if err != nil {
+		return tui.Item{}, err
+	}
+	return eventItem(*event), nil
+}
+
+func (s *eventSource) SetPriority(ctx context.Context, id, priority string) (tui.Item, error) {
+	return tui.Item{}, fmt.Errorf("events have no priority")
+}
+
+func eventItem(e notioncli.Event) tui.Item {
+	return tui.Item{
+		ID:     e.ID,
+		Title:  e.Title,
+		Status: e.Status,
+		URL:    e.URL,
+		Fields: []tui.Field{
+			{Label: "Start", Value: e.Start},
+			{Label: "End", Value: e.End},
+			{Label: "Type", Value: e.Type},
+			{Label: "Location", Value: e.Location},
+			{Label: "Attendees", Value: strings.Join(e.Attendees, ", ")},
+			{Label: "Repeat", Value: e.Repeat},
+			{Label: "ID", Value: e.ID},
+		},
+		Body: e.Notes,
+	}
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/jontk/notion-cli/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tuiView    string
+	tuiRefresh time.Duration
+)
+
+var TuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse and edit pages in a full-screen interface",
+	Long: `Browse tasks, posts and events in a full-screen terminal interface with a
+list pane and a detail pane. Only the databases that are configured are shown.
+
+Keys:
+  j/k, ↑/↓       move            g/G, Home/End   first/last
+  PgUp/PgDn      page            tab, 1-3        switch view
+  s/S            next/previous status
+  p/P            next/previous priority (tasks)
+  /              search, enter to keep the filter, esc to clear it
+  o              open the page in the browser
+  r              refresh         q               quit
+
+Changes show up at once and are saved in the background; a change that fails
+to save is rolled back and the error is shown at the bottom. Every view that
+has been opened is reloaded every --refresh.`,
+	Example: `  notion-cli tui
+
+  # Start on the content pipeline and refresh every 30 seconds
+  notion-cli tui --view posts --refresh 30s`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		cfg := cmd.GetConfig()
+		client := cmd.NewClient()
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+		defer stop()
+
+		var sources []tui.Source
+		start := 0
+		if cfg.TasksDatabaseID != "" {
+			statuses, err := client.StatusOptions(ctx, cfg.TasksDatabaseID)
+			if err != nil {
+				return output.Error(err)
+			}
+			priorities, err := client.PropertyOptions(ctx, cfg.TasksDatabaseID, "Priority")
+			if err != nil {
+				return output.Error(err)
+			}
+			sources = append(sources, &taskSource{client, cfg.TasksDatabaseID, statuses, priorities})
+		}
+		if cfg.DatabaseID != "" {
+			statuses, err := client.StatusOptions(ctx, cfg.DatabaseID)
+			if err != nil {
+				return output.Error(err)
+			}
+			if strings.EqualFold(tuiView, "posts") {
+				start = len(sources)
+			}
+			sources = append(sources, &postSource{client, cfg.DatabaseID, statuses})
+		}
+		if cfg.EventsDatabaseID != "" {
+			statuses, err := client.StatusOptions(ctx, cfg.EventsDatabaseID)
+			if err != nil {
+				return output.Error(err)
+			}
+			if strings.EqualFold(tuiView, "events") {
+				start = len(sources)
+			}
+			sources = append(sources, &eventSource{client, cfg.EventsDatabaseID, statuses})
+		}
+		if len(sources) == 0 {
+			return output.Error(fmt.Errorf("no database is configured; set tasks_database_id, database_id or events_database_id"))
+		}
+
+		if err := tui.Run(ctx, sources, tui.Options{Refresh: tuiRefresh, View: start}); err != nil {
+			return output.Error(err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(TuiCmd)
+
+	TuiCmd.Flags().StringVar(&tuiView, "view", "tasks", "View to start on: tasks, posts or events")
+	TuiCmd.Flags().DurationVar(&tuiRefresh, "refresh", 2*time.Minute, "How often to reload in the background (0 to turn off)")
+}
@@ -0,0 +1,49 @@
+package users
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/jontk/notion-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var listRefresh bool
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all users",
+	Long: `List the people and bots in your Notion workspace. Emails are only shown
+when the integration has the "Read user information including email
+addresses" capability.
+
+The list is cached (see user_cache_ttl); use --refresh after inviting someone.`,
+	Example: `  notion-cli users list --output table
+
+  notion-cli users list --refresh`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		client := cmd.NewClient()
+		ctx := context.Background()
+
+		users, err := client.ListUsers(ctx, listRefresh)
+		if err != nil {
+			return output.Error(err)
+		}
+
+		if strings.ToLower(cmd.GetOutputFormat()) == "table" {
+			rows := make([][]string, 0, len(users))
+			for _, u := range users {
+				rows = append(rows, []string{u.Name, u.Email, u.Type, u.ID})
+			}
+			return output.Table([]string{"NAME", "EMAIL", "TYPE", "ID"}, rows)
+		}
+		return output.JSON(users)
+	},
+}
+
+func init() {
+	UsersCmd.AddCommand(listCmd)
+
+	listCmd.Flags().BoolVar(&listRefresh, "refresh", false, "Fetch the list again instead of using the cache")
+}
@@ -0,0 +1,16 @@
+package users
+
+import (
+	"github.com/jontk/notion-cli/cmd"
+	"github.com/spf13/cobra"
+)
+
+var UsersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "List workspace users",
+	Long:  `List the users of your Notion workspace, for use with --assignee.`,
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(UsersCmd)
+}
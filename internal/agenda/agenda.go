@@ -0,0 +1,283 @@
+// Package agenda merges events and tasks into a day-by-day plan.
+package agenda
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jontk/notion-cli/pkg/notioncli"
+)
+
+// Agenda is a chronological plan over a range of days.
+type Agenda struct {
+	From    string           `json:"from"`
+	To      string           `json:"to"`
+	Overdue []notioncli.Task `json:"overdue"`
+	Days    []Day            `json:"days"`
+}
+
+// Day holds the events and due tasks of one day. Events are ordered by start
+// time with all-day events first; tasks by due time and priority.
+type Day struct {
+	Date    string            `json:"date"`
+	Weekday string            `json:"weekday"`
+	Events  []notioncli.Event `json:"events"`
+	Tasks   []notioncli.Task  `json:"tasks"`
+}
+
+// Build lays out events and tasks over the given number of days starting at
+// the day of from, in from's location. Tasks due before that day are listed
+// as overdue; tasks without a due date are left out. The caller decides
+// which tasks are still open and which events are cancelled.
+func Build(events []notioncli.Event, tasks []notioncli.Task, from time.Time, days int) *Agenda {
+	if days < 1 {
+		days = 1
+	}
+	loc := from.Location()
+	start := midnight(from)
+	end := start.AddDate(0, 0, days)
+
+	a := &Agenda{
+		From:    start.Format("2006-01-02"),
+		To:      end.AddDate(0, 0, -1).Format("2006-01-02"),
+		Overdue: []notioncli.Task{},
+		Days:    make([]Day, days),
+	}
+	for i := range a.Days {
+		d := start.AddDate(0, 0, i)
+		a.Days[i] = Day{
+			Date:    d.Format("2006-01-02"),
+			Weekday: d.Weekday().String(),
+			Events:  []notioncli.Event{},
+			Tasks:   []notioncli.Task{},
+		}
+	}
+
+	for _, event := range events {
+		s, e, ok := eventDays(event, loc)
+		if !ok {
+			continue
+		}
+		for i := range a.Days {
+			day := start.AddDate(0, 0, i)
+			if !s.After(day) && !e.Before(day) {
+				a.Days[i].Events = append(a.Days[i].Events, event)
+			}
+		}
+	}
+
+	for _, task := range tasks {
+		due, ok := parse(task.DueDate, loc)
+		if !ok {
+			continue
+		}
+		if due.Before(start) {
+			a.Overdue = append(a.Overdue, task)
+			continue
+		}
+		if !due.Before(end) {
+			continue
+		}
+		date := due.Format("2006-01-02")
+		for i := range a.Days {
+			if a.Days[i].Date == date {
+				a.Days[i].Tasks = append(a.Days[i].Tasks, task)
+				break
+			}
+		}
+	}
+
+	sortTasks(a.Overdue, loc)
+	for i := range a.Days {
+		sortEvents(a.Days[i].Events, loc)
+		sortTasks(a.Days[i].Tasks, loc)
+	}
+	return a
+}
+
+// Rows flattens the agenda into table rows of date, time, kind, title and
+// details.
+func (a *Agenda) Rows() [][]string {
+	var rows [][]string
+	for _, t := range a.Overdue {
+		rows = append(rows, []string{"overdue", t.DueDate, "task", t.Title, taskDetails(t)})
+	}
+	for _, d := range a.Days {
+		for _, e := range d.Events {
+			rows = append(rows, []string{d.Date, eventTime(e, d.Date), "event", e.Title, e.Location})
+		}
+		for _, t := range d.Tasks {
+			rows = append(rows, []string{d.Date, taskTime(t), "task", t.Title, taskDetails(t)})
+		}
+	}
+	return rows
+}
+
+// WriteMarkdown renders the agenda as Markdown, suitable for stand-up notes.
+func (a *Agenda) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	if a.From == a.To {
+		fmt.Fprintf(&b, "# Agenda for %s\n", a.From)
+	} else {
+		fmt.Fprintf(&b, "# Agenda %s to %s\n", a.From, a.To)
+	}
+
+	if len(a.Overdue) > 0 {
+		b.WriteString("\n## Overdue\n\n")
+		for _, t := range a.Overdue {
+			fmt.Fprintf(&b, "- [ ] %s (due %s%s)\n", t.Title, t.DueDate, prefixed(", ", t.Priority))
+		}
+	}
+
+	for _, d := range a.Days {
+		date, _ := time.Parse("2006-01-02", d.Date)
+		fmt.Fprintf(&b, "\n## %s\n\n", date.Format("Monday, 2 January 2006"))
+		if len(d.Events) == 0 && len(d.Tasks) == 0 {
+			b.WriteString("_Nothing scheduled_\n")
+			continue
+		}
+		for _, e := range d.Events {
+			fmt.Fprintf(&b, "- %s %s%s\n", eventTime(e, d.Date), e.Title, prefixed(" @ ", e.Location))
+		}
+		for _, t := range d.Tasks {
+			fmt.Fprintf(&b, "- [ ] %s%s\n", t.Title, prefixed(" ", parenthesized(t.Priority)))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// eventDays returns the first and last day an event touches.
+func eventDays(e notioncli.Event, loc *time.Location) (time.Time, time.Time, bool) {
+	start, ok := parse(e.Start, loc)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	end := start
+	if t, ok := parse(e.End, loc); ok {
+		end = t
+		// A timed event ending exactly at midnight doesn't touch that day
+		if !e.AllDay && end.Equal(midnight(end)) && end.After(start) {
+			end = end.Add(-time.Nanosecond)
+		}
+	}
+	return midnight(start), midnight(end), true
+}
+
+// eventTime describes when an event happens on the given day.
+func eventTime(e notioncli.Event, day string) string {
+	if e.AllDay {
+		return "all day"
+	}
+	start, _ := time.Parse(time.RFC3339, e.Start)
+	end, hasEnd := time.Time{}, false
+	if e.End != "" {
+		if t, err := time.Parse(time.RFC3339, e.End); err == nil {
+			end, hasEnd = t, true
+		}
+	}
+
+	from := start.Format("15:04")
+	if start.Format("2006-01-02") != day {
+		from = "…"
+	}
+	if !hasEnd {
+		return from
+	}
+	to := end.Format("15:04")
+	if end.Format("2006-01-02") != day {
+		to = "…"
+	}
+	return from + "–" + to
+}
+
+func taskTime(t notioncli.Task) string {
+	if due, err := time.Parse(time.RFC3339, t.DueDate); err == nil {
+		return due.Format("15:04")
+	}
+	return ""
+}
+
+func taskDetails(t notioncli.Task) string {
+	var parts []string
+	for _, s := range []string{t.Priority, t.Status} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+func sortEvents(events []notioncli.Event, loc *time.Location) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].AllDay != events[j].AllDay {
+			return events[i].AllDay
+		}
+		a, _ := parse(events[i].Start, loc)
+		b, _ := parse(events[j].Start, loc)
+		return a.Before(b)
+	})
+}
+
+func sortTasks(tasks []notioncli.Task, loc *time.Location) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, _ := parse(tasks[i].DueDate, loc)
+		b, _ := parse(tasks[j].DueDate, loc)
+		if !midnight(a).Equal(midnight(b)) {
+			return a.Before(b)
+		}
+		if pa, pb := priorityRank(tasks[i].Priority), priorityRank(tasks[j].Priority); pa != pb {
+			return pa < pb
+		}
+		return a.Before(b)
+	})
+}
+
+// priorityRank orders the documented priorities, with unknown ones last.
+func priorityRank(p string) int {
+	switch strings.ToLower(p) {
+	case "high", "urgent":
+		return 0
+	case "medium":
+		return 1
+	case "low":
+		return 2
+	}
+	return 3
+}
+
+// parse reads the date formats the client emits: RFC 3339 or YYYY-MM-DD.
+func parse(s string, loc *time.Location) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.In(loc), true
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, loc); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func prefixed(prefix, s string) string {
+	if s == "" {
+		return ""
+	}
+	return prefix + s
+}
+
+func parenthesized(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "(" + s + ")"
+}
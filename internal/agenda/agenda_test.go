@@ -0,0 +1,160 @@
+package agenda
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jontk/notion-cli/pkg/notioncli"
+)
+
+func eventIDs(events []notioncli.Event) string {
+	ids := make([]string, 0, len(events))
+	for _, e := range events {
+		ids = append(ids, e.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func taskIDs(tasks []notioncli.Task) string {
+	ids := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestBuild(t *testing.T) {
+	loc := time.FixedZone("CEST", 2*60*60)
+	from := time.Date(2026, 10, 19, 10, 0, 0, 0, loc)
+	events := []notioncli.Event{
+		{ID: "timed", Start: "2026-10-20T09:00:00+02:00", End: "2026-10-20T10:00:00+02:00"},
+		{ID: "allday", Start: "2026-10-20", AllDay: true},
+		{ID: "ends-at-midnight", Start: "2026-10-18T22:00:00+02:00", End: "2026-10-19T00:00:00+02:00"},
+		{ID: "multiday", Start: "2026-10-19T20:00:00+02:00", End: "2026-10-21T08:00:00+02:00"},
+		{ID: "utc", Start: "2026-10-20T23:30:00Z"},
+		{ID: "undated"},
+		{ID: "later", Start: "2026-10-22", AllDay: true},
+	}
+	tasks := []notioncli.Task{
+		{ID: "yesterday", DueDate: "2026-10-18"},
+		{ID: "last-week", DueDate: "2026-10-12", Priority: "High"},
+		{ID: "low", DueDate: "2026-10-19", Priority: "Low"},
+		{ID: "high", DueDate: "2026-10-19", Priority: "High"},
+		{ID: "none", DueDate: "2026-10-19"},
+		{ID: "afternoon", DueDate: "2026-10-20T15:00:00+02:00"},
+		{ID: "morning", DueDate: "2026-10-20T08:00:00+02:00", Priority: "Low"},
+		{ID: "undated"},
+		{ID: "later", DueDate: "2026-10-22"},
+	}
+
+	a := Build(events, tasks, from, 3)
+	if a.From != "2026-10-19" || a.To != "2026-10-21" {
+		t.Errorf("range = %s to %s, want 2026-10-19 to 2026-10-21", a.From, a.To)
+	}
+	if got, want := taskIDs(a.Overdue), "last-week,yesterday"; got != want {
+		t.Errorf("overdue = %s, want %s", got, want)
+	}
+	for i, want := range []struct {
+		date, weekday, events, tasks string
+	}{
+		{"2026-10-19", "Monday", "multiday", "high,low,none"},
+		{"2026-10-20", "Tuesday", "allday,multiday,timed", "morning,afternoon"},
+		{"2026-10-21", "Wednesday", "multiday,utc", ""},
+	} {
+		d := a.Days[i]
+		if d.Date != want.date || d.Weekday != want.weekday {
+			t.Errorf("day %d = %s %s, want %s %s", i, d.Date, d.Weekday, want.date, want.weekday)
+		}
+		if got := eventIDs(d.Events); got != want.events {
+			t.Errorf("%s events = %s, want %s", d.Date, got, want.events)
+		}
+		if got := taskIDs(d.Tasks); got != want.tasks {
+			t.Errorf("%s tasks = %s, want %s", d.Date, got, want.tasks)
+		}
+	}
+}
+
+func TestBuildAtLeastOneDay(t *testing.T) {
+	a := Build(nil, nil, time.Date(2026, 10, 19, 23, 0, 0, 0, time.UTC), 0)
+	if len(a.Days) != 1 || a.From != "2026-10-19" || a.To != "2026-10-19" {
+		t.Errorf("Build with 0 days = %s to %s with %d days, want one day", a.From, a.To, len(a.Days))
+	}
+	if a.Overdue == nil || a.Days[0].Events == nil || a.Days[0].Tasks == nil {
+		t.Errorf("empty lists are nil, want them encoded as []")
+	}
+}
+
+func TestEventTime(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ev   notioncli.Event
+		day  string
+		want string
+	}{
+		{"all day", notioncli.Event{Start: "2026-10-20", AllDay: true}, "2026-10-20", "all day"},
+		{"no end", notioncli.Event{Start: "2026-10-20T09:00:00+02:00"}, "2026-10-20", "09:00"},
+		{"same day", notioncli.Event{Start: "2026-10-20T09:00:00+02:00", End: "2026-10-20T10:30:00+02:00"}, "2026-10-20", "09:00–10:30"},
+		{"continues", notioncli.Event{Start: "2026-10-19T20:00:00+02:00", End: "2026-10-21T08:00:00+02:00"}, "2026-10-19", "20:00–…"},
+		{"all of a middle day", notioncli.Event{Start: "2026-10-19T20:00:00+02:00", End: "2026-10-21T08:00:00+02:00"}, "2026-10-20", "…–…"},
+		{"ends", notioncli.Event{Start: "2026-10-19T20:00:00+02:00", End: "2026-10-21T08:00:00+02:00"}, "2026-10-21", "…–08:00"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := eventTime(tc.ev, tc.day); got != tc.want {
+				t.Errorf("eventTime = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name   string
+		events []notioncli.Event
+		tasks  []notioncli.Task
+		days   int
+		want   string
+	}{
+		{"empty day", nil, nil, 1, "# Agenda for 2026-10-19\n\n## Monday, 19 October 2026\n\n_Nothing scheduled_\n"},
+		{"busy days",
+			[]notioncli.Event{{Title: "Standup", Start: "2026-10-20T09:00:00Z", End: "2026-10-20T09:15:00Z", Location: "Room 1"}},
+			[]notioncli.Task{{Title: "Report", DueDate: "2026-10-01", Priority: "High"}, {Title: "Review", DueDate: "2026-10-19"}},
+			2,
+			"# Agenda 2026-10-19 to 2026-10-20\n\n## Overdue\n\n- [ ] Report (due 2026-10-01, High)\n" +
+				"\n## Monday, 19 October 2026\n\n- [ ] Review\n" +
+				"\n## Tuesday, 20 October 2026\n\n- 09:00–09:15 Standup @ Room 1\n"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Build(tc.events, tc.tasks, from, tc.days).WriteMarkdown(&buf); err != nil {
+				t.Fatalf("WriteMarkdown: %v", err)
+			}
+			if buf.String() != tc.want {
+				t.Errorf("WriteMarkdown =\n%s\nwant\n%s", buf.String(), tc.want)
+			}
+		})
+	}
+}
+
+func TestRows(t *testing.T) {
+	a := Build(
+		[]notioncli.Event{{Title: "Offsite", Start: "2026-10-19", AllDay: true, Location: "Bergen"}},
+		[]notioncli.Task{{Title: "Report", DueDate: "2026-10-01", Priority: "High", Status: "Blocked"}, {Title: "Call", DueDate: "2026-10-19T14:00:00Z"}},
+		time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), 1)
+	want := [][]string{
+		{"overdue", "2026-10-01", "task", "Report", "High · Blocked"},
+		{"2026-10-19", "all day", "event", "Offsite", "Bergen"},
+		{"2026-10-19", "14:00", "task", "Call", ""},
+	}
+	got := a.Rows()
+	if len(got) != len(want) {
+		t.Fatalf("Rows = %q, want %q", got, want)
+	}
+	for i := range want {
+		if strings.Join(got[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("row %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,271 @@
+// Package board lays out items in status columns and renders them as a
+// kanban board for the terminal.
+package board
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// NoStatus is the column of items without a status.
+const NoStatus = "No Status"
+
+// Card is one item on the board.
+type Card struct {
+	ID      string   `json:"id"`
+	Title   string   `json:"title"`
+	Details []string `json:"details,omitempty"`
+	Status  string   `json:"-"`
+}
+
+// Column holds the cards with one status. Limit is the WIP limit, zero when
+// the column has none.
+type Column struct {
+	Name      string `json:"name"`
+	Count     int    `json:"count"`
+	Limit     int    `json:"limit,omitempty"`
+	OverLimit bool   `json:"over_limit,omitempty"`
+	Cards     []Card `json:"cards"`
+}
+
+// Board is a set of status columns.
+type Board struct {
+	Title   string   `json:"title"`
+	Columns []Column `json:"columns"`
+}
+
+// Options controls how a board is rendered.
+type Options struct {
+	// Width is the total width to fit the board into.
+	Width int
+	// MaxCards is the most cards shown per column; the rest are counted.
+	// Zero shows every card.
+	MaxCards int
+	// ASCII draws the frame with plain ASCII instead of box-drawing
+	// characters.
+	ASCII bool
+}
+
+// minColumnWidth is the narrowest a column is drawn, even when the board
+// doesn't fit the width it was given.
+const minColumnWidth = 14
+
+// Build sorts cards into columns. Columns follow order, which is usually the
+// status options of the database; statuses missing from it get columns at
+// the end, and cards without a status go in NoStatus. When only is not nil,
+// just those statuses get columns. WIP limits are matched to statuses
+// regardless of case.
+func Build(title string, order []string, cards []Card, limits map[string]int, only []string) *Board {
+	b := &Board{Title: title, Columns: []Column{}}
+	index := make(map[string]int)
+
+	allowed := func(status string) bool {
+		if only == nil {
+			return true
+		}
+		for _, s := range only {
+			if strings.EqualFold(s, status) {
+				return true
+			}
+		}
+		return false
+	}
+	add := func(status string) int {
+		key := strings.ToLower(status)
+		if i, ok := index[key]; ok {
+			return i
+		}
+		col := Column{Name: status, Cards: []Card{}}
+		for name, limit := range limits {
+			if strings.EqualFold(name, status) {
+				col.Limit = limit
+			}
+		}
+		b.Columns = append(b.Columns, col)
+		index[key] = len(b.Columns) - 1
+		return index[key]
+	}
+
+	for _, status := range order {
+		if allowed(status) {
+			add(status)
+		}
+	}
+	for _, card := range cards {
+		status := card.Status
+		if status == "" {
+			status = NoStatus
+		}
+		if !allowed(status) {
+			continue
+		}
+		i := add(status)
+		b.Columns[i].Cards = append(b.Columns[i].Cards, card)
+	}
+
+	for i := range b.Columns {
+		col := &b.Columns[i]
+		col.Count = len(col.Cards)
+		col.OverLimit = col.Limit > 0 && col.Count > col.Limit
+	}
+	return b
+}
+
+// Warnings describes the columns over their WIP limit.
+func (b *Board) Warnings() []string {
+	var warnings []string
+	for _, col := range b.Columns {
+		if col.OverLimit {
+			warnings = append(warnings, fmt.Sprintf("%s has %d items, over its WIP limit of %d", col.Name, col.Count, col.Limit))
+		}
+	}
+	return warnings
+}
+
+// frame holds the characters the board is drawn with.
+type frame struct {
+	h, v                                     string
+	tl, tm, tr, ml, mm, mr, bl, bm, br, warn string
+	bullet, sep, ellipsis                    string
+}
+
+var (
+	unicodeFrame = frame{"─", "│", "┌", "┬", "┐", "├", "┼", "┤", "└", "┴", "┘", "⚠", "•", " · ", "…"}
+	asciiFrame   = frame{"-", "|", "+", "+", "+", "+", "+", "+", "+", "+", "+", "!", "*", " - ", "~"}
+)
+
+// Write renders the board as side-by-side columns, each headed by its
+// status and card count, followed by any WIP-limit warnings.
+func (b *Board) Write(w io.Writer, opts Options) error {
+	f := unicodeFrame
+	if opts.ASCII {
+		f = asciiFrame
+	}
+	var sb strings.Builder
+
+	if b.Title != "" {
+		sb.WriteString(b.Title + "\n")
+	}
+	if len(b.Columns) == 0 {
+		sb.WriteString("(no columns)\n")
+		_, err := io.WriteString(w, sb.String())
+		return err
+	}
+
+	n := len(b.Columns)
+	width := (opts.Width - (n + 1)) / n
+	if width < minColumnWidth {
+		width = minColumnWidth
+	}
+	inner := width - 2
+
+	cells := make([][]string, n)
+	headers := make([]string, n)
+	height := 0
+	for i, col := range b.Columns {
+		// Keep the count visible when the name has to be cut
+		count := fmt.Sprintf(" (%d)", col.Count)
+		if col.Limit > 0 {
+			count = fmt.Sprintf(" (%d/%d)", col.Count, col.Limit)
+		}
+		name := col.Name
+		if col.OverLimit {
+			name = f.warn + " " + name
+		}
+		headers[i] = truncate(name, inner-utf8.RuneCountInString(count), f.ellipsis) + count
+
+		cards := col.Cards
+		hidden := 0
+		if opts.MaxCards > 0 && len(cards) > opts.MaxCards {
+			hidden = len(cards) - opts.MaxCards
+			cards = cards[:opts.MaxCards]
+		}
+		var lines []string
+		for j, card := range cards {
+			if j > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, truncate(f.bullet+" "+card.Title, inner, f.ellipsis))
+			if len(card.Details) > 0 {
+				lines = append(lines, truncate("  "+strings.Join(card.Details, f.sep), inner, f.ellipsis))
+			}
+		}
+		if hidden > 0 {
+			lines = append(lines, "", fmt.Sprintf("+%d more", hidden))
+		}
+		cells[i] = lines
+		if len(lines) > height {
+			height = len(lines)
+		}
+	}
+
+	rule := func(left, mid, right string) {
+		sb.WriteString(left)
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				sb.WriteString(mid)
+			}
+			sb.WriteString(strings.Repeat(f.h, width))
+		}
+		sb.WriteString(right + "\n")
+	}
+	row := func(cell func(i int) string) {
+		for i := 0; i < n; i++ {
+			sb.WriteString(f.v + " " + pad(truncate(cell(i), inner, f.ellipsis), inner) + " ")
+		}
+		sb.WriteString(f.v + "\n")
+	}
+
+	rule(f.tl, f.tm, f.tr)
+	row(func(i int) string { return headers[i] })
+	rule(f.ml, f.mm, f.mr)
+	for line := 0; line < height; line++ {
+		row(func(i int) string {
+			if line < len(cells[i]) {
+				return cells[i][line]
+			}
+			return ""
+		})
+	}
+	rule(f.bl, f.bm, f.br)
+
+	for _, warning := range b.Warnings() {
+		sb.WriteString(f.warn + " " + warning + "\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// truncate shortens s to at most width characters, marking the cut.
+func truncate(s string, width int, ellipsis string) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	if width < 1 {
+		return ""
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + ellipsis
+}
+
+// pad fills s with spaces up to width characters.
+func pad(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+// DefaultWidth returns the width of the terminal as reported by COLUMNS,
+// or 120 when it isn't set.
+func DefaultWidth() int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	return 120
+}
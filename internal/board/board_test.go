@@ -0,0 +1,169 @@
+package board
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func columns(b *Board) string {
+	var parts []string
+	for _, col := range b.Columns {
+		var ids []string
+		for _, c := range col.Cards {
+			ids = append(ids, c.ID)
+		}
+		parts = append(parts, fmt.Sprintf("%s[%s]", col.Name, strings.Join(ids, ",")))
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestBuild(t *testing.T) {
+	cards := []Card{
+		{ID: "1", Status: "Done"},
+		{ID: "2", Status: "To Do"},
+		{ID: "3"},
+		{ID: "4", Status: "Archived"},
+		{ID: "5", Status: "to do"},
+	}
+	order := []string{"To Do", "In Progress", "Done"}
+	for _, tc := range []struct {
+		name string
+		only []string
+		want string
+	}{
+		{"all", nil, "To Do[2,5] In Progress[] Done[1] No Status[3] Archived[4]"},
+		{"only some", []string{"done", "to do"}, "To Do[2,5] Done[1]"},
+		{"only no status", []string{NoStatus}, "No Status[3]"},
+		{"only nothing", []string{}, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := columns(Build("", order, cards, nil, tc.only)); got != tc.want {
+				t.Errorf("Build = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBuildLimits(t *testing.T) {
+	cards := []Card{{ID: "1", Status: "Doing"}, {ID: "2", Status: "Doing"}, {ID: "3", Status: "Review"}}
+	b := Build("", []string{"Doing", "Review", "Done"}, cards, map[string]int{"doing": 1, "REVIEW": 1}, nil)
+	for i, want := range []Column{
+		{Name: "Doing", Count: 2, Limit: 1, OverLimit: true},
+		{Name: "Review", Count: 1, Limit: 1},
+		{Name: "Done"},
+	} {
+		col := b.Columns[i]
+		if col.Name != want.Name || col.Count != want.Count || col.Limit != want.Limit || col.OverLimit != want.OverLimit {
+			t.Errorf("column %d = %+v, want %+v", i, col, want)
+		}
+	}
+	warnings := b.Warnings()
+	if len(warnings) != 1 || warnings[0] != "Doing has 2 items, over its WIP limit of 1" {
+		t.Errorf("Warnings = %q", warnings)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	b := Build("Tasks", []string{"To Do", "Done"}, []Card{
+		{ID: "1", Title: "Write report", Details: []string{"High", "Mon"}, Status: "To Do"},
+		{ID: "2", Title: "A rather long title", Status: "To Do"},
+	}, map[string]int{"To Do": 1}, nil)
+
+	for _, tc := range []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{"ascii", Options{Width: 35, MaxCards: 1, ASCII: true}, `Tasks
++----------------+----------------+
+| ! To Do (2/1)  | Done (0)       |
++----------------+----------------+
+| * Write report |                |
+|   High - Mon   |                |
+|                |                |
+| +1 more        |                |
++----------------+----------------+
+! To Do has 2 items, over its WIP limit of 1
+`},
+		{"unicode", Options{Width: 35}, `Tasks
+┌────────────────┬────────────────┐
+│ ⚠ To Do (2/1)  │ Done (0)       │
+├────────────────┼────────────────┤
+│ • Write report │                │
+│   High · Mon   │                │
+│                │                │
+│ • A rather lo… │                │
+└────────────────┴────────────────┘
+⚠ To Do has 2 items, over its WIP limit of 1
+`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := b.Write(&buf, tc.opts); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+			if buf.String() != tc.want {
+				t.Errorf("Write =\n%s\nwant\n%s", buf.String(), tc.want)
+			}
+		})
+	}
+}
+
+func TestWriteNoColumns(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Build("Empty", nil, nil, nil, nil).Write(&buf, Options{Width: 80}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got, want := buf.String(), "Empty\n(no columns)\n"; got != want {
+		t.Errorf("Write = %q, want %q", got, want)
+	}
+}
+
+func TestWriteNarrow(t *testing.T) {
+	b := Build("", []string{"A", "B", "C", "D"}, nil, nil, nil)
+	var buf bytes.Buffer
+	if err := b.Write(&buf, Options{Width: 20}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	first := strings.SplitN(buf.String(), "\n", 2)[0]
+	if got, want := len([]rune(first)), 4*minColumnWidth+5; got != want {
+		t.Errorf("board is %d wide, want columns of at least %d (%d)", got, minColumnWidth, want)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	for _, tc := range []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"exactly", 7, "exactly"},
+		{"too long", 5, "too …"},
+		{"ærlig talt", 6, "ærlig…"},
+		{"x", 0, ""},
+	} {
+		if got := truncate(tc.in, tc.width, "…"); got != tc.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tc.in, tc.width, got, tc.want)
+		}
+	}
+}
+
+func TestDefaultWidth(t *testing.T) {
+	for _, tc := range []struct {
+		env  string
+		want int
+	}{
+		{"", 120},
+		{"80", 80},
+		{"wide", 120},
+		{"-5", 120},
+	} {
+		t.Setenv("COLUMNS", tc.env)
+		if got := DefaultWidth(); got != tc.want {
+			t.Errorf("DefaultWidth with COLUMNS=%q = %d, want %d", tc.env, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Package codegen renders typed definitions for the pages of a Notion
+// database from its schema: a Go struct or a TypeScript interface with one
+// field per property, plus the page ID and URL.
+//
+// Property names become identifiers word by word, so "Due Date" is DueDate
+// in Go and due_date in JSON and TypeScript. Select and status options are
+// listed in Go comments and become string unions in TypeScript.
+package codegen
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jontk/notion-cli/pkg/notioncli"
+)
+
+// field is a database property as a struct field
+type field struct {
+	Property string
+	Type     string
+	GoName   string
+	JSONName string
+	Options  []string
+}
+
+// initialisms are words written in capitals in Go names
+var initialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "ID": true, "JSON": true,
+	"UID": true, "URL": true, "UUID": true,
+}
+
+// TypeName turns a database title into a type name for one of its pages,
+// e.g. "Tasks" into Task and "Reading list" into ReadingList
+func TypeName(title string) string {
+	name := goName(title)
+	switch {
+	case name == "":
+		return "Page"
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss") &&
+		!strings.HasSuffix(name, "us") && !strings.HasSuffix(name, "is"):
+		return strings.TrimSuffix(name, "s")
+	}
+	return name
+}
+
+// words splits a property name into its letters and digits
+func words(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// goName returns an exported Go identifier for a name
+func goName(s string) string {
+	var b strings.Builder
+	for _, w := range words(s) {
+		if upper := strings.ToUpper(w); initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		r := []rune(w)
+		b.WriteString(strings.ToUpper(string(r[0])) + string(r[1:]))
+	}
+	name := b.String()
+	if name != "" && unicode.IsDigit([]rune(name)[0]) {
+		name = "P" + name
+	}
+	return name
+}
+
+// jsonName returns a snake_case name for a property
+func jsonName(s string) string {
+	ws := words(s)
+	for i, w := range ws {
+		ws[i] = strings.ToLower(w)
+	}
+	return strings.Join(ws, "_")
+}
+
+// fields lists the properties of a schema as fields: the title first, then
+// the others by name. Names that would clash get a number.
+func fields(schema *notioncli.Schema) []field {
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		ti, tj := schema.Properties[names[i]].Type == "title", schema.Properties[names[j]].Type == "title"
+		if ti != tj {
+			return ti
+		}
+		return names[i] < names[j]
+	})
+
+	usedGo := map[string]bool{"ID": true, "URL": true}
+	usedJSON := map[string]bool{"id": true, "url": true}
+	out := make([]field, 0, len(names))
+	for _, name := range names {
+		prop := schema.Properties[name]
+		f := field{Property: name, Type: prop.Type, GoName: goName(name), JSONName: jsonName(name)}
+		if f.GoName == "" {
+			f.GoName, f.JSONName = "Property", "property"
+		}
+		for base, n := f.GoName, 2; usedGo[f.GoName]; n++ {
+			f.GoName = fmt.Sprintf("%s%d", base, n)
+		}
+		for base, n := f.JSONName, 2; usedJSON[f.JSONName]; n++ {
+			f.JSONName = fmt.Sprintf("%s_%d", base, n)
+		}
+		usedGo[f.GoName], usedJSON[f.JSONName] = true, true
+		for _, opt := range prop.Options {
+			f.Options = append(f.Options, opt.Name)
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// goType returns the Go type a property's values are read into
+func goType(propertyType string) string {
+	switch propertyType {
+	case "number":
+		return "float64"
+	case "checkbox":
+		return "bool"
+	case "multi_select", "people", "relation", "files":
+		return "[]string"
+	}
+	return "string"
+}
+
+// tsType returns the TypeScript type of a property's values
+func tsType(f field) string {
+	union := make([]string, 0, len(f.Options))
+	for _, opt := range f.Options {
+		union = append(union, fmt.Sprintf("%q", opt))
+	}
+	switch {
+	case f.Type == "number":
+		return "number"
+	case f.Type == "checkbox":
+		return "boolean"
+	case f.Type == "multi_select" && len(union) > 0:
+		return "Array<" + strings.Join(union, " | ") + ">"
+	case goType(f.Type) == "[]string":
+		return "string[]"
+	case (f.Type == "select" || f.Type == "status") && len(union) > 0:
+		return strings.Join(union, " | ")
+	}
+	return "string"
+}
+
+// GoStruct renders a Go struct for the pages of a database, formatted as
+// gofmt would
+func GoStruct(name string, schema *notioncli.Schema) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s is a page of the %q database\n", name, schema.Title)
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	b.WriteString("ID string `json:\"id\"`\n")
+	b.WriteString("URL string `json:\"url\"`\n")
+	for _, f := range fields(schema) {
+		if len(f.Options) > 0 {
+			fmt.Fprintf(&b, "// %s is one of: %s\n", f.GoName, strings.Join(f.Options, ", "))
+		}
+		tag := f.JSONName
+		if f.Type != "title" {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&b, "%s %s `json:\"%s\"`\n", f.GoName, goType(f.Type), tag)
+	}
+	b.WriteString("}\n")
+	return gofmt(b.String())
+}
+
+// gofmt formats Go source, or returns it as it is if it doesn't parse
+func gofmt(src string) string {
+	out, err := format.Source([]byte(src))
+	if err != nil {
+		return src
+	}
+	return string(out)
+}
+
+// TypeScript renders a TypeScript interface for the pages of a database
+func TypeScript(name string, schema *notioncli.Schema) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "/** A page of the %q database. */\n", schema.Title)
+	fmt.Fprintf(&b, "export interface %s {\n", name)
+	b.WriteString("  id: string;\n")
+	b.WriteString("  url: string;\n")
+	for _, f := range fields(schema) {
+		optional := "?"
+		if f.Type == "title" {
+			optional = ""
+		}
+		fmt.Fprintf(&b, "  %s%s: %s;\n", f.JSONName, optional, tsType(f))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,148 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/jontk/notion-cli/pkg/notioncli"
+)
+
+func testSchema() *notioncli.Schema {
+	return &notioncli.Schema{
+		Title: "Reading list",
+		Properties: map[string]notioncli.PropertyInfo{
+			"Name":      {Type: "title"},
+			"Due Date":  {Type: "date"},
+			"Blog URL":  {Type: "url"},
+			"URL":       {Type: "url"},
+			"Pages":     {Type: "number"},
+			"Done":      {Type: "checkbox"},
+			"Shelf":     {Type: "select", Options: []notioncli.OptionInfo{{Name: "To read"}, {Name: "Read"}}},
+			"Tags":      {Type: "multi_select", Options: []notioncli.OptionInfo{{Name: "go"}}},
+			"Authors":   {Type: "people"},
+			"2024 pick": {Type: "checkbox"},
+		},
+	}
+}
+
+func TestTypeName(t *testing.T) {
+	for title, want := range map[string]string{
+		"Tasks":        "Task",
+		"Reading list": "ReadingList",
+		"Stories":      "Story",
+		"Status":       "Status",
+		"Analysis":     "Analysis",
+		"":             "Page",
+		"🎯 Goals":      "Goal",
+	} {
+		if got := TypeName(title); got != want {
+			t.Errorf("TypeName(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
+
+func TestGoStruct(t *testing.T) {
+	src := GoStruct("Book", testSchema())
+	if _, err := parser.ParseFile(token.NewFileSet(), "book.go", "package books\n\n"+src, 0); err != nil {
+		t.Fatalf("GoStruct output doesn't parse: %v\n%s", err, src)
+	}
+	// gofmt aligns fields in runs, so compare with spacing collapsed
+	flat := strings.Join(strings.Fields(src), " ")
+	for _, want := range []string{
+		`// Book is a page of the "Reading list" database`,
+		"ID string `json:\"id\"`",
+		"Name string `json:\"name\"`",
+		"BlogURL string `json:\"blog_url,omitempty\"`",
+		"URL2 string `json:\"url_2,omitempty\"`",
+		"P2024Pick bool `json:\"2024_pick,omitempty\"`",
+		"Pages float64 `json:\"pages,omitempty\"`",
+		"// Shelf is one of: To read, Read",
+		"Authors []string `json:\"authors,omitempty\"`",
+	} {
+		if !strings.Contains(flat, want) {
+			t.Errorf("GoStruct output lacks %q:\n%s", want, src)
+		}
+	}
+	if strings.Index(src, "Name ") > strings.Index(src, "Authors") {
+		t.Errorf("title field isn't first:\n%s", src)
+	}
+}
+
+func TestTypeScript(t *testing.T) {
+	src := TypeScript("Book", testSchema())
+	for _, want := range []string{
+		"export interface Book {\n",
+		"  name: string;\n",
+		"  shelf?: \"To read\" | \"Read\";\n",
+		"  tags?: Array<\"go\">;\n",
+		"  authors?: string[];\n",
+		"  pages?: number;\n",
+		"  done?: boolean;\n",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("TypeScript output lacks %q:\n%s", want, src)
+		}
+	}
+}
+
+func TestGoModels(t *testing.T) {
+	schema := testSchema()
+	schema.ID = "db-1"
+	src, err := GoModels("books", []Model{{Name: "Book", Schema: schema}})
+	if err != nil {
+		t.Fatalf("GoModels: %v", err)
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "books.go", src, 0)
+	if err != nil {
+		t.Fatalf("GoModels output doesn't parse: %v\n%s", err, src)
+	}
+	if file.Name.Name != "books" {
+		t.Errorf("package = %s, want books", file.Name.Name)
+	}
+	// Check the generated code against the notionapi it will be built with
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("books", fset, []*ast.File{file}, nil); err != nil {
+		t.Fatalf("GoModels output doesn't type-check: %v\n%s", err, src)
+	}
+
+	flat := strings.Join(strings.Fields(src), " ")
+	for _, want := range []string{
+		`const BookDatabaseID = "db-1"`,
+		"type BookInput struct {",
+		"Pages *float64",
+		"Done *bool",
+		"func DecodeBook(page *notionapi.Page) *Book {",
+		"func EncodeBookInput(in BookInput) (notionapi.Properties, error) {",
+		"func CreateBook(ctx context.Context, client *notionapi.Client, in BookInput) (*Book, error) {",
+		"func UpdateBook(ctx context.Context, client *notionapi.Client, pageID string, in BookInput) (*Book, error) {",
+		`if p, ok := page.Properties["Due Date"].(*notionapi.DateProperty); ok && p.Date != nil && p.Date.Start != nil {`,
+		`props["Shelf"] = notionapi.SelectProperty{Select: notionapi.Option{Name: in.Shelf}}`,
+		`props["Authors"] = notionapi.PeopleProperty{People: users(in.Authors)}`,
+	} {
+		if !strings.Contains(flat, want) {
+			t.Errorf("GoModels output lacks %q", want)
+		}
+	}
+}
+
+func TestGoModelsInvalid(t *testing.T) {
+	schema := testSchema()
+	for name, ms := range map[string][]Model{
+		"duplicate names": {{Name: "Book", Schema: schema}, {Name: "Book", Schema: schema}},
+		"unexported name": {{Name: "book", Schema: schema}},
+		"no databases":    nil,
+	} {
+		if _, err := GoModels("books", ms); err == nil {
+			t.Errorf("GoModels with %s succeeded", name)
+		}
+	}
+	if _, err := GoModels("my-books", []Model{{Name: "Book", Schema: schema}}); err == nil {
+		t.Error("GoModels with an invalid package name succeeded")
+	}
+}
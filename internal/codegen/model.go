@@ -0,0 +1,384 @@
+package codegen
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"strings"
+
+	"github.com/jontk/notion-cli/pkg/notioncli"
+)
+
+// Model is a database to generate code for and the name of its page type
+type Model struct {
+	Name   string
+	Schema *notioncli.Schema
+}
+
+// writable reports whether pages can set a property type through the API.
+// Files need uploads, so they are read but not written.
+func writable(propertyType string) bool {
+	switch propertyType {
+	case "title", "rich_text", "number", "select", "multi_select", "status",
+		"date", "people", "checkbox", "url", "email", "phone_number", "relation":
+		return true
+	}
+	return false
+}
+
+// inputType returns the Go type of a property in an input struct. Numbers
+// and checkboxes are pointers so that zero and false can be set.
+func inputType(propertyType string) string {
+	switch propertyType {
+	case "number":
+		return "*float64"
+	case "checkbox":
+		return "*bool"
+	}
+	return goType(propertyType)
+}
+
+// GoModels renders a Go file for the pages of one or more databases. Each
+// gets a struct, an input struct, functions decoding pages and encoding
+// inputs as notionapi.Properties, and functions creating and updating
+// pages. The helpers they share are written once, so the databases of one
+// package should be generated together.
+func GoModels(pkg string, ms []Model) (string, error) {
+	if !token.IsIdentifier(pkg) {
+		return "", fmt.Errorf("%q isn't a valid package name", pkg)
+	}
+	if len(ms) == 0 {
+		return "", fmt.Errorf("no databases to generate")
+	}
+	seen := map[string]bool{}
+	titles := make([]string, 0, len(ms))
+	for _, m := range ms {
+		if !token.IsIdentifier(m.Name) || !token.IsExported(m.Name) {
+			return "", fmt.Errorf("%q isn't a valid exported type name", m.Name)
+		}
+		if seen[m.Name] {
+			return "", fmt.Errorf("two databases would both generate %s; name them apart", m.Name)
+		}
+		seen[m.Name] = true
+		titles = append(titles, fmt.Sprintf("%q", m.Schema.Title))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Code generated by notion-cli codegen from the %s database%s; DO NOT EDIT.\n\n",
+		strings.Join(titles, ", "), plural(len(ms)))
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	b.WriteString("import (\n\"context\"\n\"fmt\"\n\"strconv\"\n\"strings\"\n\"time\"\n\n\"github.com/jomei/notionapi\"\n)\n")
+	for _, m := range ms {
+		b.WriteString("\n")
+		writeModel(&b, m)
+	}
+	b.WriteString(helpers)
+
+	out, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return "", fmt.Errorf("generated code doesn't parse: %w", err)
+	}
+	return string(out), nil
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+// writeModel writes the types and functions for one database
+func writeModel(b *strings.Builder, m Model) {
+	name, schema := m.Name, m.Schema
+	fs := fields(schema)
+
+	fmt.Fprintf(b, "// %sDatabaseID is the database %s was generated from\n", name, name)
+	fmt.Fprintf(b, "const %sDatabaseID = %q\n\n", name, schema.ID)
+	b.WriteString(GoStruct(name, schema))
+
+	fmt.Fprintf(b, "\n// %sInput holds the properties to set when creating or updating a %s.\n", name, name)
+	b.WriteString("// Empty strings and lists and nil pointers leave a property as it is;\n")
+	b.WriteString("// people are user IDs and relations page IDs.\n")
+	fmt.Fprintf(b, "type %sInput struct {\n", name)
+	for _, f := range fs {
+		if writable(f.Type) {
+			fmt.Fprintf(b, "%s %s\n", f.GoName, inputType(f.Type))
+		}
+	}
+	b.WriteString("}\n")
+
+	fmt.Fprintf(b, "\n// Decode%s reads a page of the database into a %s\n", name, name)
+	fmt.Fprintf(b, "func Decode%s(page *notionapi.Page) *%s {\n", name, name)
+	fmt.Fprintf(b, "v := &%s{ID: string(page.ID), URL: page.URL}\n", name)
+	for _, f := range fs {
+		writeDecode(b, f)
+	}
+	b.WriteString("return v\n}\n")
+
+	fmt.Fprintf(b, "\n// Encode%sInput turns the fields set in an input into page properties\n", name)
+	fmt.Fprintf(b, "func Encode%sInput(in %sInput) (notionapi.Properties, error) {\n", name, name)
+	b.WriteString("props := notionapi.Properties{}\n")
+	for _, f := range fs {
+		if writable(f.Type) {
+			writeEncode(b, f)
+		}
+	}
+	b.WriteString("return props, nil\n}\n")
+
+	fmt.Fprintf(b, `
+// Create%[1]s creates a page in the database
+func Create%[1]s(ctx context.Context, client *notionapi.Client, in %[1]sInput) (*%[1]s, error) {
+	props, err := Encode%[1]sInput(in)
+	if err != nil {
+		return nil, err
+	}
+	page, err := client.Page.Create(ctx, &notionapi.PageCreateRequest{
+		Parent: notionapi.Parent{
+			Type:       notionapi.ParentTypeDatabaseID,
+			DatabaseID: %[1]sDatabaseID,
+		},
+		Properties: props,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create page: %%w", err)
+	}
+	return Decode%[1]s(page), nil
+}
+
+// Update%[1]s changes the properties set in an input
+func Update%[1]s(ctx context.Context, client *notionapi.Client, pageID string, in %[1]sInput) (*%[1]s, error) {
+	props, err := Encode%[1]sInput(in)
+	if err != nil {
+		return nil, err
+	}
+	page, err := client.Page.Update(ctx, notionapi.PageID(pageID), &notionapi.PageUpdateRequest{
+		Properties: props,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update page: %%w", err)
+	}
+	return Decode%[1]s(page), nil
+}
+`, name)
+}
+
+// writeDecode writes the statement reading a property into a field of v
+func writeDecode(b *strings.Builder, f field) {
+	var typ, expr string
+	cond := ""
+	switch f.Type {
+	case "title":
+		typ, expr = "TitleProperty", "plainText(p.Title)"
+	case "rich_text":
+		typ, expr = "RichTextProperty", "plainText(p.RichText)"
+	case "number":
+		typ, expr = "NumberProperty", "p.Number"
+	case "select":
+		typ, expr = "SelectProperty", "p.Select.Name"
+	case "status":
+		typ, expr = "StatusProperty", "p.Status.Name"
+	case "multi_select":
+		typ, expr = "MultiSelectProperty", "optionNames(p.MultiSelect)"
+	case "date":
+		typ, expr, cond = "DateProperty", "formatDate(p.Date.Start)", " && p.Date != nil && p.Date.Start != nil"
+	case "checkbox":
+		typ, expr = "CheckboxProperty", "p.Checkbox"
+	case "url":
+		typ, expr = "URLProperty", "p.URL"
+	case "email":
+		typ, expr = "EmailProperty", "p.Email"
+	case "phone_number":
+		typ, expr = "PhoneNumberProperty", "p.PhoneNumber"
+	case "people":
+		typ, expr = "PeopleProperty", "userIDs(p.People)"
+	case "relation":
+		typ, expr = "RelationProperty", "relationIDs(p.Relation)"
+	case "files":
+		typ, expr = "FilesProperty", "fileURLs(p.Files)"
+	case "formula":
+		typ, expr = "FormulaProperty", "formulaText(p.Formula)"
+	case "rollup":
+		typ, expr = "RollupProperty", "rollupText(p.Rollup)"
+	case "created_time":
+		typ, expr = "CreatedTimeProperty", "p.CreatedTime.Format(time.RFC3339)"
+	case "last_edited_time":
+		typ, expr = "LastEditedTimeProperty", "p.LastEditedTime.Format(time.RFC3339)"
+	case "created_by":
+		typ, expr = "CreatedByProperty", "string(p.CreatedBy.ID)"
+	case "last_edited_by":
+		typ, expr = "LastEditedByProperty", "string(p.LastEditedBy.ID)"
+	default:
+		fmt.Fprintf(b, "// %s: %s properties aren't read\n", f.GoName, f.Type)
+		return
+	}
+	fmt.Fprintf(b, "if p, ok := page.Properties[%q].(*notionapi.%s); ok%s {\nv.%s = %s\n}\n",
+		f.Property, typ, cond, f.GoName, expr)
+}
+
+// writeEncode writes the statements adding a field of in to props
+func writeEncode(b *strings.Builder, f field) {
+	v := "in." + f.GoName
+	set := func(cond, value string) {
+		fmt.Fprintf(b, "if %s {\nprops[%q] = %s\n}\n", cond, f.Property, value)
+	}
+	switch f.Type {
+	case "title":
+		set(v+` != ""`, "notionapi.TitleProperty{Title: richText("+v+")}")
+	case "rich_text":
+		set(v+` != ""`, "notionapi.RichTextProperty{RichText: richText("+v+")}")
+	case "number":
+		set(v+" != nil", "notionapi.NumberProperty{Number: *"+v+"}")
+	case "select":
+		set(v+` != ""`, "notionapi.SelectProperty{Select: notionapi.Option{Name: "+v+"}}")
+	case "status":
+		set(v+` != ""`, "notionapi.StatusProperty{Status: notionapi.Status{Name: "+v+"}}")
+	case "multi_select":
+		set("len("+v+") > 0", "notionapi.MultiSelectProperty{MultiSelect: options("+v+")}")
+	case "checkbox":
+		set(v+" != nil", "notionapi.CheckboxProperty{Checkbox: *"+v+"}")
+	case "url":
+		set(v+` != ""`, "notionapi.URLProperty{URL: "+v+"}")
+	case "email":
+		set(v+` != ""`, "notionapi.EmailProperty{Email: "+v+"}")
+	case "phone_number":
+		set(v+` != ""`, "notionapi.PhoneNumberProperty{PhoneNumber: "+v+"}")
+	case "people":
+		set("len("+v+") > 0", "notionapi.PeopleProperty{People: users("+v+")}")
+	case "relation":
+		set("len("+v+") > 0", "notionapi.RelationProperty{Relation: relations("+v+")}")
+	case "date":
+		fmt.Fprintf(b, `if %[1]s != "" {
+	d, err := parseDate(%[1]s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %%s: %%w", %[2]q, err)
+	}
+	props[%[2]q] = notionapi.DateProperty{Date: &notionapi.DateObject{Start: &d}}
+}
+`, v, f.Property)
+	}
+}
+
+// helpers are the unexported functions the generated code shares
+const helpers = `
+func richText(s string) []notionapi.RichText {
+	return []notionapi.RichText{{Text: &notionapi.Text{Content: s}}}
+}
+
+func plainText(rt []notionapi.RichText) string {
+	var b strings.Builder
+	for _, t := range rt {
+		b.WriteString(t.PlainText)
+	}
+	return b.String()
+}
+
+func options(names []string) []notionapi.Option {
+	out := make([]notionapi.Option, 0, len(names))
+	for _, name := range names {
+		out = append(out, notionapi.Option{Name: name})
+	}
+	return out
+}
+
+func optionNames(opts []notionapi.Option) []string {
+	out := make([]string, 0, len(opts))
+	for _, o := range opts {
+		out = append(out, o.Name)
+	}
+	return out
+}
+
+func users(ids []string) []notionapi.User {
+	out := make([]notionapi.User, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, notionapi.User{ID: notionapi.UserID(id)})
+	}
+	return out
+}
+
+func userIDs(people []notionapi.User) []string {
+	out := make([]string, 0, len(people))
+	for _, u := range people {
+		out = append(out, string(u.ID))
+	}
+	return out
+}
+
+func relations(ids []string) []notionapi.Relation {
+	out := make([]notionapi.Relation, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, notionapi.Relation{ID: notionapi.PageID(id)})
+	}
+	return out
+}
+
+func relationIDs(rels []notionapi.Relation) []string {
+	out := make([]string, 0, len(rels))
+	for _, r := range rels {
+		out = append(out, string(r.ID))
+	}
+	return out
+}
+
+func fileURLs(files []notionapi.File) []string {
+	out := make([]string, 0, len(files))
+	for _, f := range files {
+		switch {
+		case f.External != nil:
+			out = append(out, f.External.URL)
+		case f.File != nil:
+			out = append(out, f.File.URL)
+		}
+	}
+	return out
+}
+
+// parseDate reads a date as YYYY-MM-DD or RFC 3339
+func parseDate(s string) (notionapi.Date, error) {
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return notionapi.Date(t), nil
+		}
+	}
+	return notionapi.Date{}, fmt.Errorf("%q is neither YYYY-MM-DD nor RFC 3339", s)
+}
+
+// formatDate writes a date without a time of day as YYYY-MM-DD, and other
+// dates as RFC 3339
+func formatDate(d *notionapi.Date) string {
+	t := time.Time(*d)
+	if t.Location() == time.UTC && t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formulaText(f notionapi.Formula) string {
+	switch f.Type {
+	case "string":
+		return f.String
+	case "number":
+		return strconv.FormatFloat(f.Number, 'f', -1, 64)
+	case "boolean":
+		return strconv.FormatBool(f.Boolean)
+	case "date":
+		if f.Date != nil && f.Date.Start != nil {
+			return formatDate(f.Date.Start)
+		}
+	}
+	return ""
+}
+
+func rollupText(r notionapi.Rollup) string {
+	switch r.Type {
+	case "number":
+		return strconv.FormatFloat(r.Number, 'f', -1, 64)
+	case "date":
+		if r.Date != nil && r.Date.Start != nil {
+			return formatDate(r.Date.Start)
+		}
+	}
+	return ""
+}
+`
@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -14,17 +16,85 @@ type Config struct {
 	DefaultStatus      string
 	DefaultTaskStatus  string
 	DefaultPriority    string
+	Timezone           string
+	RecurrenceProperty string
+	SeriesProperty     string
+	UIDProperty        string
+	ParentProperty     string
+	BlockedByProperty  string
+	AssigneeProperty   string
+	Me                 string
+	UserCacheTTL       time.Duration
+	WorkingHours       string
+	TaskStatuses       StatusGroups
+	EventStatuses      StatusGroups
+	// WIPLimits maps a board ("tasks" or "posts") to the most items each
+	// status column should hold
+	WIPLimits map[string]map[string]int
+}
+
+// StatusGroups sorts the values of a Status property by meaning. Groups
+// left empty fall back to the documented defaults.
+type StatusGroups struct {
+	Open       []string
+	InProgress []string
+	Done       []string
+	Cancelled  []string
+}
+
+// loadLimits reads a map of status names to limits. Viper lowercases the
+// keys, so limits have to be matched to statuses regardless of case.
+func loadLimits(key string) map[string]int {
+	limits := make(map[string]int)
+	for name, value := range viper.GetStringMap(key) {
+		switch v := value.(type) {
+		case int:
+			limits[name] = v
+		case float64:
+			limits[name] = int(v)
+		case string:
+			if n, err := strconv.Atoi(v); err == nil {
+				limits[name] = n
+			}
+		}
+	}
+	return limits
+}
+
+func loadStatusGroups(key string) StatusGroups {
+	return StatusGroups{
+		Open:       viper.GetStringSlice(key + ".open"),
+		InProgress: viper.GetStringSlice(key + ".in_progress"),
+		Done:       viper.GetStringSlice(key + ".done"),
+		Cancelled:  viper.GetStringSlice(key + ".cancelled"),
+	}
 }
 
 func Load() (*Config, error) {
 	cfg := &Config{
-		APIToken:          viper.GetString("api_token"),
-		DatabaseID:        viper.GetString("database_id"),
-		TasksDatabaseID:   viper.GetString("tasks_database_id"),
-		EventsDatabaseID:  viper.GetString("events_database_id"),
-		DefaultStatus:     viper.GetString("default_status"),
-		DefaultTaskStatus: viper.GetString("default_task_status"),
-		DefaultPriority:   viper.GetString("default_priority"),
+		APIToken:           viper.GetString("api_token"),
+		DatabaseID:         viper.GetString("database_id"),
+		TasksDatabaseID:    viper.GetString("tasks_database_id"),
+		EventsDatabaseID:   viper.GetString("events_database_id"),
+		DefaultStatus:      viper.GetString("default_status"),
+		DefaultTaskStatus:  viper.GetString("default_task_status"),
+		DefaultPriority:    viper.GetString("default_priority"),
+		Timezone:           viper.GetString("timezone"),
+		RecurrenceProperty: viper.GetString("recurrence_property"),
+		SeriesProperty:     viper.GetString("series_property"),
+		UIDProperty:        viper.GetString("uid_property"),
+		ParentProperty:     viper.GetString("parent_property"),
+		BlockedByProperty:  viper.GetString("blocked_by_property"),
+		AssigneeProperty:   viper.GetString("assignee_property"),
+		Me:                 viper.GetString("me"),
+		UserCacheTTL:       viper.GetDuration("user_cache_ttl"),
+		WorkingHours:       viper.GetString("working_hours"),
+		TaskStatuses:       loadStatusGroups("status_groups.tasks"),
+		EventStatuses:      loadStatusGroups("status_groups.events"),
+		WIPLimits: map[string]map[string]int{
+			"tasks": loadLimits("wip_limits.tasks"),
+			"posts": loadLimits("wip_limits.posts"),
+		},
 	}
 
 	// Set defaults if not configured
@@ -37,11 +107,56 @@ func Load() (*Config, error) {
 	if cfg.DefaultPriority == "" {
 		cfg.DefaultPriority = "Medium"
 	}
+	if cfg.RecurrenceProperty == "" {
+		cfg.RecurrenceProperty = "Repeat"
+	}
+	if cfg.SeriesProperty == "" {
+		cfg.SeriesProperty = "Series"
+	}
+	if cfg.UIDProperty == "" {
+		cfg.UIDProperty = "UID"
+	}
+	if cfg.ParentProperty == "" {
+		cfg.ParentProperty = "Parent"
+	}
+	if cfg.BlockedByProperty == "" {
+		cfg.BlockedByProperty = "Blocked By"
+	}
+	if cfg.AssigneeProperty == "" {
+		cfg.AssigneeProperty = "Assignee"
+	}
+	if !viper.IsSet("user_cache_ttl") {
+		cfg.UserCacheTTL = time.Hour
+	}
+	if cfg.WorkingHours == "" {
+		cfg.WorkingHours = "09:00-17:00"
+	}
+	if len(cfg.TaskStatuses.Open) == 0 {
+		cfg.TaskStatuses.Open = []string{cfg.DefaultTaskStatus}
+	}
 
 	// Validate required fields
 	if cfg.APIToken == "" {
 		return nil, fmt.Errorf("API token is required. Set NOTION_API_TOKEN environment variable or run 'notion-cli config init'")
 	}
 
+	if cfg.Timezone != "" {
+		if _, err := time.LoadLocation(cfg.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: use an IANA name such as 'Europe/London'", cfg.Timezone)
+		}
+	}
+
 	return cfg, nil
 }
+
+// Location returns the configured timezone, falling back to the system zone
+func (c *Config) Location() *time.Location {
+	if c.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
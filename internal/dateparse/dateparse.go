@@ -0,0 +1,334 @@
+// Package dateparse turns the date expressions accepted on the command line
+// into concrete times.
+//
+// Supported forms:
+//
+//	2026-10-20                     calendar date
+//	2026-10-20 14:00               date and time (also with a T separator)
+//	2026-10-20T14:00:00+02:00      RFC 3339
+//	2026-10-20 14:00 +0200         date and time with a numeric offset
+//	2026-10-20 14:00 Europe/Oslo   date and time with an IANA zone
+//	2026-W43, 2026-W43-5           ISO week (Monday, or the given weekday)
+//	now, today, tomorrow, yesterday
+//	friday, next friday, last fri  weekdays
+//	eow, eom, eoy                  end of week (Sunday), month, year
+//	+3d, -2w, +1mo, +4h            offsets (min, h, d, w, m/mo, y)
+//	in 2 weeks, 3 days ago         spelled-out offsets
+//
+// Any date-only form may be followed by a time of day, optionally introduced
+// by "at": "tomorrow 9:30", "next friday at 3pm".
+//
+// Expressions without an explicit zone are resolved in the location of the
+// reference time passed to Parse.
+package dateparse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Result is a parsed date expression.
+type Result struct {
+	// Time is the resolved instant. For date-only results it is midnight in
+	// the reference location.
+	Time time.Time
+	// HasTime reports whether the expression carried a time of day.
+	HasTime bool
+}
+
+var (
+	isoWeekRe  = regexp.MustCompile(`^(\d{4})-?w(\d{1,2})(?:-?([1-7]))?$`)
+	offsetRe   = regexp.MustCompile(`^([+-])\s*(\d+)\s*([a-z]+)$`)
+	inRe       = regexp.MustCompile(`^in\s+(\d+)\s*([a-z]+)$`)
+	agoRe      = regexp.MustCompile(`^(\d+)\s*([a-z]+)\s+ago$`)
+	clockRe    = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+	durationRe = regexp.MustCompile(`^(\d+)([dw])(.*)$`)
+	numericTZ  = regexp.MustCompile(`^(?:z|[+-]\d{2}:?\d{2})$`)
+	zonelessTS = []string{
+		"2006-01-02",
+		"2006-01-02 15:04",
+		"2006-01-02T15:04",
+		"2006-01-02 15:04:05",
+		"2006-01-02T15:04:05",
+	}
+	zonedTS = []string{
+		time.RFC3339,
+		"2006-01-02T15:04Z07:00",
+		"2006-01-02 15:04:05Z07:00",
+		"2006-01-02 15:04Z07:00",
+		"2006-01-02 15:04:05 Z07:00",
+		"2006-01-02 15:04 Z07:00",
+		"2006-01-02 15:04:05 -0700",
+		"2006-01-02 15:04 -0700",
+	}
+	weekdays = map[string]time.Weekday{
+		"sunday": time.Sunday, "sun": time.Sunday,
+		"monday": time.Monday, "mon": time.Monday,
+		"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+		"wednesday": time.Wednesday, "wed": time.Wednesday,
+		"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+		"friday": time.Friday, "fri": time.Friday,
+		"saturday": time.Saturday, "sat": time.Saturday,
+	}
+)
+
+// Parse interprets input relative to now. It returns an error for empty or
+// unrecognized input instead of a zero time.
+func Parse(input string, now time.Time) (Result, error) {
+	s := strings.ToLower(strings.Join(strings.Fields(input), " "))
+	if s == "" {
+		return Result{}, fmt.Errorf("empty date")
+	}
+
+	if r, ok := parseAbsolute(strings.TrimSpace(input), now.Location()); ok {
+		return r, nil
+	}
+
+	if r, ok, err := parseRelative(s, now); ok || err != nil {
+		if err != nil {
+			return Result{}, fmt.Errorf("invalid date %q: %w", input, err)
+		}
+		return r, nil
+	}
+
+	// "<date expression> [at] <clock>"
+	if i := strings.LastIndex(s, " "); i > 0 {
+		head, clock := strings.TrimSuffix(s[:i], " at"), s[i+1:]
+		if h, m, ok := ParseClock(clock); ok {
+			base, err := Parse(head, now)
+			if err == nil && !base.HasTime {
+				t := base.Time
+				return Result{
+					Time:    time.Date(t.Year(), t.Month(), t.Day(), h, m, 0, 0, t.Location()),
+					HasTime: true,
+				}, nil
+			}
+		}
+	}
+
+	return Result{}, fmt.Errorf("unrecognized date %q", input)
+}
+
+// parseAbsolute handles calendar dates and timestamps, with or without zone.
+func parseAbsolute(s string, loc *time.Location) (Result, bool) {
+	for _, layout := range zonedTS {
+		if t, err := time.Parse(layout, strings.ToUpper(s)); err == nil {
+			return Result{Time: t, HasTime: true}, true
+		}
+	}
+	for _, layout := range zonelessTS {
+		if t, err := time.ParseInLocation(layout, strings.ToUpper(s), loc); err == nil {
+			return Result{Time: t, HasTime: layout != "2006-01-02"}, true
+		}
+	}
+
+	// Trailing IANA zone name, e.g. "2026-10-20 09:00 Europe/Berlin".
+	if i := strings.LastIndex(s, " "); i > 0 {
+		head, zone := s[:i], s[i+1:]
+		if numericTZ.MatchString(strings.ToLower(zone)) {
+			return Result{}, false
+		}
+		if zl, err := time.LoadLocation(zone); err == nil && zone != "" && zone != "Local" {
+			if r, ok := parseAbsolute(head, zl); ok {
+				return r, true
+			}
+		}
+	}
+	return Result{}, false
+}
+
+// parseRelative handles keywords, weekdays, ISO weeks and offsets. The
+// boolean result reports whether s was recognized.
+func parseRelative(s string, now time.Time) (Result, bool, error) {
+	today := midnight(now)
+
+	switch s {
+	case "now":
+		return Result{Time: now, HasTime: true}, true, nil
+	case "today":
+		return Result{Time: today}, true, nil
+	case "tomorrow":
+		return Result{Time: today.AddDate(0, 0, 1)}, true, nil
+	case "yesterday":
+		return Result{Time: today.AddDate(0, 0, -1)}, true, nil
+	case "eow":
+		return Result{Time: today.AddDate(0, 0, (7-int(today.Weekday()))%7)}, true, nil
+	case "eom":
+		first := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+		return Result{Time: first.AddDate(0, 1, -1)}, true, nil
+	case "eoy":
+		return Result{Time: time.Date(today.Year(), 12, 31, 0, 0, 0, 0, today.Location())}, true, nil
+	}
+
+	if m := isoWeekRe.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		week, _ := strconv.Atoi(m[2])
+		day := 1
+		if m[3] != "" {
+			day, _ = strconv.Atoi(m[3])
+		}
+		t, err := isoWeekDate(year, week, day, now.Location())
+		return Result{Time: t}, true, err
+	}
+
+	if r, ok := parseWeekday(s, today); ok {
+		return r, true, nil
+	}
+
+	var sign, amount, unit string
+	if m := offsetRe.FindStringSubmatch(s); m != nil {
+		sign, amount, unit = m[1], m[2], m[3]
+	} else if m := inRe.FindStringSubmatch(s); m != nil {
+		sign, amount, unit = "+", m[1], m[2]
+	} else if m := agoRe.FindStringSubmatch(s); m != nil {
+		sign, amount, unit = "-", m[1], m[2]
+	} else {
+		return Result{}, false, nil
+	}
+
+	n, err := strconv.Atoi(amount)
+	if err != nil {
+		return Result{}, true, err
+	}
+	if sign == "-" {
+		n = -n
+	}
+	r, err := applyOffset(now, today, n, unit)
+	return r, true, err
+}
+
+// parseWeekday handles "friday", "this friday", "next friday" and
+// "last friday". A bare or "this" weekday includes today; "next" is the first
+// matching day strictly after today and "last" the most recent one before it.
+func parseWeekday(s string, today time.Time) (Result, bool) {
+	modifier, name := "", s
+	if i := strings.Index(s, " "); i > 0 {
+		modifier, name = s[:i], s[i+1:]
+	}
+	wd, ok := weekdays[name]
+	if !ok {
+		return Result{}, false
+	}
+
+	diff := (int(wd) - int(today.Weekday()) + 7) % 7
+	switch modifier {
+	case "", "this":
+	case "next":
+		if diff == 0 {
+			diff = 7
+		}
+	case "last":
+		diff -= 7
+	default:
+		return Result{}, false
+	}
+	return Result{Time: today.AddDate(0, 0, diff)}, true
+}
+
+// applyOffset shifts now by n units. Day-level units yield a date-only
+// result; hours and minutes keep the time of day.
+func applyOffset(now, today time.Time, n int, unit string) (Result, error) {
+	switch unit {
+	case "min", "mins", "minute", "minutes":
+		return Result{Time: now.Add(time.Duration(n) * time.Minute), HasTime: true}, nil
+	case "h", "hr", "hrs", "hour", "hours":
+		return Result{Time: now.Add(time.Duration(n) * time.Hour), HasTime: true}, nil
+	case "d", "day", "days":
+		return Result{Time: today.AddDate(0, 0, n)}, nil
+	case "w", "wk", "wks", "week", "weeks":
+		return Result{Time: today.AddDate(0, 0, 7*n)}, nil
+	case "m", "mo", "mon", "month", "months":
+		return Result{Time: today.AddDate(0, n, 0)}, nil
+	case "y", "yr", "yrs", "year", "years":
+		return Result{Time: today.AddDate(n, 0, 0)}, nil
+	}
+	return Result{}, fmt.Errorf("unknown unit %q", unit)
+}
+
+// isoWeekDate returns the given ISO 8601 weekday (1 = Monday) of an ISO week.
+func isoWeekDate(year, week, day int, loc *time.Location) (time.Time, error) {
+	if week < 1 {
+		return time.Time{}, fmt.Errorf("week %d out of range", week)
+	}
+
+	// January 4th is always in ISO week 1.
+	jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, loc)
+	offset := (int(jan4.Weekday()) + 6) % 7
+	monday := jan4.AddDate(0, 0, -offset+(week-1)*7)
+
+	if y, w := monday.ISOWeek(); y != year || w != week {
+		return time.Time{}, fmt.Errorf("%d has no ISO week %d", year, week)
+	}
+	return monday.AddDate(0, 0, day-1), nil
+}
+
+// ParseClock parses a time of day such as "14:00", "9:30am" or "3pm" into
+// hours and minutes.
+func ParseClock(s string) (int, int, bool) {
+	m := clockRe.FindStringSubmatch(strings.ToLower(strings.TrimSpace(s)))
+	if m == nil {
+		return 0, 0, false
+	}
+	hour, _ := strconv.Atoi(m[1])
+	minute := 0
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	// A bare number is only a time when it has a colon or am/pm marker.
+	if m[2] == "" && m[3] == "" {
+		return 0, 0, false
+	}
+	// A 12-hour clock runs from 1 to 12
+	if m[3] != "" && (hour < 1 || hour > 12) {
+		return 0, 0, false
+	}
+	switch m[3] {
+	case "am":
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour < 12 {
+			hour += 12
+		}
+	}
+	if hour > 23 || minute > 59 {
+		return 0, 0, false
+	}
+	return hour, minute, true
+}
+
+// ParseDuration parses a duration such as "30m", "1h30m", "2d" or "1w". It
+// accepts everything time.ParseDuration does plus day and week units.
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if m := durationRe.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		unit := 24 * time.Hour
+		if m[2] == "w" {
+			unit *= 7
+		}
+		d := time.Duration(n) * unit
+		if m[3] != "" {
+			rest, err := time.ParseDuration(m[3])
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			d += rest
+		}
+		return d, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+// midnight truncates t to the start of its day in its own location.
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
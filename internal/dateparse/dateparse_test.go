@@ -0,0 +1,135 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	oslo := time.FixedZone("CEST", 2*60*60)
+	// A Wednesday
+	now := time.Date(2026, 10, 21, 10, 30, 0, 0, oslo)
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, oslo) }
+
+	for _, tc := range []struct {
+		in      string
+		want    time.Time
+		hasTime bool
+	}{
+		{"2026-11-02", day(2026, 11, 2), false},
+		{"2026-11-02 14:00", time.Date(2026, 11, 2, 14, 0, 0, 0, oslo), true},
+		{"2026-11-02T14:00", time.Date(2026, 11, 2, 14, 0, 0, 0, oslo), true},
+		{"2026-11-02T14:00:00Z", time.Date(2026, 11, 2, 14, 0, 0, 0, time.UTC), true},
+		{"2026-11-02 14:00 +0100", time.Date(2026, 11, 2, 13, 0, 0, 0, time.UTC), true},
+		{"2026-11-02 14:00 UTC", time.Date(2026, 11, 2, 14, 0, 0, 0, time.UTC), true},
+		{"2026-W43", day(2026, 10, 19), false},
+		{"2026-w43-5", day(2026, 10, 23), false},
+		{"now", now, true},
+		{"today", day(2026, 10, 21), false},
+		{"Tomorrow", day(2026, 10, 22), false},
+		{"yesterday", day(2026, 10, 20), false},
+		{"eow", day(2026, 10, 25), false},
+		{"eom", day(2026, 10, 31), false},
+		{"eoy", day(2026, 12, 31), false},
+		{"wednesday", day(2026, 10, 21), false},
+		{"next wed", day(2026, 10, 28), false},
+		{"friday", day(2026, 10, 23), false},
+		{"last friday", day(2026, 10, 16), false},
+		{"+3d", day(2026, 10, 24), false},
+		{"-2w", day(2026, 10, 7), false},
+		{"+1mo", day(2026, 11, 21), false},
+		{"+4h", now.Add(4 * time.Hour), true},
+		{"in 2 weeks", day(2026, 11, 4), false},
+		{"3 days ago", day(2026, 10, 18), false},
+		{"tomorrow 9:30", time.Date(2026, 10, 22, 9, 30, 0, 0, oslo), true},
+		{"next friday at 3pm", time.Date(2026, 10, 23, 15, 0, 0, 0, oslo), true},
+		{"2026-11-02 12am", day(2026, 11, 2), true},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := Parse(tc.in, now)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tc.in, err)
+			}
+			if !got.Time.Equal(tc.want) || got.HasTime != tc.hasTime {
+				t.Errorf("Parse(%q) = %v (time %v), want %v (time %v)", tc.in, got.Time, got.HasTime, tc.want, tc.hasTime)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	now := time.Date(2026, 10, 21, 10, 30, 0, 0, time.UTC)
+	for _, in := range []string{
+		"",
+		"  ",
+		"someday",
+		"2026-13-01",
+		"2026-W54",
+		"+3 fortnights",
+		"next someday",
+		"tomorrow 13pm",
+		"now at 9:30",
+	} {
+		if got, err := Parse(in, now); err == nil {
+			t.Errorf("Parse(%q) = %v, want an error", in, got.Time)
+		}
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	for _, tc := range []struct {
+		in           string
+		hour, minute int
+		ok           bool
+	}{
+		{"14:00", 14, 0, true},
+		{"9:30", 9, 30, true},
+		{"00:05", 0, 5, true},
+		{"3pm", 15, 0, true},
+		{"9:30PM", 21, 30, true},
+		{"12am", 0, 0, true},
+		{"12:15am", 0, 15, true},
+		{"12pm", 12, 0, true},
+		{"1 am", 1, 0, true},
+		{"9", 0, 0, false},
+		{"13pm", 0, 0, false},
+		{"13:00am", 0, 0, false},
+		{"0am", 0, 0, false},
+		{"00:30pm", 0, 0, false},
+		{"24:00", 0, 0, false},
+		{"9:60", 0, 0, false},
+		{"noon", 0, 0, false},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			hour, minute, ok := ParseClock(tc.in)
+			if hour != tc.hour || minute != tc.minute || ok != tc.ok {
+				t.Errorf("ParseClock(%q) = %d, %d, %v, want %d, %d, %v", tc.in, hour, minute, ok, tc.hour, tc.minute, tc.ok)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"30m", 30 * time.Minute, true},
+		{"1h30m", 90 * time.Minute, true},
+		{"2d", 48 * time.Hour, true},
+		{"1w", 7 * 24 * time.Hour, true},
+		{"1d12h", 36 * time.Hour, true},
+		{" 2H ", 2 * time.Hour, true},
+		{"", 0, false},
+		{"soon", 0, false},
+		{"2dx", 0, false},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := ParseDuration(tc.in)
+			if (err == nil) != tc.ok || got != tc.want {
+				t.Errorf("ParseDuration(%q) = %v, %v, want %v (ok %v)", tc.in, got, err, tc.want, tc.ok)
+			}
+		})
+	}
+}
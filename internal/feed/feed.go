@@ -0,0 +1,218 @@
+// Package feed serves iCalendar feeds over HTTP for calendar applications to
+// subscribe to.
+package feed
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Filter narrows a feed to events of one type and/or status. It is taken
+// from the type and status query parameters of the feed URL.
+type Filter struct {
+	Type   string
+	Status string
+}
+
+// maxEntries caps the number of filters whose documents are cached, since
+// filters come from query parameters anyone can make up
+const maxEntries = 64
+
+// renderTimeout bounds a render, which runs on its own rather than on the
+// context of the request that started it
+const renderTimeout = time.Minute
+
+// RenderFunc produces the iCalendar document for a filter.
+type RenderFunc func(ctx context.Context, filter Filter) ([]byte, error)
+
+// Handler serves feeds rendered by a RenderFunc. Each filter's document is
+// cached for the TTL and served with an ETag, so clients that poll often
+// get 304 Not Modified without another round trip to Notion.
+type Handler struct {
+	render RenderFunc
+	ttl    time.Duration
+	logger *log.Logger
+
+	mu      sync.Mutex
+	cache   map[Filter]*entry
+	pending map[Filter]*call
+}
+
+type entry struct {
+	body     []byte
+	etag     string
+	fetched  time.Time
+	modified time.Time
+}
+
+// call is a document being rendered, which requests for the same filter
+// wait for rather than starting their own
+type call struct {
+	done chan struct{}
+	e    *entry
+	err  error
+}
+
+// NewHandler returns a handler that renders feeds with render and caches
+// them for ttl. A zero ttl disables caching. Errors are logged to logger
+// when it is not nil.
+func NewHandler(render RenderFunc, ttl time.Duration, logger *log.Logger) *Handler {
+	return &Handler{
+		render:  render,
+		ttl:     ttl,
+		logger:  logger,
+		cache:   make(map[Filter]*entry),
+		pending: make(map[Filter]*call),
+	}
+}
+
+// ServeHTTP serves the feed on "/" and any path ending in ".ics".
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" && !strings.HasSuffix(r.URL.Path, ".ics") {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	filter := Filter{Type: q.Get("type"), Status: q.Get("status")}
+
+	e, err := h.get(r.Context(), filter)
+	if err != nil {
+		h.logf("feed %+v: %v", filter, err)
+		http.Error(w, "failed to load events from Notion", http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("ETag", e.etag)
+	w.Header().Set("Last-Modified", e.modified.UTC().Format(http.TimeFormat))
+	w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", int(h.ttl.Seconds())))
+
+	if matchesETag(r.Header.Get("If-None-Match"), e.etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Length", fmt.Sprint(len(e.body)))
+	if r.Method == http.MethodHead {
+		return
+	}
+	w.Write(e.body)
+}
+
+// get returns the cached document for a filter, rendering it again once it
+// is older than the TTL. A stale document is served when rendering fails.
+// Rendering happens without the lock held, so a slow fetch doesn't hold up
+// other filters, and requests for a filter being rendered wait for it. The
+// render doesn't use ctx, so a client hanging up doesn't fail it for the
+// others waiting.
+func (h *Handler) get(ctx context.Context, filter Filter) (*entry, error) {
+	h.mu.Lock()
+	cached := h.cache[filter]
+	if cached != nil && time.Since(cached.fetched) < h.ttl {
+		h.mu.Unlock()
+		return cached, nil
+	}
+	c, ok := h.pending[filter]
+	if !ok {
+		c = &call{done: make(chan struct{})}
+		h.pending[filter] = c
+		go h.renderCall(c, filter, cached)
+	}
+	h.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.e, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// renderCall renders a filter's document and hands it to the requests
+// waiting on c
+func (h *Handler) renderCall(c *call, filter Filter, cached *entry) {
+	ctx, cancel := context.WithTimeout(context.Background(), renderTimeout)
+	defer cancel()
+	body, err := h.render(ctx, filter)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.pending, filter)
+	c.e, c.err = h.store(filter, cached, body, err)
+	close(c.done)
+}
+
+// store caches a freshly rendered document, or falls back on the cached one
+// when rendering failed. It is called with h.mu held.
+func (h *Handler) store(filter Filter, cached *entry, body []byte, err error) (*entry, error) {
+	if err != nil {
+		if cached != nil {
+			h.logf("feed %+v: serving cached copy: %v", filter, err)
+			return cached, nil
+		}
+		return nil, err
+	}
+
+	sum := sha256.Sum256(body)
+	now := time.Now()
+	e := &entry{
+		body:     body,
+		etag:     `"` + hex.EncodeToString(sum[:16]) + `"`,
+		fetched:  now,
+		modified: now,
+	}
+	// Last-Modified only moves when the content changes
+	if cached != nil && cached.etag == e.etag {
+		e.modified = cached.modified
+	}
+	if _, ok := h.cache[filter]; !ok && len(h.cache) >= maxEntries {
+		h.evictOldest()
+	}
+	h.cache[filter] = e
+	return e, nil
+}
+
+// evictOldest drops the document fetched longest ago
+func (h *Handler) evictOldest() {
+	var oldest Filter
+	var at time.Time
+	for f, e := range h.cache {
+		if at.IsZero() || e.fetched.Before(at) {
+			oldest, at = f, e.fetched
+		}
+	}
+	delete(h.cache, oldest)
+}
+
+func (h *Handler) logf(format string, args ...any) {
+	if h.logger != nil {
+		h.logger.Printf(format, args...)
+	}
+}
+
+// matchesETag reports whether an If-None-Match header matches etag.
+func matchesETag(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
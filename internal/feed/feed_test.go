@@ -0,0 +1,191 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func get(t *testing.T, h http.Handler, target, etag string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandlerETag(t *testing.T) {
+	renders := 0
+	h := NewHandler(func(ctx context.Context, f Filter) ([]byte, error) {
+		renders++
+		return []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"), nil
+	}, 0, nil)
+
+	first := get(t, h, "/events.ics", "")
+	if first.Code != http.StatusOK || first.Header().Get("ETag") == "" {
+		t.Fatalf("first response = %d, ETag %q", first.Code, first.Header().Get("ETag"))
+	}
+	etag := first.Header().Get("ETag")
+	modified := first.Header().Get("Last-Modified")
+
+	time.Sleep(time.Second)
+	again := get(t, h, "/events.ics", etag)
+	if again.Code != http.StatusNotModified {
+		t.Errorf("unchanged feed after a re-render = %d, want 304", again.Code)
+	}
+	if got := again.Header().Get("Last-Modified"); got != modified {
+		t.Errorf("Last-Modified moved from %s to %s without a change", modified, got)
+	}
+	if renders != 2 {
+		t.Errorf("rendered %d times, want 2 with caching off", renders)
+	}
+}
+
+func TestHandlerCache(t *testing.T) {
+	renders := map[Filter]int{}
+	h := NewHandler(func(ctx context.Context, f Filter) ([]byte, error) {
+		renders[f]++
+		return []byte(f.Type), nil
+	}, time.Hour, nil)
+
+	get(t, h, "/?type=Work", "")
+	get(t, h, "/?type=Work", "")
+	if n := renders[Filter{Type: "Work"}]; n != 1 {
+		t.Errorf("rendered Work %d times, want 1 within the TTL", n)
+	}
+
+	for i := 0; i < 2*maxEntries; i++ {
+		get(t, h, fmt.Sprintf("/?type=t%d", i), "")
+	}
+	if len(h.cache) > maxEntries {
+		t.Errorf("cached %d filters, want at most %d", len(h.cache), maxEntries)
+	}
+}
+
+func TestHandlerRendersWithoutLock(t *testing.T) {
+	release := make(chan struct{})
+	h := NewHandler(func(ctx context.Context, f Filter) ([]byte, error) {
+		if f.Type == "slow" {
+			<-release
+		}
+		return []byte(f.Type), nil
+	}, time.Hour, nil)
+
+	slow := make(chan int)
+	for i := 0; i < 2; i++ {
+		go func() { slow <- get(t, h, "/?type=slow", "").Code }()
+	}
+
+	done := make(chan int)
+	go func() { done <- get(t, h, "/?type=fast", "").Code }()
+	select {
+	case code := <-done:
+		if code != http.StatusOK {
+			t.Errorf("fast feed = %d", code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("a slow render held up another filter")
+	}
+
+	close(release)
+	for i := 0; i < 2; i++ {
+		if code := <-slow; code != http.StatusOK {
+			t.Errorf("slow feed = %d", code)
+		}
+	}
+}
+
+func TestHandlerRenderOutlivesRequest(t *testing.T) {
+	release := make(chan struct{})
+	h := NewHandler(func(ctx context.Context, f Filter) ([]byte, error) {
+		<-release
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return []byte("feed"), nil
+	}, time.Hour, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := h.get(ctx, Filter{})
+		first <- err
+	}()
+	for {
+		h.mu.Lock()
+		n := len(h.pending)
+		h.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	second := make(chan int)
+	go func() { second <- get(t, h, "/", "").Code }()
+
+	cancel()
+	if err := <-first; err != context.Canceled {
+		t.Errorf("cancelled request = %v, want %v", err, context.Canceled)
+	}
+	close(release)
+	if code := <-second; code != http.StatusOK {
+		t.Errorf("request waiting on a cancelled one's render = %d, want 200", code)
+	}
+}
+
+func TestHandlerErrors(t *testing.T) {
+	fail := false
+	h := NewHandler(func(ctx context.Context, f Filter) ([]byte, error) {
+		if fail {
+			return nil, fmt.Errorf("notion is down")
+		}
+		return []byte("feed"), nil
+	}, 0, nil)
+
+	for _, tc := range []struct {
+		name   string
+		method string
+		target string
+		fail   bool
+		want   int
+	}{
+		{"not a feed", http.MethodGet, "/favicon.png", false, http.StatusNotFound},
+		{"wrong method", http.MethodPost, "/", false, http.StatusMethodNotAllowed},
+		{"renders", http.MethodGet, "/", false, http.StatusOK},
+		{"stale copy on failure", http.MethodGet, "/", true, http.StatusOK},
+		{"no copy to fall back on", http.MethodGet, "/?type=Work", true, http.StatusBadGateway},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fail = tc.fail
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.target, nil))
+			if rec.Code != tc.want {
+				t.Errorf("status = %d, want %d", rec.Code, tc.want)
+			}
+		})
+	}
+}
+
+func TestMatchesETag(t *testing.T) {
+	for _, tc := range []struct {
+		header string
+		want   bool
+	}{
+		{"", false},
+		{`"abc"`, true},
+		{`W/"abc"`, true},
+		{`"xyz", "abc"`, true},
+		{`"xyz"`, false},
+		{"*", true},
+	} {
+		if got := matchesETag(tc.header, `"abc"`); got != tc.want {
+			t.Errorf("matchesETag(%q) = %v, want %v", tc.header, got, tc.want)
+		}
+	}
+}
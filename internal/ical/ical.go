@@ -0,0 +1,662 @@
+// Package ical reads and writes the parts of iCalendar (RFC 5545) needed to
+// exchange events with other calendar applications: VCALENDAR, VEVENT and a
+// generated VTIMEZONE for the zone event times are written in.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	prodID       = "-//notion-cli//notion-cli//EN"
+	dateLayout   = "20060102"
+	localLayout  = "20060102T150405"
+	utcLayout    = "20060102T150405Z"
+	maxLineBytes = 75
+)
+
+// Calendar is a VCALENDAR object.
+type Calendar struct {
+	// Name is written as X-WR-CALNAME, which most clients show as the
+	// calendar title.
+	Name string
+	// Location is the zone timed events are written in. When it has an IANA
+	// name, times carry a TZID and a matching VTIMEZONE is included;
+	// otherwise they are written in UTC. On read it resolves floating times.
+	Location *time.Location
+	Events   []Event
+}
+
+// Event is a VEVENT component.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	// Status is TENTATIVE, CONFIRMED or CANCELLED.
+	Status     string
+	Categories []string
+	Start      time.Time
+	// End is exclusive, so an all-day event on one day ends at midnight of
+	// the next. It is zero when the event has no end.
+	End       time.Time
+	AllDay    bool
+	Attendees []Attendee
+	// RRule is the recurrence rule without the "RRULE:" prefix.
+	RRule   string
+	ExDates []time.Time
+	// RecurrenceID marks the event as an override of one occurrence of the
+	// recurring event with the same UID.
+	RecurrenceID time.Time
+	URL          string
+	Created      time.Time
+	LastModified time.Time
+}
+
+// Attendee is an ATTENDEE property. Email is empty for attendees known only
+// by name.
+type Attendee struct {
+	Name  string
+	Email string
+}
+
+// Write encodes cal as an iCalendar stream with CRLF line endings.
+func Write(w io.Writer, cal *Calendar) error {
+	e := &encoder{w: bufio.NewWriter(w)}
+	loc := cal.Location
+	if loc == nil || !namedZone(loc) {
+		loc = time.UTC
+	}
+
+	e.line("BEGIN", nil, "VCALENDAR")
+	e.line("VERSION", nil, "2.0")
+	e.line("PRODID", nil, prodID)
+	e.line("CALSCALE", nil, "GREGORIAN")
+	e.line("METHOD", nil, "PUBLISH")
+	if cal.Name != "" {
+		e.text("X-WR-CALNAME", cal.Name)
+	}
+	if loc != time.UTC {
+		e.line("X-WR-TIMEZONE", nil, loc.String())
+		writeTimezone(e, loc, cal.Events)
+	}
+
+	now := time.Now()
+	for _, ev := range cal.Events {
+		e.line("BEGIN", nil, "VEVENT")
+		e.line("UID", nil, ev.UID)
+		e.line("DTSTAMP", nil, stamp(ev, now).UTC().Format(utcLayout))
+		if !ev.RecurrenceID.IsZero() {
+			e.time("RECURRENCE-ID", ev.RecurrenceID, ev.AllDay, loc)
+		}
+		e.time("DTSTART", ev.Start, ev.AllDay, loc)
+		if !ev.End.IsZero() {
+			e.time("DTEND", ev.End, ev.AllDay, loc)
+		}
+		e.text("SUMMARY", ev.Summary)
+		if ev.Description != "" {
+			e.text("DESCRIPTION", ev.Description)
+		}
+		if ev.Location != "" {
+			e.text("LOCATION", ev.Location)
+		}
+		if ev.Status != "" {
+			e.line("STATUS", nil, ev.Status)
+		}
+		if len(ev.Categories) > 0 {
+			escaped := make([]string, len(ev.Categories))
+			for i, c := range ev.Categories {
+				escaped[i] = escapeText(c)
+			}
+			e.line("CATEGORIES", nil, strings.Join(escaped, ","))
+		}
+		for _, a := range ev.Attendees {
+			var params []string
+			if a.Name != "" {
+				params = append(params, "CN="+quoteParam(a.Name))
+			}
+			value := "invalid:nomail"
+			if a.Email != "" {
+				value = "mailto:" + a.Email
+			}
+			e.line("ATTENDEE", params, value)
+		}
+		if ev.RRule != "" {
+			e.line("RRULE", nil, ev.RRule)
+		}
+		for _, d := range ev.ExDates {
+			e.time("EXDATE", d, ev.AllDay, loc)
+		}
+		if ev.URL != "" {
+			e.line("URL", nil, ev.URL)
+		}
+		if !ev.Created.IsZero() {
+			e.line("CREATED", nil, ev.Created.UTC().Format(utcLayout))
+		}
+		if !ev.LastModified.IsZero() {
+			e.line("LAST-MODIFIED", nil, ev.LastModified.UTC().Format(utcLayout))
+		}
+		e.line("END", nil, "VEVENT")
+	}
+
+	e.line("END", nil, "VCALENDAR")
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+// line writes a content line, folding it at 75 octets without splitting a
+// UTF-8 sequence.
+func (e *encoder) line(name string, params []string, value string) {
+	if e.err != nil {
+		return
+	}
+	s := name
+	for _, p := range params {
+		s += ";" + p
+	}
+	s += ":" + value
+
+	var b strings.Builder
+	n := 0
+	for _, r := range s {
+		size := len(string(r))
+		if n+size > maxLineBytes {
+			b.WriteString("\r\n ")
+			n = 1
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	b.WriteString("\r\n")
+	_, e.err = e.w.WriteString(b.String())
+}
+
+func (e *encoder) text(name, value string) {
+	e.line(name, nil, escapeText(value))
+}
+
+func (e *encoder) time(name string, t time.Time, allDay bool, loc *time.Location) {
+	switch {
+	case allDay:
+		e.line(name, []string{"VALUE=DATE"}, t.Format(dateLayout))
+	case loc == time.UTC:
+		e.line(name, nil, t.UTC().Format(utcLayout))
+	default:
+		e.line(name, []string{"TZID=" + loc.String()}, t.In(loc).Format(localLayout))
+	}
+}
+
+// stamp returns the DTSTAMP of an event: when it was last changed, so the
+// same events always encode the same way, or now when that isn't known
+func stamp(ev Event, now time.Time) time.Time {
+	switch {
+	case !ev.LastModified.IsZero():
+		return ev.LastModified
+	case !ev.Created.IsZero():
+		return ev.Created
+	}
+	return now
+}
+
+// recurringYears is how far past its start a recurring event is taken to
+// run when deciding which years a VTIMEZONE covers
+const recurringYears = 5
+
+// writeTimezone writes a VTIMEZONE listing the zone's offset changes over the
+// years the events cover, from the year before the first so the offset in
+// force at its start is defined. Recurring events count as running for a few
+// more years.
+func writeTimezone(e *encoder, loc *time.Location, events []Event) {
+	first, last := 0, 0
+	see := func(y int) {
+		if first == 0 || y < first {
+			first = y
+		}
+		if y > last {
+			last = y
+		}
+	}
+	for _, ev := range events {
+		for _, t := range []time.Time{ev.Start, ev.End, ev.RecurrenceID} {
+			if !t.IsZero() {
+				see(t.In(loc).Year())
+			}
+		}
+		if ev.RRule != "" && !ev.Start.IsZero() {
+			see(ev.Start.In(loc).Year() + recurringYears)
+		}
+	}
+	if first == 0 {
+		first, last = time.Now().In(loc).Year(), time.Now().In(loc).Year()
+	}
+
+	e.line("BEGIN", nil, "VTIMEZONE")
+	e.line("TZID", nil, loc.String())
+
+	from := time.Date(first-1, 1, 1, 0, 0, 0, 0, loc)
+	until := time.Date(last+1, 1, 1, 0, 0, 0, 0, loc)
+	name, offset := from.Zone()
+	transitions := 0
+	for day := from; day.Before(until); {
+		next := day.AddDate(0, 0, 1)
+		if _, o := next.Zone(); o == offset {
+			day = next
+			continue
+		}
+		t := transition(day, next)
+		n, o := t.Zone()
+		kind := "STANDARD"
+		if t.IsDST() {
+			kind = "DAYLIGHT"
+		}
+		e.line("BEGIN", nil, kind)
+		// DTSTART is the local time in the offset being left
+		e.line("DTSTART", nil, t.In(time.FixedZone("", offset)).Format(localLayout))
+		e.line("TZOFFSETFROM", nil, formatOffset(offset))
+		e.line("TZOFFSETTO", nil, formatOffset(o))
+		e.line("TZNAME", nil, n)
+		e.line("END", nil, kind)
+		name, offset = n, o
+		transitions++
+		day = t
+	}
+	if transitions == 0 {
+		e.line("BEGIN", nil, "STANDARD")
+		e.line("DTSTART", nil, "19700101T000000")
+		e.line("TZOFFSETFROM", nil, formatOffset(offset))
+		e.line("TZOFFSETTO", nil, formatOffset(offset))
+		e.line("TZNAME", nil, name)
+		e.line("END", nil, "STANDARD")
+	}
+	e.line("END", nil, "VTIMEZONE")
+}
+
+// transition bisects between two whole-second instants with different UTC
+// offsets and returns the first second of the later offset
+func transition(lo, hi time.Time) time.Time {
+	_, before := lo.Zone()
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+		if _, o := mid.Zone(); o == before {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi
+}
+
+func formatOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+// namedZone reports whether loc is an IANA zone other calendars can resolve.
+func namedZone(loc *time.Location) bool {
+	name := loc.String()
+	return name != "Local" && name != "UTC" && name != ""
+}
+
+func escapeText(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+func unescapeText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'n', 'N':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(s[i])
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func quoteParam(s string) string {
+	s = strings.ReplaceAll(s, `"`, "'")
+	if strings.ContainsAny(s, ";:,") {
+		return `"` + s + `"`
+	}
+	return s
+}
+
+// property is one unfolded content line.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Read parses an iCalendar stream and returns its events. Times without a
+// zone, or with a TZID that is not an IANA name, are read in loc.
+func Read(r io.Reader, loc *time.Location) (*Calendar, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cal := &Calendar{Location: loc}
+	var stack []string
+	var ev *Event
+	var duration time.Duration
+	for i, raw := range lines {
+		prop, err := parseLine(raw)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			stack = append(stack, strings.ToUpper(prop.value))
+			if len(stack) == 2 && stack[1] == "VEVENT" {
+				ev = &Event{}
+				duration = 0
+			}
+			continue
+		case "END":
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", i+1, prop.value)
+			}
+			if len(stack) == 2 && stack[1] == "VEVENT" {
+				if ev.UID == "" {
+					return nil, fmt.Errorf("line %d: event without UID", i+1)
+				}
+				if ev.Start.IsZero() {
+					return nil, fmt.Errorf("line %d: event %s without DTSTART", i+1, ev.UID)
+				}
+				if ev.End.IsZero() && duration > 0 {
+					ev.End = ev.Start.Add(duration)
+					if ev.AllDay {
+						ev.End = ev.Start.AddDate(0, 0, int(duration/(24*time.Hour)))
+					}
+				}
+				if !ev.End.IsZero() && ev.End.Before(ev.Start) {
+					return nil, fmt.Errorf("line %d: event %s ends before it starts", i+1, ev.UID)
+				}
+				cal.Events = append(cal.Events, *ev)
+				ev = nil
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		if len(stack) == 1 && prop.name == "X-WR-CALNAME" {
+			cal.Name = unescapeText(prop.value)
+		}
+		// Properties of nested components such as VALARM are ignored
+		if ev == nil || len(stack) != 2 {
+			continue
+		}
+		if prop.name == "DURATION" {
+			if duration, err = parseDuration(prop.value); err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			continue
+		}
+		if err := ev.set(prop, loc); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+	}
+
+	if len(stack) != 0 {
+		return nil, fmt.Errorf("unterminated %s", stack[len(stack)-1])
+	}
+	return cal, nil
+}
+
+func (ev *Event) set(p property, loc *time.Location) error {
+	switch p.name {
+	case "UID":
+		ev.UID = p.value
+	case "SUMMARY":
+		ev.Summary = unescapeText(p.value)
+	case "DESCRIPTION":
+		ev.Description = unescapeText(p.value)
+	case "LOCATION":
+		ev.Location = unescapeText(p.value)
+	case "STATUS":
+		ev.Status = strings.ToUpper(p.value)
+	case "URL":
+		ev.URL = p.value
+	case "CATEGORIES":
+		for _, c := range splitList(p.value) {
+			ev.Categories = append(ev.Categories, unescapeText(c))
+		}
+	case "RRULE":
+		ev.RRule = p.value
+	case "DTSTART":
+		t, allDay, err := parseTime(p, loc)
+		if err != nil {
+			return err
+		}
+		ev.Start, ev.AllDay = t, allDay
+	case "DTEND":
+		t, _, err := parseTime(p, loc)
+		if err != nil {
+			return err
+		}
+		ev.End = t
+	case "RECURRENCE-ID":
+		t, _, err := parseTime(p, loc)
+		if err != nil {
+			return err
+		}
+		ev.RecurrenceID = t
+	case "EXDATE":
+		for _, v := range strings.Split(p.value, ",") {
+			t, _, err := parseTime(property{name: p.name, params: p.params, value: v}, loc)
+			if err != nil {
+				return err
+			}
+			ev.ExDates = append(ev.ExDates, t)
+		}
+	case "ATTENDEE":
+		a := Attendee{Name: unquoteParam(p.params["CN"])}
+		if strings.HasPrefix(strings.ToLower(p.value), "mailto:") {
+			a.Email = p.value[len("mailto:"):]
+		}
+		if a.Name != "" || a.Email != "" {
+			ev.Attendees = append(ev.Attendees, a)
+		}
+	case "CREATED":
+		if t, _, err := parseTime(p, loc); err == nil {
+			ev.Created = t
+		}
+	case "LAST-MODIFIED":
+		if t, _, err := parseTime(p, loc); err == nil {
+			ev.LastModified = t
+		}
+	}
+	return nil
+}
+
+// unfold reads content lines, joining continuation lines that start with a
+// space or tab.
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+	return lines, nil
+}
+
+// parseLine splits a content line into name, parameters and value, honouring
+// quoted parameter values.
+func parseLine(line string) (property, error) {
+	p := property{params: map[string]string{}}
+	inQuotes := false
+	start := 0
+	var parts []string
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ';':
+			if !inQuotes {
+				parts = append(parts, line[start:i])
+				start = i + 1
+			}
+		case ':':
+			if !inQuotes {
+				parts = append(parts, line[start:i])
+				p.value = line[i+1:]
+				p.name = strings.ToUpper(parts[0])
+				for _, param := range parts[1:] {
+					if k, v, ok := strings.Cut(param, "="); ok {
+						p.params[strings.ToUpper(k)] = v
+					}
+				}
+				return p, nil
+			}
+		}
+	}
+	return p, fmt.Errorf("malformed content line %q", line)
+}
+
+func parseTime(p property, loc *time.Location) (time.Time, bool, error) {
+	v := strings.TrimSpace(p.value)
+	if strings.EqualFold(p.params["VALUE"], "DATE") || len(v) == len(dateLayout) {
+		t, err := time.ParseInLocation(dateLayout, v, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %s %q", p.name, v)
+		}
+		return t, true, nil
+	}
+	if strings.HasSuffix(v, "Z") {
+		t, err := time.Parse(utcLayout, v)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %s %q", p.name, v)
+		}
+		return t, false, nil
+	}
+
+	zone := loc
+	if tzid := unquoteParam(p.params["TZID"]); tzid != "" {
+		if l, err := time.LoadLocation(strings.TrimPrefix(tzid, "/")); err == nil {
+			zone = l
+		}
+	}
+	t, err := time.ParseInLocation(localLayout, v, zone)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s %q", p.name, v)
+	}
+	return t, false, nil
+}
+
+// parseDuration parses an RFC 5545 duration such as "PT1H30M", "P1D" or
+// "P2W".
+func parseDuration(s string) (time.Duration, error) {
+	orig := s
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign, s = -1, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, fmt.Errorf("invalid DURATION %q", orig)
+	}
+	s = s[1:]
+
+	var d time.Duration
+	inTime := false
+	n := 0
+	digits := false
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			n = n*10 + int(r-'0')
+			digits = true
+			continue
+		case r == 'T':
+			inTime = true
+			continue
+		}
+		if !digits {
+			return 0, fmt.Errorf("invalid DURATION %q", orig)
+		}
+		unit := map[rune]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}[r]
+		if inTime {
+			unit = map[rune]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}[r]
+		}
+		if unit == 0 {
+			return 0, fmt.Errorf("invalid DURATION %q", orig)
+		}
+		d += time.Duration(n) * unit
+		n, digits = 0, false
+	}
+	if digits {
+		return 0, fmt.Errorf("invalid DURATION %q", orig)
+	}
+	return sign * d, nil
+}
+
+// splitList splits a comma-separated value, ignoring escaped commas.
+func splitList(s string) []string {
+	var out []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == ',' {
+			out = append(out, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(out, s[start:])
+}
+
+func unquoteParam(s string) string {
+	return strings.Trim(s, `"`)
+}
+
+// SortOverridesLast orders events so that recurring events come before the
+// overrides of their occurrences.
+func SortOverridesLast(events []Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].RecurrenceID.IsZero() && !events[j].RecurrenceID.IsZero()
+	})
+}
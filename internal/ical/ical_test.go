@@ -0,0 +1,266 @@
+package ical
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteStamp(t *testing.T) {
+	edited := time.Date(2026, 10, 1, 8, 30, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name string
+		ev   Event
+		want string
+	}{
+		{"last modified", Event{LastModified: edited, Created: edited.AddDate(0, -1, 0)}, "DTSTAMP:20261001T083000Z"},
+		{"created", Event{Created: edited}, "DTSTAMP:20261001T083000Z"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ev := tc.ev
+			ev.UID, ev.Summary, ev.Start = "a@example.com", "Standup", edited
+			cal := &Calendar{Location: time.UTC, Events: []Event{ev}}
+
+			var first, second bytes.Buffer
+			if err := Write(&first, cal); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+			time.Sleep(time.Second)
+			if err := Write(&second, cal); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+			if first.String() != second.String() {
+				t.Errorf("the same calendar encoded differently:\n%s\n%s", first.String(), second.String())
+			}
+			if !strings.Contains(first.String(), tc.want+"\r\n") {
+				t.Errorf("output lacks %s:\n%s", tc.want, first.String())
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	if err != nil {
+		t.Skipf("no zone data: %v", err)
+	}
+	start := time.Date(2026, 10, 20, 9, 30, 0, 0, oslo)
+	for _, tc := range []struct {
+		name string
+		loc  *time.Location
+		ev   Event
+	}{
+		{"timed in zone", oslo, Event{
+			Start: start, End: start.Add(time.Hour),
+			Description: "Line one\nline two; with, punctuation \\ too",
+			Location:    "Room 1", Status: "CONFIRMED",
+			Categories: []string{"Work", "a,b"},
+			Attendees:  []Attendee{{Name: "Ada Lovelace", Email: "ada@example.com"}, {Name: "Team: ops"}},
+			RRule:      "FREQ=WEEKLY;BYDAY=TU", ExDates: []time.Time{start.AddDate(0, 0, 7)},
+			URL: "https://example.com/e", LastModified: time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC),
+		}},
+		{"timed in UTC", time.UTC, Event{Start: start.UTC(), End: start.Add(90 * time.Minute).UTC()}},
+		{"all day", oslo, Event{
+			Start: time.Date(2026, 10, 20, 0, 0, 0, 0, oslo), End: time.Date(2026, 10, 22, 0, 0, 0, 0, oslo),
+			AllDay: true,
+		}},
+		{"override", oslo, Event{Start: start.Add(time.Hour), RecurrenceID: start}},
+		{"long summary", oslo, Event{Start: start, Summary: strings.Repeat("Plenty of ærlige ord ", 10)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ev := tc.ev
+			ev.UID = "e1@example.com"
+			if ev.Summary == "" {
+				ev.Summary = "Standup"
+			}
+			var buf bytes.Buffer
+			if err := Write(&buf, &Calendar{Name: "Work", Location: tc.loc, Events: []Event{ev}}); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+			for _, line := range strings.Split(buf.String(), "\r\n") {
+				if len(line) > maxLineBytes {
+					t.Errorf("line longer than %d octets: %q", maxLineBytes, line)
+				}
+			}
+
+			cal, err := Read(&buf, tc.loc)
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			if cal.Name != "Work" || len(cal.Events) != 1 {
+				t.Fatalf("Read = %q with %d events, want Work with 1", cal.Name, len(cal.Events))
+			}
+			got := cal.Events[0]
+			if got.UID != ev.UID || got.Summary != ev.Summary || got.Description != ev.Description ||
+				got.Location != ev.Location || got.Status != ev.Status || got.URL != ev.URL ||
+				got.RRule != ev.RRule || got.AllDay != ev.AllDay {
+				t.Errorf("Read = %+v, want %+v", got, ev)
+			}
+			for _, p := range [][2]time.Time{
+				{got.Start, ev.Start}, {got.End, ev.End}, {got.RecurrenceID, ev.RecurrenceID}, {got.LastModified, ev.LastModified},
+			} {
+				if !p[0].Equal(p[1]) {
+					t.Errorf("time = %v, want %v", p[0], p[1])
+				}
+			}
+			if strings.Join(got.Categories, "|") != strings.Join(ev.Categories, "|") {
+				t.Errorf("Categories = %q, want %q", got.Categories, ev.Categories)
+			}
+			if len(got.Attendees) != len(ev.Attendees) {
+				t.Fatalf("Attendees = %+v, want %+v", got.Attendees, ev.Attendees)
+			}
+			for i := range got.Attendees {
+				if got.Attendees[i] != ev.Attendees[i] {
+					t.Errorf("Attendees[%d] = %+v, want %+v", i, got.Attendees[i], ev.Attendees[i])
+				}
+			}
+			if len(got.ExDates) != len(ev.ExDates) || (len(got.ExDates) > 0 && !got.ExDates[0].Equal(ev.ExDates[0])) {
+				t.Errorf("ExDates = %v, want %v", got.ExDates, ev.ExDates)
+			}
+		})
+	}
+}
+
+func TestWriteTimezone(t *testing.T) {
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	if err != nil {
+		t.Skipf("no zone data: %v", err)
+	}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("no zone data: %v", err)
+	}
+	timezone := func(loc *time.Location, events ...Event) string {
+		var buf bytes.Buffer
+		if err := Write(&buf, &Calendar{Location: loc, Events: events}); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		out := buf.String()
+		return out[strings.Index(out, "BEGIN:VTIMEZONE") : strings.Index(out, "END:VTIMEZONE")+len("END:VTIMEZONE")]
+	}
+	onsets := func(vtimezone string) []string {
+		var out []string
+		for _, line := range strings.Split(vtimezone, "\r\n") {
+			if strings.HasPrefix(line, "DTSTART:") {
+				out = append(out, strings.TrimPrefix(line, "DTSTART:"))
+			}
+		}
+		return out
+	}
+
+	summer := Event{UID: "a", Start: time.Date(2026, 7, 1, 9, 0, 0, 0, oslo)}
+	got := timezone(oslo, summer)
+	if want := "BEGIN:DAYLIGHT\r\nDTSTART:20260329T020000\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0200\r\nTZNAME:CEST\r\nEND:DAYLIGHT"; !strings.Contains(got, want) {
+		t.Errorf("VTIMEZONE lacks the 2026 spring change:\n%s", got)
+	}
+	if want := "BEGIN:STANDARD\r\nDTSTART:20261025T030000\r\nTZOFFSETFROM:+0200\r\nTZOFFSETTO:+0100\r\nTZNAME:CET\r\nEND:STANDARD"; !strings.Contains(got, want) {
+		t.Errorf("VTIMEZONE lacks the 2026 autumn change:\n%s", got)
+	}
+	if want := []string{"20250330T020000", "20251026T030000", "20260329T020000", "20261025T030000"}; !reflect.DeepEqual(onsets(got), want) {
+		t.Errorf("onsets for a 2026 event = %q, want %q", onsets(got), want)
+	}
+
+	old := Event{UID: "b", Start: time.Date(2019, 1, 10, 9, 0, 0, 0, oslo)}
+	if n := len(onsets(timezone(oslo, old, summer))); n != 18 {
+		t.Errorf("events in 2019 and 2026 give %d onsets, want 18 for 2018 to 2026", n)
+	}
+	recurring := summer
+	recurring.RRule = "FREQ=WEEKLY"
+	if n := len(onsets(timezone(oslo, recurring))); n != 14 {
+		t.Errorf("a recurring event from 2026 gives %d onsets, want 14 for 2025 to 2031", n)
+	}
+
+	got = timezone(tokyo, Event{UID: "c", Start: time.Date(2026, 7, 1, 9, 0, 0, 0, tokyo)})
+	if want := "BEGIN:STANDARD\r\nDTSTART:19700101T000000\r\nTZOFFSETFROM:+0900\r\nTZOFFSETTO:+0900\r\nTZNAME:JST\r\nEND:STANDARD"; !strings.Contains(got, want) {
+		t.Errorf("VTIMEZONE for a zone without changes:\n%s", got)
+	}
+}
+
+func TestRead(t *testing.T) {
+	cet := time.FixedZone("CET", 60*60)
+	wrap := func(lines ...string) string {
+		return "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:x\r\n" + strings.Join(lines, "\r\n") + "\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	}
+	for _, tc := range []struct {
+		name       string
+		in         string
+		start, end time.Time
+		allDay     bool
+	}{
+		{"utc", wrap("DTSTART:20261020T070000Z", "DTEND:20261020T080000Z"),
+			time.Date(2026, 10, 20, 7, 0, 0, 0, time.UTC), time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC), false},
+		{"floating", wrap("DTSTART:20261020T090000"),
+			time.Date(2026, 10, 20, 9, 0, 0, 0, cet), time.Time{}, false},
+		{"unknown tzid", wrap("DTSTART;TZID=\"Custom Zone\":20261020T090000"),
+			time.Date(2026, 10, 20, 9, 0, 0, 0, cet), time.Time{}, false},
+		{"duration", wrap("DTSTART:20261020T070000Z", "DURATION:PT1H30M"),
+			time.Date(2026, 10, 20, 7, 0, 0, 0, time.UTC), time.Date(2026, 10, 20, 8, 30, 0, 0, time.UTC), false},
+		{"all day", wrap("DTSTART;VALUE=DATE:20261020", "DURATION:P2D"),
+			time.Date(2026, 10, 20, 0, 0, 0, 0, cet), time.Date(2026, 10, 22, 0, 0, 0, 0, cet), true},
+		{"bare date", wrap("DTSTART:20261020"),
+			time.Date(2026, 10, 20, 0, 0, 0, 0, cet), time.Time{}, true},
+		{"folded and alarm", wrap("DTSTART:2026102", " 0T070000Z", "BEGIN:VALARM", "DTSTART:20200101T000000Z", "END:VALARM"),
+			time.Date(2026, 10, 20, 7, 0, 0, 0, time.UTC), time.Time{}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cal, err := Read(strings.NewReader(tc.in), cet)
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			ev := cal.Events[0]
+			if !ev.Start.Equal(tc.start) || !ev.End.Equal(tc.end) || ev.AllDay != tc.allDay {
+				t.Errorf("Read = %v - %v (all day %v), want %v - %v (all day %v)", ev.Start, ev.End, ev.AllDay, tc.start, tc.end, tc.allDay)
+			}
+		})
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name, in string
+	}{
+		{"no uid", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20261020T070000Z\nEND:VEVENT\nEND:VCALENDAR\n"},
+		{"no start", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:x\nEND:VEVENT\nEND:VCALENDAR\n"},
+		{"ends before start", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:x\nDTSTART:20261020T070000Z\nDTEND:20261020T060000Z\nEND:VEVENT\nEND:VCALENDAR\n"},
+		{"bad time", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:x\nDTSTART:tomorrow\nEND:VEVENT\nEND:VCALENDAR\n"},
+		{"bad duration", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:x\nDTSTART:20261020T070000Z\nDURATION:1H\nEND:VEVENT\nEND:VCALENDAR\n"},
+		{"malformed line", "BEGIN:VCALENDAR\nnonsense\nEND:VCALENDAR\n"},
+		{"unterminated", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:x\n"},
+		{"stray end", "END:VCALENDAR\n"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Read(strings.NewReader(tc.in), time.UTC); err == nil {
+				t.Errorf("Read succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"PT1H30M", 90 * time.Minute, true},
+		{"P1D", 24 * time.Hour, true},
+		{"P2W", 14 * 24 * time.Hour, true},
+		{"P1DT12H", 36 * time.Hour, true},
+		{"+PT15S", 15 * time.Second, true},
+		{"-PT15M", -15 * time.Minute, true},
+		{"PT", 0, false},
+		{"P1H", 0, false},
+		{"PT1", 0, false},
+		{"PTM", 0, false},
+		{"1H", 0, false},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := parseDuration(tc.in)
+			if (err == nil) != tc.ok || got != tc.want {
+				t.Errorf("parseDuration(%q) = %v, %v, want %v (ok %v)", tc.in, got, err, tc.want, tc.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package notiontest
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// maxChildren is the most blocks Notion accepts in one append request
+const maxChildren = 100
+
+// blockTypes lists the block types the fake accepts
+var blockTypes = map[string]bool{
+	"paragraph": true, "heading_1": true, "heading_2": true, "heading_3": true,
+	"bulleted_list_item": true, "numbered_list_item": true, "to_do": true,
+	"toggle": true, "quote": true, "code": true, "divider": true,
+	"callout": true, "bookmark": true, "equation": true,
+}
+
+// Paragraph returns a paragraph block for AddBlocks
+func Paragraph(text string) map[string]any {
+	return textBlock("paragraph", text)
+}
+
+// Heading returns a heading block of level 1 to 3 for AddBlocks
+func Heading(level int, text string) map[string]any {
+	return textBlock(fmt.Sprintf("heading_%d", level), text)
+}
+
+// BulletedItem returns a bulleted list item for AddBlocks
+func BulletedItem(text string) map[string]any {
+	return textBlock("bulleted_list_item", text)
+}
+
+// ToDo returns a to-do block for AddBlocks
+func ToDo(text string, checked bool) map[string]any {
+	b := textBlock("to_do", text)
+	b["to_do"].(map[string]any)["checked"] = checked
+	return b
+}
+
+func textBlock(kind, text string) map[string]any {
+	return map[string]any{
+		"object": "block",
+		"type":   kind,
+		kind: map[string]any{
+			"rich_text": []any{map[string]any{"type": "text", "text": map[string]any{"content": text}}},
+		},
+	}
+}
+
+// AddBlocks appends blocks to a page or block and returns their IDs
+func (s *Server) AddBlocks(parentID string, blocks ...map[string]any) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	children := make([]any, 0, len(blocks))
+	for _, b := range blocks {
+		children = append(children, map[string]any(clone(b)))
+	}
+	added, err := s.addChildren(parentID, children, "")
+	if err != nil {
+		panic(fmt.Sprintf("notiontest: AddBlocks: %v", err))
+	}
+	ids := make([]string, 0, len(added))
+	for _, b := range added {
+		ids = append(ids, b["id"].(string))
+	}
+	return ids
+}
+
+// container finds the page or block children are added to
+func (s *Server) container(id string) (map[string]any, *apiError) {
+	k, ok := key(id)
+	if !ok {
+		return nil, invalidID("block", id)
+	}
+	if page, ok := s.pages[k]; ok {
+		return page, nil
+	}
+	if block, ok := s.blocks[k]; ok {
+		return block, nil
+	}
+	return nil, notFound(id)
+}
+
+// addChildren stores blocks under a parent, after the given sibling or at
+// the end. Children nested in a block's content are stored under it.
+func (s *Server) addChildren(parentID string, raw []any, after string) ([]map[string]any, *apiError) {
+	parent, err := s.container(parentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent["archived"] == true {
+		return nil, validation("Can't edit block that is archived. You must unarchive the block before editing.")
+	}
+	if len(raw) > maxChildren {
+		return nil, validation("body failed validation: body.children.length should be ≤ `%d`, instead was `%d`.", maxChildren, len(raw))
+	}
+
+	pk, _ := key(parent["id"].(string))
+	siblings := s.children[pk]
+	pos := len(siblings)
+	if after != "" {
+		ak, ok := key(after)
+		pos = -1
+		for i, id := range siblings {
+			if ok && id == ak {
+				pos = i + 1
+			}
+		}
+		if pos < 0 {
+			return nil, validation("Could not find block with ID: %s among the children of %s.", after, parentID)
+		}
+	}
+
+	ref := map[string]any{"type": "page_id", "page_id": parent["id"]}
+	if parent["object"] == "block" {
+		ref = map[string]any{"type": "block_id", "block_id": parent["id"]}
+	}
+
+	added := make([]map[string]any, 0, len(raw))
+	keys := make([]string, 0, len(raw))
+	var nested [][]any
+	for i, r := range raw {
+		in, _ := r.(map[string]any)
+		kind, _ := in["type"].(string)
+		if kind == "" {
+			for t := range blockTypes {
+				if _, ok := in[t]; ok {
+					kind = t
+				}
+			}
+		}
+		if !blockTypes[kind] {
+			return nil, validation("body failed validation: body.children[%d] should be a supported block type, instead was `%q`.", i, kind)
+		}
+		content, _ := in[kind].(map[string]any)
+		content = clone(content)
+		if content == nil {
+			content = map[string]any{}
+		}
+		if rt, ok := content["rich_text"]; ok {
+			text, err := checkRichText(fmt.Sprintf("children[%d].%s.rich_text", i, kind), rt)
+			if err != nil {
+				return nil, err
+			}
+			content["rich_text"] = text
+		}
+		kids, _ := content["children"].([]any)
+		delete(content, "children")
+		nested = append(nested, kids)
+
+		now := s.now()
+		block := map[string]any{
+			"object":           "block",
+			"id":               s.newID(),
+			"parent":           ref,
+			"created_time":     now,
+			"last_edited_time": now,
+			"created_by":       map[string]any{"object": "user", "id": s.bot["id"]},
+			"last_edited_by":   map[string]any{"object": "user", "id": s.bot["id"]},
+			"has_children":     false,
+			"archived":         false,
+			"in_trash":         false,
+			"type":             kind,
+			kind:               content,
+		}
+		k, _ := key(block["id"].(string))
+		s.blocks[k] = block
+		added = append(added, block)
+		keys = append(keys, k)
+	}
+
+	updated := append(append(append([]string{}, siblings[:pos]...), keys...), siblings[pos:]...)
+	s.children[pk] = updated
+	if parent["object"] == "block" && len(keys) > 0 {
+		parent["has_children"] = true
+	}
+
+	for i, kids := range nested {
+		if len(kids) == 0 {
+			continue
+		}
+		if _, err := s.addChildren(added[i]["id"].(string), kids, ""); err != nil {
+			return nil, err
+		}
+	}
+	return added, nil
+}
+
+func (s *Server) block(id string) (map[string]any, *apiError) {
+	k, ok := key(id)
+	if !ok {
+		return nil, invalidID("block", id)
+	}
+	block, ok := s.blocks[k]
+	if !ok {
+		return nil, notFound(id)
+	}
+	return block, nil
+}
+
+func (s *Server) getBlock(id string) (any, *apiError) {
+	block, err := s.block(id)
+	if err != nil {
+		return nil, err
+	}
+	return clone(block), nil
+}
+
+// liveChildren returns the children of a page or block that aren't archived
+func (s *Server) liveChildren(id string) []map[string]any {
+	k, _ := key(id)
+	var out []map[string]any
+	for _, ck := range s.children[k] {
+		if b := s.blocks[ck]; b != nil && b["archived"] != true {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+func (s *Server) listChildren(id string, query url.Values) (any, *apiError) {
+	parent, err := s.container(id)
+	if err != nil {
+		return nil, err
+	}
+	size, err := pageSize(first(query["page_size"]))
+	if err != nil {
+		return nil, err
+	}
+	results, next, more, err := s.paginate(s.liveChildren(parent["id"].(string)), first(query["start_cursor"]), size)
+	if err != nil {
+		return nil, err
+	}
+	return list("block", clone(results), next, more), nil
+}
+
+func (s *Server) appendChildren(id string, req map[string]any) (any, *apiError) {
+	children, ok := req["children"].([]any)
+	if !ok {
+		return nil, validation("body failed validation: body.children should be defined, instead was `undefined`.")
+	}
+	after, _ := req["after"].(string)
+	added, err := s.addChildren(id, children, after)
+	if err != nil {
+		return nil, err
+	}
+	return list("block", clone(added), nil, false), nil
+}
+
+// updateBlock changes the content of a block or archives it. The content has
+// to be given under the block's own type.
+func (s *Server) updateBlock(id string, req map[string]any) (any, *apiError) {
+	block, err := s.block(id)
+	if err != nil {
+		return nil, err
+	}
+	archived, hasArchived := req["archived"].(bool)
+	if block["archived"] == true && !(hasArchived && !archived) {
+		return nil, validation("Can't edit block that is archived. You must unarchive the block before editing.")
+	}
+
+	kind := block["type"].(string)
+	for t := range blockTypes {
+		if _, ok := req[t]; ok && t != kind {
+			return nil, validation("body failed validation: body.%s should not be present for a block of type %s.", t, kind)
+		}
+	}
+	if update, ok := req[kind].(map[string]any); ok {
+		content := block[kind].(map[string]any)
+		for field, v := range update {
+			if field == "rich_text" || field == "caption" {
+				text, err := checkRichText(kind+"."+field, v)
+				if err != nil {
+					return nil, err
+				}
+				v = text
+			}
+			if field == "children" {
+				continue
+			}
+			content[field] = v
+		}
+	}
+	if hasArchived {
+		block["archived"] = archived
+		block["in_trash"] = archived
+	}
+	block["last_edited_time"] = s.now()
+	return clone(block), nil
+}
+
+func (s *Server) deleteBlock(id string) (any, *apiError) {
+	block, err := s.block(id)
+	if err != nil {
+		return nil, err
+	}
+	if block["archived"] == true {
+		return nil, validation("Can't edit block that is archived. You must unarchive the block before editing.")
+	}
+	block["archived"] = true
+	block["in_trash"] = true
+	block["last_edited_time"] = s.now()
+	return clone(block), nil
+}
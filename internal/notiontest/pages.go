@@ -0,0 +1,653 @@
+package notiontest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxTextLength is Notion's limit on the content of one rich text object
+const maxTextLength = 2000
+
+// AddPage adds a page to a database and returns its ID. Values are given by
+// property name either in API form or as shorthand: a string for text,
+// select, status, URL, email, phone and date properties, a []string for
+// multi-select options or related page and user IDs, a number or a bool.
+func (s *Server) AddPage(databaseID string, values map[string]any) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	db, err := s.database(databaseID)
+	if err != nil {
+		panic(fmt.Sprintf("notiontest: AddPage: %v", err))
+	}
+	configs := db["properties"].(map[string]any)
+	props := make(map[string]any, len(values))
+	for name, v := range values {
+		_, cfg, ok := lookupConfig(configs, name)
+		if !ok {
+			panic(fmt.Sprintf("notiontest: AddPage: no property %q", name))
+		}
+		props[name] = shorthand(cfg["type"].(string), v)
+	}
+	page, err := s.newPage(map[string]any{"type": "database_id", "database_id": db["id"]}, props)
+	if err != nil {
+		panic(fmt.Sprintf("notiontest: AddPage: %v", err))
+	}
+	return page["id"].(string)
+}
+
+// AddWorkspacePage adds a page outside any database, such as the page new
+// databases are created in, and returns its ID
+func (s *Server) AddWorkspacePage(title string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	page, err := s.newPage(map[string]any{"type": "workspace", "workspace": true}, map[string]any{
+		"title": map[string]any{"title": []any{map[string]any{"text": map[string]any{"content": title}}}},
+	})
+	if err != nil {
+		panic(fmt.Sprintf("notiontest: AddWorkspacePage: %v", err))
+	}
+	return page["id"].(string)
+}
+
+// SetIcon gives a page or database an emoji icon
+func (s *Server) SetIcon(id, emoji string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k, _ := key(id)
+	obj := s.pages[k]
+	if obj == nil {
+		obj = s.databases[k]
+	}
+	if obj == nil {
+		panic(fmt.Sprintf("notiontest: SetIcon: no page or database %s", id))
+	}
+	obj["icon"] = map[string]any{"type": "emoji", "emoji": emoji}
+}
+
+// AddUser adds a workspace member and returns their ID
+func (s *Server) AddUser(name, email string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.newID()
+	s.users = append(s.users, map[string]any{
+		"object":     "user",
+		"id":         id,
+		"type":       "person",
+		"name":       name,
+		"avatar_url": nil,
+		"person":     map[string]any{"email": email},
+	})
+	return id
+}
+
+// shorthand expands an AddPage value into the API form for a property type
+func shorthand(kind string, v any) any {
+	if m, ok := v.(map[string]any); ok {
+		return m
+	}
+	switch val := v.(type) {
+	case nil:
+		return map[string]any{kind: nil}
+	case string:
+		switch kind {
+		case "title", "rich_text":
+			return map[string]any{kind: []any{map[string]any{"text": map[string]any{"content": val}}}}
+		case "select", "status":
+			return map[string]any{kind: map[string]any{"name": val}}
+		case "multi_select":
+			return map[string]any{kind: []any{map[string]any{"name": val}}}
+		case "date":
+			return map[string]any{kind: map[string]any{"start": val}}
+		case "relation", "people":
+			return map[string]any{kind: []any{map[string]any{"id": val}}}
+		case "number":
+			n, _ := strconv.ParseFloat(val, 64)
+			return map[string]any{kind: n}
+		case "checkbox":
+			b, _ := strconv.ParseBool(val)
+			return map[string]any{kind: b}
+		}
+		return map[string]any{kind: val}
+	case []string:
+		items := make([]any, 0, len(val))
+		for _, item := range val {
+			if kind == "multi_select" {
+				items = append(items, map[string]any{"name": item})
+			} else {
+				items = append(items, map[string]any{"id": item})
+			}
+		}
+		return map[string]any{kind: items}
+	case int:
+		return map[string]any{kind: float64(val)}
+	}
+	return map[string]any{kind: v}
+}
+
+func (s *Server) page(id string) (map[string]any, *apiError) {
+	k, ok := key(id)
+	if !ok {
+		return nil, invalidID("page", id)
+	}
+	page, ok := s.pages[k]
+	if !ok {
+		return nil, notFound(id)
+	}
+	return page, nil
+}
+
+func (s *Server) getPage(id string) (any, *apiError) {
+	page, err := s.page(id)
+	if err != nil {
+		return nil, err
+	}
+	return clone(page), nil
+}
+
+func (s *Server) createPage(req map[string]any) (any, *apiError) {
+	parent, _ := req["parent"].(map[string]any)
+	var out map[string]any
+	switch {
+	case parent["database_id"] != nil:
+		db, err := s.database(fmt.Sprint(parent["database_id"]))
+		if err != nil {
+			return nil, err
+		}
+		props, _ := req["properties"].(map[string]any)
+		page, err := s.newPage(map[string]any{"type": "database_id", "database_id": db["id"]}, props)
+		if err != nil {
+			return nil, err
+		}
+		out = page
+	case parent["page_id"] != nil:
+		if _, err := s.page(fmt.Sprint(parent["page_id"])); err != nil {
+			return nil, err
+		}
+		props, _ := req["properties"].(map[string]any)
+		page, err := s.newPage(map[string]any{"type": "page_id", "page_id": parent["page_id"]}, props)
+		if err != nil {
+			return nil, err
+		}
+		out = page
+	default:
+		return nil, validation("body failed validation: body.parent should be defined.")
+	}
+
+	if icon, ok := req["icon"]; ok {
+		out["icon"] = icon
+	}
+	if children, ok := req["children"].([]any); ok {
+		if _, err := s.addChildren(out["id"].(string), children, ""); err != nil {
+			return nil, err
+		}
+	}
+	return clone(out), nil
+}
+
+// newPage stores a page, checking its properties against the schema of its
+// database. Properties left out get their empty value.
+func (s *Server) newPage(parent map[string]any, props map[string]any) (map[string]any, *apiError) {
+	now := s.now()
+	page := map[string]any{
+		"object":           "page",
+		"id":               s.newID(),
+		"created_time":     now,
+		"last_edited_time": now,
+		"created_by":       map[string]any{"object": "user", "id": s.bot["id"]},
+		"last_edited_by":   map[string]any{"object": "user", "id": s.bot["id"]},
+		"cover":            nil,
+		"icon":             nil,
+		"parent":           parent,
+		"archived":         false,
+		"in_trash":         false,
+		"properties":       map[string]any{},
+		"public_url":       nil,
+	}
+
+	if schema := s.schemaOf(page); schema != nil {
+		values, err := s.writeProperties(schema, page, props)
+		if err != nil {
+			return nil, err
+		}
+		for name, c := range schema {
+			if _, ok := values[name]; !ok {
+				values[name] = s.emptyValue(c.(map[string]any), page)
+			}
+		}
+		page["properties"] = values
+	} else {
+		for name := range props {
+			if name != "title" {
+				return nil, validation("Invalid property for a page outside a database: %s. Only title can be set.", name)
+			}
+		}
+		raw, _ := props["title"].(map[string]any)
+		title, err := checkRichText("title", raw["title"])
+		if err != nil {
+			return nil, err
+		}
+		page["properties"] = map[string]any{"title": map[string]any{"id": "title", "type": "title", "title": title}}
+	}
+
+	k, _ := key(page["id"].(string))
+	page["url"] = "https://www.notion.so/" + slug(pageTitle(page)) + k
+	s.pages[k] = page
+	s.order = append(s.order, k)
+	return page, nil
+}
+
+func slug(title string) string {
+	var b strings.Builder
+	for _, r := range title {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "-"):
+			b.WriteRune('-')
+		}
+	}
+	out := strings.Trim(b.String(), "-")
+	if out == "" {
+		return ""
+	}
+	return out + "-"
+}
+
+func (s *Server) updatePage(id string, req map[string]any) (any, *apiError) {
+	page, err := s.page(id)
+	if err != nil {
+		return nil, err
+	}
+	archived, _ := req["archived"].(bool)
+	if page["archived"] == true {
+		if archived || req["archived"] == nil {
+			return nil, validation("Can't edit block that is archived. You must unarchive the block before editing.")
+		}
+	}
+
+	if props, ok := req["properties"].(map[string]any); ok && len(props) > 0 {
+		schema := s.schemaOf(page)
+		if schema == nil {
+			schema = map[string]any{"title": map[string]any{"id": "title", "name": "title", "type": "title", "title": map[string]any{}}}
+		}
+		values, err := s.writeProperties(schema, page, props)
+		if err != nil {
+			return nil, err
+		}
+		current := page["properties"].(map[string]any)
+		for name, v := range values {
+			current[name] = v
+		}
+	}
+	if icon, ok := req["icon"]; ok {
+		page["icon"] = icon
+	}
+	if _, ok := req["archived"]; ok {
+		page["archived"] = archived
+		page["in_trash"] = archived
+	}
+	page["last_edited_time"] = s.now()
+	return clone(page), nil
+}
+
+// writeProperties validates property values against a schema and returns
+// them in the form pages are read back in
+func (s *Server) writeProperties(schema map[string]any, page map[string]any, props map[string]any) (map[string]any, *apiError) {
+	values := make(map[string]any, len(props))
+	for nameOrID, raw := range props {
+		name, cfg, ok := lookupConfig(schema, nameOrID)
+		if !ok {
+			return nil, validation("%s is not a property that exists.", nameOrID)
+		}
+		v, ok := raw.(map[string]any)
+		if !ok {
+			return nil, validation("body failed validation: body.properties.%s should be an object.", name)
+		}
+		value, err := s.writeValue(name, cfg, v)
+		if err != nil {
+			return nil, err
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// writeValue checks one property value and expands it the way Notion stores
+// it: rich text gets plain_text, options get IDs, and users are filled in
+func (s *Server) writeValue(name string, cfg map[string]any, v map[string]any) (map[string]any, *apiError) {
+	kind := cfg["type"].(string)
+	if writable := propertyTypes[kind]; !writable {
+		return nil, validation("%s is a %s property, which can't be edited through the API.", name, kind)
+	}
+	raw, ok := v[kind]
+	if !ok {
+		return nil, validation("%s is expected to be %s.", name, kind)
+	}
+	if t, ok := v["type"].(string); ok && t != kind {
+		return nil, validation("%s is expected to be %s.", name, kind)
+	}
+
+	out := map[string]any{"id": cfg["id"], "type": kind}
+	switch kind {
+	case "title", "rich_text":
+		rt, err := checkRichText(name, raw)
+		if err != nil {
+			return nil, err
+		}
+		out[kind] = rt
+	case "select":
+		if raw == nil {
+			out[kind] = nil
+			break
+		}
+		opt, err := s.option(name, cfg, raw, true)
+		if err != nil {
+			return nil, err
+		}
+		out[kind] = opt
+	case "status":
+		if raw == nil {
+			out[kind] = nil
+			break
+		}
+		opt, err := s.option(name, cfg, raw, false)
+		if err != nil {
+			return nil, err
+		}
+		out[kind] = opt
+	case "multi_select":
+		items, ok := raw.([]any)
+		if !ok {
+			return nil, validation("body failed validation: body.properties.%s.multi_select should be an array.", name)
+		}
+		opts := make([]any, 0, len(items))
+		for _, item := range items {
+			opt, err := s.option(name, cfg, item, true)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, opt)
+		}
+		out[kind] = opts
+	case "number":
+		if _, ok := raw.(float64); !ok && raw != nil {
+			return nil, validation("body failed validation: body.properties.%s.number should be a number or null.", name)
+		}
+		out[kind] = raw
+	case "checkbox":
+		if _, ok := raw.(bool); !ok {
+			return nil, validation("body failed validation: body.properties.%s.checkbox should be a boolean.", name)
+		}
+		out[kind] = raw
+	case "url", "email", "phone_number":
+		if _, ok := raw.(string); !ok && raw != nil {
+			return nil, validation("body failed validation: body.properties.%s.%s should be a string or null.", name, kind)
+		}
+		if raw == "" {
+			raw = nil
+		}
+		out[kind] = raw
+	case "date":
+		date, err := dateValue(name, raw)
+		if err != nil {
+			return nil, err
+		}
+		out[kind] = date
+	case "people":
+		items, _ := raw.([]any)
+		users := make([]any, 0, len(items))
+		for _, item := range items {
+			ref, _ := item.(map[string]any)
+			user := s.user(fmt.Sprint(ref["id"]))
+			if user == nil {
+				return nil, validation("Could not find user with ID: %v", ref["id"])
+			}
+			users = append(users, clone(user))
+		}
+		out[kind] = users
+	case "relation":
+		items, _ := raw.([]any)
+		related := make([]any, 0, len(items))
+		for _, item := range items {
+			ref, _ := item.(map[string]any)
+			target, err := s.page(fmt.Sprint(ref["id"]))
+			if err != nil {
+				return nil, validation("Could not find page with ID: %v", ref["id"])
+			}
+			related = append(related, map[string]any{"id": target["id"]})
+		}
+		out[kind] = related
+		out["has_more"] = false
+	case "files":
+		out[kind] = raw
+	}
+	return out, nil
+}
+
+// option resolves a select or status option by name or ID. Select options
+// that don't exist yet are added to the schema, as Notion does; status
+// options have to exist.
+func (s *Server) option(name string, cfg map[string]any, raw any, create bool) (map[string]any, *apiError) {
+	ref, ok := raw.(map[string]any)
+	if !ok {
+		return nil, validation("body failed validation: body.properties.%s should hold option objects.", name)
+	}
+	kind := cfg["type"].(string)
+	body := cfg[kind].(map[string]any)
+	opts, _ := body["options"].([]any)
+	want, _ := ref["name"].(string)
+	for _, o := range opts {
+		opt := o.(map[string]any)
+		if (want != "" && opt["name"] == want) || (ref["id"] != nil && opt["id"] == ref["id"]) {
+			return clone(opt), nil
+		}
+	}
+	if !create || want == "" {
+		return nil, validation("Invalid %s option. %s option %q does not exist.", kind, strings.ToUpper(kind[:1])+kind[1:], want)
+	}
+	if strings.Contains(want, ",") {
+		return nil, validation("Invalid select option, commas not allowed: %s", want)
+	}
+	opt := map[string]any{"id": s.newID(), "name": want, "color": "default"}
+	body["options"] = append(opts, opt)
+	return clone(opt), nil
+}
+
+// dateValue checks a date value; start is required and both ends must be
+// ISO 8601 dates or date-times
+func dateValue(name string, raw any) (any, *apiError) {
+	if raw == nil {
+		return nil, nil
+	}
+	d, ok := raw.(map[string]any)
+	if !ok {
+		return nil, validation("body failed validation: body.properties.%s.date should be an object or null.", name)
+	}
+	start, _ := d["start"].(string)
+	if _, _, ok := parseDate(start); !ok {
+		return nil, validation("body failed validation: body.properties.%s.date.start should be a valid ISO 8601 date string, instead was `%q`.", name, start)
+	}
+	out := map[string]any{"start": start, "end": nil, "time_zone": d["time_zone"]}
+	if end, ok := d["end"].(string); ok && end != "" {
+		if _, _, ok := parseDate(end); !ok {
+			return nil, validation("body failed validation: body.properties.%s.date.end should be a valid ISO 8601 date string, instead was `%q`.", name, end)
+		}
+		out["end"] = end
+	}
+	return out, nil
+}
+
+// parseDate reads an ISO 8601 date or date-time
+func parseDate(s string) (time.Time, bool, bool) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, true, true
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, false, true
+	}
+	return time.Time{}, false, false
+}
+
+// emptyValue is the value of a property nothing was written to
+func (s *Server) emptyValue(cfg map[string]any, page map[string]any) map[string]any {
+	kind := cfg["type"].(string)
+	out := map[string]any{"id": cfg["id"], "type": kind}
+	switch kind {
+	case "title", "rich_text", "multi_select", "people", "files":
+		out[kind] = []any{}
+	case "relation":
+		out[kind] = []any{}
+		out["has_more"] = false
+	case "checkbox":
+		out[kind] = false
+	case "status":
+		out[kind] = nil
+		body := cfg[kind].(map[string]any)
+		groups, _ := body["groups"].([]any)
+		if len(groups) > 0 {
+			ids, _ := groups[0].(map[string]any)["option_ids"].([]any)
+			if len(ids) > 0 {
+				for _, o := range body["options"].([]any) {
+					if o.(map[string]any)["id"] == ids[0] {
+						out[kind] = clone(o)
+					}
+				}
+			}
+		}
+	case "formula":
+		out[kind] = map[string]any{"type": "string", "string": nil}
+	case "rollup":
+		out[kind] = map[string]any{"type": "array", "array": []any{}, "function": "show_original"}
+	case "created_time":
+		out[kind] = page["created_time"]
+	case "last_edited_time":
+		out[kind] = page["last_edited_time"]
+	case "created_by", "last_edited_by":
+		out[kind] = map[string]any{"object": "user", "id": s.bot["id"]}
+	default:
+		out[kind] = nil
+	}
+	return out
+}
+
+// checkRichText checks a rich text array and fills in what Notion adds to it
+func checkRichText(name string, raw any) ([]any, *apiError) {
+	if raw == nil {
+		return []any{}, nil
+	}
+	items, ok := raw.([]any)
+	if !ok {
+		return nil, validation("body failed validation: body.properties.%s should be an array of rich text.", name)
+	}
+	out := make([]any, 0, len(items))
+	for i, item := range items {
+		rt, _ := item.(map[string]any)
+		text, _ := rt["text"].(map[string]any)
+		if text == nil {
+			return nil, validation("body failed validation: body.properties.%s[%d].text should be defined.", name, i)
+		}
+		content, _ := text["content"].(string)
+		if len(content) > maxTextLength {
+			return nil, validation("body failed validation: body.properties.%s[%d].text.content.length should be ≤ `%d`, instead was `%d`.", name, i, maxTextLength, len(content))
+		}
+		annotations, _ := rt["annotations"].(map[string]any)
+		if annotations == nil {
+			annotations = map[string]any{"bold": false, "italic": false, "strikethrough": false, "underline": false, "code": false, "color": "default"}
+		}
+		var href any
+		if link, ok := text["link"].(map[string]any); ok {
+			href = link["url"]
+		}
+		out = append(out, map[string]any{
+			"type":        "text",
+			"text":        map[string]any{"content": content, "link": text["link"]},
+			"annotations": annotations,
+			"plain_text":  content,
+			"href":        href,
+		})
+	}
+	return out, nil
+}
+
+// richText builds a rich text array from plain text
+func richText(content string) []any {
+	if content == "" {
+		return []any{}
+	}
+	rt, _ := checkRichText("", []any{map[string]any{"text": map[string]any{"content": content}}})
+	return rt
+}
+
+// plainText joins the plain text of a rich text array
+func plainText(raw any) string {
+	items, _ := raw.([]any)
+	var b strings.Builder
+	for _, item := range items {
+		rt, _ := item.(map[string]any)
+		s, _ := rt["plain_text"].(string)
+		b.WriteString(s)
+	}
+	return b.String()
+}
+
+// pageTitle returns the plain text of a page's title property
+func pageTitle(page map[string]any) string {
+	props, _ := page["properties"].(map[string]any)
+	for _, p := range props {
+		prop, _ := p.(map[string]any)
+		if prop["type"] == "title" {
+			return plainText(prop["title"])
+		}
+	}
+	return ""
+}
+
+// user finds a user by ID
+func (s *Server) user(id string) map[string]any {
+	k, ok := key(id)
+	if !ok {
+		return nil
+	}
+	for _, u := range s.users {
+		if uk, _ := key(u["id"].(string)); uk == k {
+			return u
+		}
+	}
+	return nil
+}
+
+func (s *Server) listUsers(query map[string][]string) (any, *apiError) {
+	size, err := pageSize(first(query["page_size"]))
+	if err != nil {
+		return nil, err
+	}
+	results, next, more, err := s.paginate(s.users, first(query["start_cursor"]), size)
+	if err != nil {
+		return nil, err
+	}
+	return list("user", clone(results), next, more), nil
+}
+
+func (s *Server) getUser(id string) (any, *apiError) {
+	if id == "me" {
+		return clone(s.bot), nil
+	}
+	if _, ok := key(id); !ok {
+		return nil, invalidID("user", id)
+	}
+	user := s.user(id)
+	if user == nil {
+		return nil, notFound(id)
+	}
+	return clone(user), nil
+}
+
+// first returns the first query value, or "" when there is none
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,555 @@
+package notiontest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// filterTypes maps each filter condition to the property types it applies to
+var filterTypes = map[string][]string{
+	"title":        {"title"},
+	"rich_text":    {"rich_text", "title"},
+	"url":          {"url"},
+	"email":        {"email"},
+	"phone_number": {"phone_number"},
+	"number":       {"number"},
+	"checkbox":     {"checkbox"},
+	"select":       {"select"},
+	"multi_select": {"multi_select"},
+	"status":       {"status"},
+	"date":         {"date"},
+	"people":       {"people"},
+	"relation":     {"relation"},
+}
+
+func (s *Server) queryDatabase(id string, req map[string]any) (any, *apiError) {
+	db, err := s.database(id)
+	if err != nil {
+		return nil, err
+	}
+	schema := db["properties"].(map[string]any)
+
+	var pages []map[string]any
+	for _, k := range s.order {
+		page, ok := s.pages[k]
+		if !ok || page["archived"] == true || !inDatabase(page, db["id"].(string)) {
+			continue
+		}
+		if filter, ok := req["filter"].(map[string]any); ok {
+			match, err := s.match(schema, page, filter, 0)
+			if err != nil {
+				return nil, err
+			}
+			if !match {
+				continue
+			}
+		}
+		pages = append(pages, page)
+	}
+
+	if sorts, ok := req["sorts"].([]any); ok {
+		if err := sortPages(schema, pages, sorts); err != nil {
+			return nil, err
+		}
+	}
+
+	size, err := pageSize(req["page_size"])
+	if err != nil {
+		return nil, err
+	}
+	cursor, _ := req["start_cursor"].(string)
+	results, next, more, err := s.paginate(pages, cursor, size)
+	if err != nil {
+		return nil, err
+	}
+	return list("page_or_database", clone(results), next, more), nil
+}
+
+// match evaluates a filter against a page. Compound filters nest at most two
+// levels deep, as in Notion.
+func (s *Server) match(schema, page, filter map[string]any, depth int) (bool, *apiError) {
+	for _, op := range []string{"and", "or"} {
+		raw, ok := filter[op]
+		if !ok {
+			continue
+		}
+		if depth >= 2 {
+			return false, validation("body failed validation: body.filter compound filters can be nested at most two levels deep.")
+		}
+		items, _ := raw.([]any)
+		for _, item := range items {
+			f, _ := item.(map[string]any)
+			ok, err := s.match(schema, page, f, depth+1)
+			if err != nil {
+				return false, err
+			}
+			if op == "and" && !ok {
+				return false, nil
+			}
+			if op == "or" && ok {
+				return true, nil
+			}
+		}
+		return op == "and", nil
+	}
+
+	if ts, ok := filter["timestamp"].(string); ok {
+		cond, _ := filter[ts].(map[string]any)
+		if cond == nil {
+			return false, validation("body failed validation: body.filter.%s should be defined.", ts)
+		}
+		return matchDate(page[ts], cond)
+	}
+
+	name, _ := filter["property"].(string)
+	if name == "" {
+		return false, validation("body failed validation: body.filter.property should be defined.")
+	}
+	_, cfg, ok := lookupConfig(schema, name)
+	if !ok {
+		return false, validation("Could not find property with name or id: %s", name)
+	}
+	kind := cfg["type"].(string)
+	for condType, applies := range filterTypes {
+		cond, ok := filter[condType].(map[string]any)
+		if !ok {
+			continue
+		}
+		if !contains(applies, kind) {
+			return false, validation("database property %s does not match filter %s", kind, condType)
+		}
+		prop, _ := page["properties"].(map[string]any)[cfg["name"].(string)].(map[string]any)
+		return matchValue(kind, prop[kind], cond)
+	}
+	return false, validation("body failed validation: body.filter should define a condition for %s.", name)
+}
+
+// matchValue evaluates a condition against a stored property value
+func matchValue(kind string, value any, cond map[string]any) (bool, *apiError) {
+	switch kind {
+	case "title", "rich_text", "url", "email", "phone_number":
+		var text string
+		if kind == "title" || kind == "rich_text" {
+			text = plainText(value)
+		} else {
+			text, _ = value.(string)
+		}
+		return matchText(text, cond), nil
+	case "select", "status":
+		var name string
+		if opt, ok := value.(map[string]any); ok {
+			name, _ = opt["name"].(string)
+		}
+		return matchOption(name, cond), nil
+	case "multi_select", "people", "relation":
+		items, _ := value.([]any)
+		var names []string
+		for _, item := range items {
+			m, _ := item.(map[string]any)
+			if kind == "multi_select" {
+				names = append(names, fmt.Sprint(m["name"]))
+			} else {
+				k, _ := key(fmt.Sprint(m["id"]))
+				names = append(names, k)
+			}
+		}
+		if kind != "multi_select" {
+			for _, c := range []string{"contains", "does_not_contain"} {
+				if v, ok := cond[c].(string); ok {
+					k, _ := key(v)
+					cond = map[string]any{c: k}
+				}
+			}
+		}
+		return matchList(names, cond), nil
+	case "number":
+		n, ok := value.(float64)
+		return matchNumber(n, ok, cond), nil
+	case "checkbox":
+		b, _ := value.(bool)
+		if v, ok := cond["equals"].(bool); ok {
+			return b == v, nil
+		}
+		if v, ok := cond["does_not_equal"].(bool); ok {
+			return b != v, nil
+		}
+		return true, nil
+	case "date":
+		var start any
+		if d, ok := value.(map[string]any); ok {
+			start = d["start"]
+		}
+		return matchDate(start, cond)
+	}
+	return false, nil
+}
+
+func matchText(text string, cond map[string]any) bool {
+	lower := strings.ToLower(text)
+	for op, v := range cond {
+		want, _ := v.(string)
+		w := strings.ToLower(want)
+		switch op {
+		case "equals":
+			if text != want {
+				return false
+			}
+		case "does_not_equal":
+			if text == want {
+				return false
+			}
+		case "contains":
+			if !strings.Contains(lower, w) {
+				return false
+			}
+		case "does_not_contain":
+			if strings.Contains(lower, w) {
+				return false
+			}
+		case "starts_with":
+			if !strings.HasPrefix(lower, w) {
+				return false
+			}
+		case "ends_with":
+			if !strings.HasSuffix(lower, w) {
+				return false
+			}
+		case "is_empty":
+			if v == true && text != "" {
+				return false
+			}
+		case "is_not_empty":
+			if v == true && text == "" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func matchOption(name string, cond map[string]any) bool {
+	for op, v := range cond {
+		switch op {
+		case "equals":
+			if name != v {
+				return false
+			}
+		case "does_not_equal":
+			if name == v {
+				return false
+			}
+		case "is_empty":
+			if v == true && name != "" {
+				return false
+			}
+		case "is_not_empty":
+			if v == true && name == "" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func matchList(items []string, cond map[string]any) bool {
+	for op, v := range cond {
+		switch op {
+		case "contains":
+			if !contains(items, fmt.Sprint(v)) {
+				return false
+			}
+		case "does_not_contain":
+			if contains(items, fmt.Sprint(v)) {
+				return false
+			}
+		case "is_empty":
+			if v == true && len(items) > 0 {
+				return false
+			}
+		case "is_not_empty":
+			if v == true && len(items) == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func matchNumber(n float64, set bool, cond map[string]any) bool {
+	for op, v := range cond {
+		want, _ := v.(float64)
+		switch op {
+		case "equals":
+			if !set || n != want {
+				return false
+			}
+		case "does_not_equal":
+			if set && n == want {
+				return false
+			}
+		case "greater_than":
+			if !set || n <= want {
+				return false
+			}
+		case "less_than":
+			if !set || n >= want {
+				return false
+			}
+		case "greater_than_or_equal_to":
+			if !set || n < want {
+				return false
+			}
+		case "less_than_or_equal_to":
+			if !set || n > want {
+				return false
+			}
+		case "is_empty":
+			if v == true && set {
+				return false
+			}
+		case "is_not_empty":
+			if v == true && !set {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchDate evaluates a date condition. A date-only filter value compares
+// calendar days, taking a stored time on the day it was written with; a full
+// timestamp compares instants, a stored date being midnight UTC.
+func matchDate(value any, cond map[string]any) (bool, *apiError) {
+	str, _ := value.(string)
+	stored, _, set := parseDate(str)
+	now := time.Now()
+	for op, v := range cond {
+		switch op {
+		case "is_empty":
+			if v == true && set {
+				return false, nil
+			}
+			continue
+		case "is_not_empty":
+			if v == true && !set {
+				return false, nil
+			}
+			continue
+		}
+		if !set {
+			return false, nil
+		}
+
+		var lo, hi time.Time
+		switch op {
+		case "past_week":
+			lo, hi = now.AddDate(0, 0, -7), now
+		case "past_month":
+			lo, hi = now.AddDate(0, -1, 0), now
+		case "past_year":
+			lo, hi = now.AddDate(-1, 0, 0), now
+		case "next_week":
+			lo, hi = now, now.AddDate(0, 0, 7)
+		case "next_month":
+			lo, hi = now, now.AddDate(0, 1, 0)
+		case "next_year":
+			lo, hi = now, now.AddDate(1, 0, 0)
+		}
+		if !lo.IsZero() {
+			if stored.Before(lo) || stored.After(hi) {
+				return false, nil
+			}
+			continue
+		}
+
+		ref, _ := v.(string)
+		want, wantDay, ok := parseDate(ref)
+		if !ok {
+			return false, validation("body failed validation: body.filter.date.%s should be a valid ISO 8601 date string, instead was `%q`.", op, ref)
+		}
+		var cmp int
+		if wantDay {
+			cmp = strings.Compare(stored.Format("2006-01-02"), want.Format("2006-01-02"))
+		} else if stored.Before(want) {
+			cmp = -1
+		} else if stored.After(want) {
+			cmp = 1
+		}
+		ok = map[string]bool{
+			"equals":       cmp == 0,
+			"before":       cmp < 0,
+			"after":        cmp > 0,
+			"on_or_before": cmp <= 0,
+			"on_or_after":  cmp >= 0,
+		}[op]
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// sortPages orders pages by property values or timestamps. Empty values sort
+// last whatever the direction.
+func sortPages(schema map[string]any, pages []map[string]any, sorts []any) *apiError {
+	type sortKey struct {
+		name      string
+		kind      string
+		timestamp string
+		desc      bool
+	}
+	var keys []sortKey
+	for _, raw := range sorts {
+		so, _ := raw.(map[string]any)
+		k := sortKey{desc: so["direction"] == "descending"}
+		if ts, ok := so["timestamp"].(string); ok {
+			k.timestamp = ts
+		} else {
+			name, _ := so["property"].(string)
+			n, cfg, ok := lookupConfig(schema, name)
+			if !ok {
+				return validation("Could not find sort property with name or id: %s", name)
+			}
+			k.name, k.kind = n, cfg["type"].(string)
+		}
+		keys = append(keys, k)
+	}
+
+	sort.SliceStable(pages, func(i, j int) bool {
+		for _, k := range keys {
+			var a, b string
+			if k.timestamp != "" {
+				a, _ = pages[i][k.timestamp].(string)
+				b, _ = pages[j][k.timestamp].(string)
+			} else {
+				a = sortValue(k.kind, pages[i], k.name)
+				b = sortValue(k.kind, pages[j], k.name)
+			}
+			if a == b {
+				continue
+			}
+			if a == "" || b == "" {
+				return b == ""
+			}
+			if k.desc {
+				return a > b
+			}
+			return a < b
+		}
+		return false
+	})
+	return nil
+}
+
+// sortValue renders a property as a string that sorts like the value
+func sortValue(kind string, page map[string]any, name string) string {
+	prop, _ := page["properties"].(map[string]any)[name].(map[string]any)
+	switch v := prop[kind].(type) {
+	case []any:
+		if kind == "title" || kind == "rich_text" {
+			return strings.ToLower(plainText(v))
+		}
+		if len(v) > 0 {
+			m, _ := v[0].(map[string]any)
+			return fmt.Sprint(m["name"])
+		}
+	case map[string]any:
+		if kind == "date" {
+			t, _, ok := parseDate(fmt.Sprint(v["start"]))
+			if ok {
+				return t.UTC().Format(time.RFC3339)
+			}
+		}
+		if name, ok := v["name"].(string); ok {
+			return name
+		}
+	case float64:
+		return fmt.Sprintf("%020.6f", v+1e12)
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case string:
+		return strings.ToLower(v)
+	}
+	return ""
+}
+
+// search finds pages and databases whose title contains the query. Results
+// are ordered by last edit, newest first, unless a sort says otherwise.
+func (s *Server) search(req map[string]any) (any, *apiError) {
+	var want string
+	if filter, ok := req["filter"].(map[string]any); ok {
+		value, _ := filter["value"].(string)
+		prop, _ := filter["property"].(string)
+		if prop != "object" {
+			return nil, validation("body failed validation: body.filter.property should be `\"object\"`, instead was `%q`.", prop)
+		}
+		if value != "page" && value != "database" {
+			return nil, validation("body failed validation: body.filter.value should be `\"page\"` or `\"database\"`, instead was `%q`.", value)
+		}
+		want = value
+	}
+	query, _ := req["query"].(string)
+	query = strings.ToLower(query)
+
+	var results []map[string]any
+	for _, k := range s.order {
+		var obj map[string]any
+		var title string
+		if db, ok := s.databases[k]; ok {
+			obj, title = db, plainText(db["title"])
+		} else if page, ok := s.pages[k]; ok {
+			obj, title = page, pageTitle(page)
+		}
+		if obj == nil || obj["archived"] == true {
+			continue
+		}
+		if want != "" && obj["object"] != want {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(title), query) {
+			continue
+		}
+		results = append(results, obj)
+	}
+
+	ascending := false
+	if so, ok := req["sort"].(map[string]any); ok {
+		if ts, _ := so["timestamp"].(string); ts != "last_edited_time" {
+			return nil, validation("body failed validation: body.sort.timestamp should be `\"last_edited_time\"`, instead was `%q`.", ts)
+		}
+		ascending = so["direction"] == "ascending"
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		a, _ := results[i]["last_edited_time"].(string)
+		b, _ := results[j]["last_edited_time"].(string)
+		if ascending {
+			return a < b
+		}
+		return a > b
+	})
+
+	size, err := pageSize(req["page_size"])
+	if err != nil {
+		return nil, err
+	}
+	cursor, _ := req["start_cursor"].(string)
+	page, next, more, err := s.paginate(results, cursor, size)
+	if err != nil {
+		return nil, err
+	}
+	return list("page_or_database", clone(page), next, more), nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
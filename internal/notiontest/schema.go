@@ -0,0 +1,645 @@
+package notiontest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Property is the configuration of a database property in the form the API
+// uses, e.g. {"type": "select", "select": {"options": [...]}}
+type Property map[string]any
+
+// Schema maps property names to their configuration
+type Schema map[string]Property
+
+func property(kind string, config map[string]any) Property {
+	if config == nil {
+		config = map[string]any{}
+	}
+	return Property{"type": kind, kind: config}
+}
+
+func options(names []string) []any {
+	opts := make([]any, 0, len(names))
+	for _, name := range names {
+		opts = append(opts, map[string]any{"name": name, "color": "default"})
+	}
+	return opts
+}
+
+// Title returns a title property
+func Title() Property { return property("title", nil) }
+
+// Text returns a rich text property
+func Text() Property { return property("rich_text", nil) }
+
+// Number returns a number property
+func Number() Property { return property("number", map[string]any{"format": "number"}) }
+
+// Select returns a select property with the given options
+func Select(names ...string) Property {
+	return property("select", map[string]any{"options": options(names)})
+}
+
+// MultiSelect returns a multi-select property with the given options
+func MultiSelect(names ...string) Property {
+	return property("multi_select", map[string]any{"options": options(names)})
+}
+
+// Status returns a status property whose options sit in Notion's To-do, In
+// progress and Complete groups
+func Status(todo, inProgress, complete []string) Property {
+	var all []string
+	var groups []any
+	for _, g := range []struct {
+		name  string
+		names []string
+	}{{"To-do", todo}, {"In progress", inProgress}, {"Complete", complete}} {
+		all = append(all, g.names...)
+		groups = append(groups, map[string]any{"name": g.name, "color": "gray", "option_names": g.names})
+	}
+	return property("status", map[string]any{"options": options(all), "groups": groups})
+}
+
+// Date returns a date property
+func Date() Property { return property("date", nil) }
+
+// People returns a people property
+func People() Property { return property("people", nil) }
+
+// Checkbox returns a checkbox property
+func Checkbox() Property { return property("checkbox", nil) }
+
+// URL returns a URL property
+func URL() Property { return property("url", nil) }
+
+// Email returns an email property
+func Email() Property { return property("email", nil) }
+
+// Phone returns a phone number property
+func Phone() Property { return property("phone_number", nil) }
+
+// Relation returns a relation to a database. An empty ID relates the
+// database to itself.
+func Relation(databaseID string) Property {
+	return property("relation", map[string]any{
+		"database_id":     databaseID,
+		"type":            "single_property",
+		"single_property": map[string]any{},
+	})
+}
+
+// Formula returns a formula property
+func Formula(expression string) Property {
+	return property("formula", map[string]any{"expression": expression})
+}
+
+// Rollup returns a rollup of a property of the pages a relation links to
+func Rollup(relation, target, function string) Property {
+	return property("rollup", map[string]any{
+		"relation_property_name": relation,
+		"rollup_property_name":   target,
+		"function":               function,
+	})
+}
+
+// TasksSchema returns the tasks database schema documented in
+// docs/TASKS_SETUP.md
+func TasksSchema() Schema {
+	return Schema{
+		"Title":      Title(),
+		"Status":     Status([]string{"Todo"}, []string{"In Progress", "Blocked"}, []string{"Done"}),
+		"Priority":   Select("High", "Medium", "Low"),
+		"Due Date":   Date(),
+		"Category":   Select("Work", "Personal"),
+		"Tags":       MultiSelect("urgent", "review"),
+		"Notes":      Text(),
+		"Repeat":     Text(),
+		"Series":     Text(),
+		"Parent":     Relation(""),
+		"Blocked By": Relation(""),
+		"Assignee":   People(),
+	}
+}
+
+// EventsSchema returns the events database schema documented in
+// docs/EVENTS_SETUP.md
+func EventsSchema() Schema {
+	return Schema{
+		"Title":     Title(),
+		"Date":      Date(),
+		"Type":      Select("Work", "Personal", "Meeting", "Appointment"),
+		"Location":  Text(),
+		"Attendees": MultiSelect(),
+		"Status":    MultiSelect("Scheduled", "Completed", "Cancelled"),
+		"Notes":     Text(),
+		"Repeat":    Text(),
+		"Series":    Text(),
+		"UID":       Text(),
+	}
+}
+
+// PostsSchema returns the posts database schema the posts commands write
+func PostsSchema() Schema {
+	return Schema{
+		"Title":            Title(),
+		"Status":           Status([]string{"Idea"}, []string{"Outline", "Draft", "Review"}, []string{"Published", "Distributed"}),
+		"Pillar":           Select("Go Tools", "Infrastructure"),
+		"Week":             Number(),
+		"Publish Date":     Date(),
+		"Published Date":   Date(),
+		"Blog URL":         URL(),
+		"Distributed To":   MultiSelect("LinkedIn", "Twitter"),
+		"Distributed Date": Date(),
+		"LinkedIn Draft":   Text(),
+		"Twitter Thread":   Text(),
+		"HN Title":         Text(),
+		"Reddit Title":     Text(),
+		"Hashtags":         MultiSelect(),
+	}
+}
+
+// AddDatabase adds a database to the workspace and returns its ID
+func (s *Server) AddDatabase(title string, schema Schema) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	props := make(map[string]any, len(schema))
+	for name, p := range schema {
+		props[name] = map[string]any(clone(p))
+	}
+	db, err := s.newDatabase(map[string]any{"type": "workspace", "workspace": true}, richText(title), props)
+	if err != nil {
+		panic(fmt.Sprintf("notiontest: AddDatabase: %v", err))
+	}
+	return db["id"].(string)
+}
+
+// SetDescription gives a database a plain text description
+func (s *Server) SetDescription(id, text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k, _ := key(id)
+	db := s.databases[k]
+	if db == nil {
+		panic(fmt.Sprintf("notiontest: SetDescription: no database %s", id))
+	}
+	db["description"] = richText(text)
+}
+
+// newDatabase stores a database built from API-shaped properties
+func (s *Server) newDatabase(parent map[string]any, title []any, props map[string]any) (map[string]any, *apiError) {
+	id := s.newID()
+	titles := 0
+	configs := make(map[string]any, len(props))
+	for name, raw := range props {
+		p, ok := raw.(map[string]any)
+		if !ok {
+			return nil, validation("body failed validation: body.properties.%s should be an object.", name)
+		}
+		cfg, err := s.newConfig(id, name, p)
+		if err != nil {
+			return nil, err
+		}
+		if cfg["type"] == "title" {
+			titles++
+		}
+		configs[name] = cfg
+	}
+	if titles != 1 {
+		return nil, validation("Databases must have exactly one title property.")
+	}
+
+	now := s.now()
+	k, _ := key(id)
+	db := map[string]any{
+		"object":           "database",
+		"id":               id,
+		"created_time":     now,
+		"last_edited_time": now,
+		"created_by":       map[string]any{"object": "user", "id": s.bot["id"]},
+		"last_edited_by":   map[string]any{"object": "user", "id": s.bot["id"]},
+		"title":            title,
+		"description":      []any{},
+		"icon":             nil,
+		"parent":           parent,
+		"url":              "https://www.notion.so/" + k,
+		"archived":         false,
+		"is_inline":        false,
+		"properties":       configs,
+	}
+	s.databases[k] = db
+	s.order = append(s.order, k)
+	return db, nil
+}
+
+// propertyTypes lists the property types the fake knows, with whether pages
+// can write them
+var propertyTypes = map[string]bool{
+	"title": true, "rich_text": true, "number": true, "select": true,
+	"multi_select": true, "status": true, "date": true, "people": true,
+	"files": true, "checkbox": true, "url": true, "email": true,
+	"phone_number": true, "relation": true, "formula": false,
+	"rollup": false, "created_time": false, "created_by": false,
+	"last_edited_time": false, "last_edited_by": false,
+}
+
+// configType returns the type key of a property configuration
+func configType(p map[string]any) (string, bool) {
+	if t, ok := p["type"].(string); ok {
+		_, known := propertyTypes[t]
+		return t, known
+	}
+	for t := range propertyTypes {
+		if _, ok := p[t]; ok {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// newConfig normalizes a property configuration, assigning IDs to the
+// property and its options
+func (s *Server) newConfig(databaseID, name string, p map[string]any) (map[string]any, *apiError) {
+	t, ok := configType(p)
+	if !ok {
+		return nil, validation("body failed validation: body.properties.%s should be a known property type.", name)
+	}
+	body, _ := p[t].(map[string]any)
+	body = clone(body)
+	if body == nil {
+		body = map[string]any{}
+	}
+
+	switch t {
+	case "select", "multi_select":
+		opts, err := s.newOptions(name, body["options"])
+		if err != nil {
+			return nil, err
+		}
+		body["options"] = opts
+	case "status":
+		if body["options"] == nil && body["groups"] == nil {
+			// Notion gives a status property created through the API its
+			// default options, since it can't take any
+			body = clone(Status([]string{"Not started"}, []string{"In progress"}, []string{"Done"})["status"].(map[string]any))
+		}
+		opts, err := s.newOptions(name, body["options"])
+		if err != nil {
+			return nil, err
+		}
+		body["options"] = opts
+		groups, _ := body["groups"].([]any)
+		for _, g := range groups {
+			group := g.(map[string]any)
+			if group["id"] == nil {
+				group["id"] = s.newID()
+			}
+			ids := []any{}
+			names := stringList(group["option_names"])
+			for _, n := range names {
+				for _, o := range opts {
+					if o.(map[string]any)["name"] == n {
+						ids = append(ids, o.(map[string]any)["id"])
+					}
+				}
+			}
+			if existing, ok := group["option_ids"].([]any); ok && len(names) == 0 {
+				ids = existing
+			}
+			delete(group, "option_names")
+			group["option_ids"] = ids
+		}
+		if groups == nil {
+			groups = []any{}
+		}
+		body["groups"] = groups
+	case "relation":
+		if body["database_id"] == nil || body["database_id"] == "" {
+			body["database_id"] = databaseID
+		}
+	}
+
+	id := fmt.Sprintf("p%d", s.seq)
+	s.seq++
+	if t == "title" {
+		id = "title"
+	}
+	return map[string]any{"id": id, "name": name, "type": t, t: body}, nil
+}
+
+// stringList reads a list of strings from a decoded or literal JSON value
+func stringList(v any) []string {
+	switch l := v.(type) {
+	case []string:
+		return l
+	case []any:
+		out := make([]string, 0, len(l))
+		for _, item := range l {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// newOptions assigns IDs and colors to select options
+func (s *Server) newOptions(name string, raw any) ([]any, *apiError) {
+	in, _ := raw.([]any)
+	opts := make([]any, 0, len(in))
+	seen := map[string]bool{}
+	for _, o := range in {
+		opt, ok := o.(map[string]any)
+		if !ok {
+			return nil, validation("body failed validation: body.properties.%s.options should be a list of objects.", name)
+		}
+		opt = clone(opt)
+		n, _ := opt["name"].(string)
+		if strings.Contains(n, ",") {
+			return nil, validation("Invalid select option, commas not allowed: %s", n)
+		}
+		if seen[n] {
+			return nil, validation("Duplicate option name %q in %s.", n, name)
+		}
+		seen[n] = true
+		if opt["id"] == nil {
+			opt["id"] = s.newID()
+		}
+		if opt["color"] == nil {
+			opt["color"] = "default"
+		}
+		opts = append(opts, opt)
+	}
+	return opts, nil
+}
+
+func (s *Server) database(id string) (map[string]any, *apiError) {
+	k, ok := key(id)
+	if !ok {
+		return nil, invalidID("database", id)
+	}
+	db, ok := s.databases[k]
+	if !ok {
+		return nil, notFound(id)
+	}
+	return db, nil
+}
+
+func (s *Server) getDatabase(id string) (any, *apiError) {
+	db, err := s.database(id)
+	if err != nil {
+		return nil, err
+	}
+	return clone(db), nil
+}
+
+func (s *Server) createDatabase(req map[string]any) (any, *apiError) {
+	parent, _ := req["parent"].(map[string]any)
+	pageID, _ := parent["page_id"].(string)
+	if pageID == "" {
+		return nil, validation("body failed validation: body.parent.page_id should be defined.")
+	}
+	if _, err := s.page(pageID); err != nil {
+		return nil, err
+	}
+	props, _ := req["properties"].(map[string]any)
+	title, err := checkRichText("title", req["title"])
+	if err != nil {
+		return nil, err
+	}
+	db, err := s.newDatabase(map[string]any{"type": "page_id", "page_id": pageID}, title, props)
+	if err != nil {
+		return nil, err
+	}
+	if icon, ok := req["icon"]; ok {
+		db["icon"] = icon
+	}
+	return clone(db), nil
+}
+
+// updateDatabase changes the title, description, icon and properties of a
+// database. Properties set to null are removed, ones with a "name" are
+// renamed, and ones with a type are added or reconfigured; select options
+// not listed are dropped.
+func (s *Server) updateDatabase(id string, req map[string]any) (any, *apiError) {
+	db, err := s.database(id)
+	if err != nil {
+		return nil, err
+	}
+	if title, ok := req["title"]; ok {
+		rt, err := checkRichText("title", title)
+		if err != nil {
+			return nil, err
+		}
+		db["title"] = rt
+	}
+	if description, ok := req["description"]; ok {
+		rt, err := checkRichText("description", description)
+		if err != nil {
+			return nil, err
+		}
+		db["description"] = rt
+	}
+	if icon, ok := req["icon"]; ok {
+		db["icon"] = icon
+	}
+
+	configs := db["properties"].(map[string]any)
+	props, _ := req["properties"].(map[string]any)
+	for name, raw := range props {
+		cfg, exists := configs[name].(map[string]any)
+		if !exists {
+			for _, c := range configs {
+				if c.(map[string]any)["id"] == name {
+					cfg, exists = c.(map[string]any), true
+					name = cfg["name"].(string)
+				}
+			}
+		}
+
+		if raw == nil {
+			if !exists {
+				return nil, validation("Could not find property with name or id: %s", name)
+			}
+			if cfg["type"] == "title" {
+				return nil, validation("Cannot delete the title property.")
+			}
+			delete(configs, name)
+			s.renameValues(db["id"].(string), name, "")
+			continue
+		}
+
+		p, ok := raw.(map[string]any)
+		if !ok {
+			return nil, validation("body failed validation: body.properties.%s should be an object or null.", name)
+		}
+		if newName, ok := p["name"].(string); ok && exists && newName != name {
+			if _, taken := configs[newName]; taken {
+				return nil, validation("A property named %s already exists.", newName)
+			}
+			delete(configs, name)
+			cfg["name"] = newName
+			configs[newName] = cfg
+			s.renameValues(db["id"].(string), name, newName)
+			name = newName
+		}
+
+		t, hasType := configType(p)
+		if !hasType {
+			if !exists {
+				return nil, validation("body failed validation: body.properties.%s should define a property type.", name)
+			}
+			continue
+		}
+		if exists && cfg["type"] == "status" && t == "status" {
+			if _, ok := p["status"]; ok {
+				return nil, validation("Cannot update the options of a status property through the API.")
+			}
+		}
+		if exists && cfg["type"] == t && (t == "select" || t == "multi_select") {
+			opts, err := s.mergeOptions(name, cfg[t].(map[string]any)["options"], p[t])
+			if err != nil {
+				return nil, err
+			}
+			cfg[t] = map[string]any{"options": opts}
+			s.dropOptions(db["id"].(string), name, opts)
+			continue
+		}
+		if exists && cfg["type"] == "title" && t != "title" {
+			return nil, validation("Cannot change the type of the title property.")
+		}
+		if !exists && t == "title" {
+			return nil, validation("Databases must have exactly one title property.")
+		}
+		next, err := s.newConfig(db["id"].(string), name, p)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			next["id"] = cfg["id"]
+		}
+		configs[name] = next
+		s.resetValues(db["id"].(string), name, next)
+	}
+
+	db["last_edited_time"] = s.now()
+	return clone(db), nil
+}
+
+// mergeOptions replaces the options of a select property, keeping the IDs of
+// options that remain
+func (s *Server) mergeOptions(name string, current, update any) ([]any, *apiError) {
+	body, _ := update.(map[string]any)
+	if body == nil || body["options"] == nil {
+		out, _ := current.([]any)
+		return out, nil
+	}
+	existing := map[string]map[string]any{}
+	for _, o := range current.([]any) {
+		opt := o.(map[string]any)
+		existing[opt["name"].(string)] = opt
+	}
+	var in []any
+	for _, o := range body["options"].([]any) {
+		opt, _ := o.(map[string]any)
+		if old, ok := existing[fmt.Sprint(opt["name"])]; ok && opt["id"] == nil {
+			opt["id"] = old["id"]
+		}
+		in = append(in, opt)
+	}
+	return s.newOptions(name, in)
+}
+
+// dropOptions clears options that no longer exist from every page of a
+// database, as Notion does when an option is deleted
+func (s *Server) dropOptions(databaseID, name string, opts []any) {
+	kept := map[string]bool{}
+	for _, o := range opts {
+		kept[fmt.Sprint(o.(map[string]any)["name"])] = true
+	}
+	for _, page := range s.pages {
+		if !inDatabase(page, databaseID) {
+			continue
+		}
+		v, _ := page["properties"].(map[string]any)[name].(map[string]any)
+		if value, ok := v["select"].(map[string]any); ok && !kept[fmt.Sprint(value["name"])] {
+			v["select"] = nil
+		}
+		if values, ok := v["multi_select"].([]any); ok {
+			remaining := []any{}
+			for _, o := range values {
+				if kept[fmt.Sprint(o.(map[string]any)["name"])] {
+					remaining = append(remaining, o)
+				}
+			}
+			v["multi_select"] = remaining
+		}
+	}
+}
+
+// renameValues moves or, with an empty new name, removes a property on
+// every page of a database
+func (s *Server) renameValues(databaseID, old, name string) {
+	for _, page := range s.pages {
+		if !inDatabase(page, databaseID) {
+			continue
+		}
+		props := page["properties"].(map[string]any)
+		if v, ok := props[old]; ok && name != "" {
+			props[name] = v
+		}
+		delete(props, old)
+	}
+}
+
+// resetValues gives every page of a database the empty value of a new or
+// retyped property
+func (s *Server) resetValues(databaseID, name string, cfg map[string]any) {
+	for _, page := range s.pages {
+		if !inDatabase(page, databaseID) {
+			continue
+		}
+		props := page["properties"].(map[string]any)
+		if old, ok := props[name].(map[string]any); ok && old["type"] == cfg["type"] {
+			continue
+		}
+		props[name] = s.emptyValue(cfg, page)
+	}
+}
+
+func inDatabase(page map[string]any, databaseID string) bool {
+	parent, _ := page["parent"].(map[string]any)
+	a, _ := key(fmt.Sprint(parent["database_id"]))
+	b, _ := key(databaseID)
+	return a != "" && a == b
+}
+
+// schemaOf returns the property configurations of the database a page
+// belongs to, or nil for pages outside databases
+func (s *Server) schemaOf(page map[string]any) map[string]any {
+	parent, _ := page["parent"].(map[string]any)
+	id, _ := parent["database_id"].(string)
+	if id == "" {
+		return nil
+	}
+	k, _ := key(id)
+	if db, ok := s.databases[k]; ok {
+		return db["properties"].(map[string]any)
+	}
+	return nil
+}
+
+// lookupConfig finds a property configuration by name or ID
+func lookupConfig(configs map[string]any, nameOrID string) (string, map[string]any, bool) {
+	if cfg, ok := configs[nameOrID].(map[string]any); ok {
+		return nameOrID, cfg, true
+	}
+	for name, c := range configs {
+		cfg := c.(map[string]any)
+		if cfg["id"] == nameOrID {
+			return name, cfg, true
+		}
+	}
+	return "", nil, false
+}
@@ -0,0 +1,363 @@
+// Package notiontest provides an in-process fake of the Notion API for tests.
+// It keeps databases, pages, blocks and users in memory, checks property
+// values and filters against database schemas the way Notion does, and
+// paginates and fails like the real service, so the client can be exercised
+// end to end without network access.
+package notiontest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Version is the Notion-Version header value the fake answers to
+const Version = "2022-06-28"
+
+// Request is a request the fake has received
+type Request struct {
+	Method string
+	Host   string
+	// Path is the part of the URL after /v1/
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Server is a fake Notion API. The zero value is not usable; create one with
+// NewServer.
+type Server struct {
+	// Token is the integration token requests have to carry. An empty
+	// token accepts any.
+	Token string
+	// MaxPageSize caps the number of results in one response, like
+	// Notion's limit of 100. Lower it to exercise pagination with few items.
+	MaxPageSize int
+
+	mu        sync.Mutex
+	seq       int
+	last      time.Time
+	databases map[string]map[string]any
+	pages     map[string]map[string]any
+	blocks    map[string]map[string]any
+	children  map[string][]string
+	order     []string
+	users     []map[string]any
+	bot       map[string]any
+	failures  []failure
+	requests  []Request
+}
+
+type failure struct {
+	method string
+	path   string
+	err    *apiError
+}
+
+// apiError is an error response in Notion's format
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *apiError) Error() string { return e.message }
+
+func errorf(status int, code, format string, args ...any) *apiError {
+	return &apiError{status: status, code: code, message: fmt.Sprintf(format, args...)}
+}
+
+func notFound(id string) *apiError {
+	return errorf(http.StatusNotFound, "object_not_found",
+		"Could not find object with ID: %s. Make sure the relevant pages and databases are shared with your integration.", id)
+}
+
+func validation(format string, args ...any) *apiError {
+	return errorf(http.StatusBadRequest, "validation_error", format, args...)
+}
+
+// NewServer returns an empty fake with an integration bot user
+func NewServer() *Server {
+	s := &Server{
+		MaxPageSize: 100,
+		databases:   make(map[string]map[string]any),
+		pages:       make(map[string]map[string]any),
+		blocks:      make(map[string]map[string]any),
+		children:    make(map[string][]string),
+	}
+	s.bot = map[string]any{
+		"object":     "user",
+		"id":         s.newID(),
+		"type":       "bot",
+		"name":       "notion-cli",
+		"avatar_url": nil,
+		"bot": map[string]any{
+			"owner":          map[string]any{"type": "workspace", "workspace": true},
+			"workspace_name": "Test Workspace",
+		},
+	}
+	s.users = append(s.users, s.bot)
+	return s
+}
+
+// HTTPClient returns an HTTP client that hands every request to the fake
+// in-process, whatever host it is addressed to
+func (s *Server) HTTPClient() *http.Client {
+	return &http.Client{Transport: transport{s}}
+}
+
+type transport struct{ h http.Handler }
+
+func (t transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	t.h.ServeHTTP(rec, r)
+	resp := rec.Result()
+	resp.Request = r
+	return resp, nil
+}
+
+// Requests returns the requests received so far, oldest first
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// FailNext makes the next request matching method and path fail with the
+// given status and error code. Path is matched as a prefix of the part of
+// the URL after /v1/, e.g. "pages" or "databases"; an empty method matches
+// any. Rate limit responses carry a Retry-After of zero seconds.
+func (s *Server) FailNext(method, path string, status int, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	message := map[string]string{
+		"rate_limited":          "You have been rate limited. Please try again in a few minutes.",
+		"internal_server_error": "Unexpected error occurred.",
+		"service_unavailable":   "Notion is unavailable, please try again later.",
+		"conflict_error":        "Conflict occurred while saving. Please try again.",
+		"unauthorized":          "API token is invalid.",
+		"restricted_resource":   "API token does not have access to this resource.",
+	}[code]
+	if message == "" {
+		message = code
+	}
+	s.failures = append(s.failures, failure{method: method, path: path, err: errorf(status, code, "%s", message)})
+}
+
+// ServeHTTP answers a Notion API request
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Requests handed to the transport directly, rather than through an
+	// http.Server, have no body when there is nothing to send
+	var body []byte
+	if r.Body != nil {
+		body, _ = io.ReadAll(r.Body)
+	}
+
+	s.mu.Lock()
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	s.requests = append(s.requests, Request{Method: r.Method, Host: r.URL.Host, Path: path, Query: r.URL.Query(), Header: r.Header, Body: body})
+	result, err := s.serve(r, path, body)
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		if err.status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(err.status)
+		json.NewEncoder(w).Encode(map[string]any{
+			"object":  "error",
+			"status":  err.status,
+			"code":    err.code,
+			"message": err.message,
+		})
+		return
+	}
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *Server) serve(r *http.Request, path string, body []byte) (any, *apiError) {
+	for i, f := range s.failures {
+		if (f.method == "" || f.method == r.Method) && strings.HasPrefix(path, f.path) {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			return nil, f.err
+		}
+	}
+
+	if s.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.Token {
+		return nil, errorf(http.StatusUnauthorized, "unauthorized", "API token is invalid.")
+	}
+	if r.Header.Get("Notion-Version") == "" {
+		return nil, errorf(http.StatusBadRequest, "missing_version", "Notion-Version header failed validation: Notion-Version header should be defined, instead was `undefined`.")
+	}
+
+	var req map[string]any
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &req); err != nil {
+			return nil, errorf(http.StatusBadRequest, "invalid_json", "Error parsing JSON body.")
+		}
+	}
+	if req == nil {
+		req = map[string]any{}
+	}
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	route := r.Method + " " + parts[0]
+	switch {
+	case len(parts) == 1 && route == "POST pages":
+		return s.createPage(req)
+	case len(parts) == 2 && route == "GET pages":
+		return s.getPage(parts[1])
+	case len(parts) == 2 && route == "PATCH pages":
+		return s.updatePage(parts[1], req)
+	case len(parts) == 1 && route == "POST databases":
+		return s.createDatabase(req)
+	case len(parts) == 2 && route == "GET databases":
+		return s.getDatabase(parts[1])
+	case len(parts) == 2 && route == "PATCH databases":
+		return s.updateDatabase(parts[1], req)
+	case len(parts) == 3 && route == "POST databases" && parts[2] == "query":
+		return s.queryDatabase(parts[1], req)
+	case len(parts) == 2 && route == "GET blocks":
+		return s.getBlock(parts[1])
+	case len(parts) == 2 && route == "PATCH blocks":
+		return s.updateBlock(parts[1], req)
+	case len(parts) == 2 && route == "DELETE blocks":
+		return s.deleteBlock(parts[1])
+	case len(parts) == 3 && route == "GET blocks" && parts[2] == "children":
+		return s.listChildren(parts[1], r.URL.Query())
+	case len(parts) == 3 && route == "PATCH blocks" && parts[2] == "children":
+		return s.appendChildren(parts[1], req)
+	case len(parts) == 1 && route == "POST search":
+		return s.search(req)
+	case len(parts) == 1 && route == "GET users":
+		return s.listUsers(r.URL.Query())
+	case len(parts) == 2 && route == "GET users":
+		return s.getUser(parts[1])
+	}
+	return nil, errorf(http.StatusBadRequest, "invalid_request_url", "Invalid request URL.")
+}
+
+// newID returns a fresh object ID in Notion's dashed UUID form
+func (s *Server) newID() string {
+	s.seq++
+	return fmt.Sprintf("%08x-0000-4000-8000-%012x", s.seq>>16, s.seq)
+}
+
+// now returns the current time, strictly later than any earlier call so
+// that ordering by timestamps is stable
+func (s *Server) now() string {
+	t := time.Now().UTC().Truncate(time.Millisecond)
+	if !t.After(s.last) {
+		t = s.last.Add(time.Millisecond)
+	}
+	s.last = t
+	return t.Format("2006-01-02T15:04:05.000Z")
+}
+
+// key turns an ID given with or without dashes into a map key. ok is false
+// when it isn't a UUID at all.
+func key(id string) (string, bool) {
+	k := strings.ToLower(strings.ReplaceAll(id, "-", ""))
+	if len(k) != 32 {
+		return "", false
+	}
+	for _, c := range k {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return "", false
+		}
+	}
+	return k, true
+}
+
+// invalidID is the error for a malformed ID in a path
+func invalidID(kind, id string) *apiError {
+	return validation("path failed validation: path.%s_id should be a valid uuid, instead was `%q`.", kind, id)
+}
+
+// paginate slices items for one response. The cursor is the ID of the
+// first item of the page it starts.
+func (s *Server) paginate(items []map[string]any, cursor string, size int) ([]map[string]any, any, bool, *apiError) {
+	switch {
+	case size < 0:
+		return nil, nil, false, validation("body failed validation: body.page_size should be a positive number, instead was `%d`.", size)
+	case size > 100:
+		return nil, nil, false, validation("body failed validation: body.page_size should be less than or equal to `100`, instead was `%d`.", size)
+	case size == 0:
+		size = 100
+	}
+	if s.MaxPageSize > 0 && size > s.MaxPageSize {
+		size = s.MaxPageSize
+	}
+
+	start := 0
+	if cursor != "" {
+		start = -1
+		for i, item := range items {
+			if item["id"] == cursor {
+				start = i
+				break
+			}
+		}
+		if start < 0 {
+			return nil, nil, false, validation("start_cursor provided is invalid: %s", cursor)
+		}
+	}
+
+	end := start + size
+	if end >= len(items) {
+		return items[start:], nil, false, nil
+	}
+	return items[start:end], items[end]["id"], true, nil
+}
+
+// list wraps results in Notion's list object
+func list(kind string, results []map[string]any, next any, more bool) map[string]any {
+	if results == nil {
+		results = []map[string]any{}
+	}
+	return map[string]any{
+		"object":      "list",
+		"results":     results,
+		"next_cursor": next,
+		"has_more":    more,
+		"type":        kind,
+		kind:          map[string]any{},
+	}
+}
+
+// pageSize reads an integer page_size from a request body or query
+func pageSize(v any) (int, *apiError) {
+	switch n := v.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return int(n), nil
+	case string:
+		if n == "" {
+			return 0, nil
+		}
+		var size int
+		if _, err := fmt.Sscanf(n, "%d", &size); err != nil {
+			return 0, validation("page_size should be a number, instead was `%q`.", n)
+		}
+		return size, nil
+	}
+	return 0, validation("body failed validation: body.page_size should be a number.")
+}
+
+// clone deep-copies a JSON value so callers can't change stored state
+func clone[T any](v T) T {
+	data, _ := json.Marshal(v)
+	var out T
+	json.Unmarshal(data, &out)
+	return out
+}
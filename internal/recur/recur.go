@@ -0,0 +1,449 @@
+// Package recur implements the subset of RFC 5545 recurrence rules used for
+// repeating events and tasks.
+//
+// Supported parts are FREQ (DAILY, WEEKLY, MONTHLY, YEARLY), INTERVAL, COUNT,
+// UNTIL, BYDAY (with ordinals such as 1MO or -1FR for monthly and yearly
+// rules), BYMONTHDAY and BYMONTH. Weeks start on Monday.
+//
+// As an extension, skipped occurrences can be listed in the same string with
+// EXDATE, e.g. "FREQ=WEEKLY;BYDAY=MO;EXDATE=20261026,20261102". Exceptions
+// match on the calendar day of the occurrence.
+package recur
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the base period of a rule.
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// maxPeriods caps how many periods are scanned, so a rule whose filters never
+// match cannot loop forever.
+const maxPeriods = 10000
+
+// WeekdayNum is a BYDAY entry. N is the ordinal within the month or year
+// (1 = first, -1 = last) and zero means every such weekday.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// Rule is a parsed recurrence rule.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []int
+	Exdates    []string // YYYY-MM-DD
+}
+
+var dayCodes = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// Parse parses a rule such as "FREQ=WEEKLY;BYDAY=MO,WE". A leading "RRULE:"
+// is accepted.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "RRULE:"), "rrule:")
+	if s == "" {
+		return nil, fmt.Errorf("empty recurrence rule")
+	}
+
+	r := &Rule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "FREQ":
+			switch f := Frequency(strings.ToUpper(value)); f {
+			case Daily, Weekly, Monthly, Yearly:
+				r.Freq = f
+			default:
+				return nil, fmt.Errorf("unsupported FREQ %q", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %q", value)
+			}
+			r.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid COUNT %q", value)
+			}
+			r.Count = n
+		case "UNTIL":
+			t, err := parseRuleDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid UNTIL %q", value)
+			}
+			r.Until = t
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				wn, err := parseWeekdayNum(code)
+				if err != nil {
+					return nil, err
+				}
+				r.ByDay = append(r.ByDay, wn)
+			}
+		case "BYMONTHDAY":
+			for _, v := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(v)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("invalid BYMONTHDAY %q", v)
+				}
+				r.ByMonthDay = append(r.ByMonthDay, n)
+			}
+		case "BYMONTH":
+			for _, v := range strings.Split(value, ",") {
+				n, err := strconv.Atoi(v)
+				if err != nil || n < 1 || n > 12 {
+					return nil, fmt.Errorf("invalid BYMONTH %q", v)
+				}
+				r.ByMonth = append(r.ByMonth, n)
+			}
+		case "EXDATE":
+			for _, v := range strings.Split(value, ",") {
+				t, err := parseRuleDate(v)
+				if err != nil {
+					return nil, fmt.Errorf("invalid EXDATE %q", v)
+				}
+				r.Exdates = append(r.Exdates, t.Format("2006-01-02"))
+			}
+		case "WKST":
+			if strings.ToUpper(value) != "MO" {
+				return nil, fmt.Errorf("only WKST=MO is supported")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported recurrence rule part %q", key)
+		}
+	}
+
+	if r.Freq == "" {
+		return nil, fmt.Errorf("recurrence rule needs a FREQ")
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return nil, fmt.Errorf("COUNT and UNTIL cannot be combined")
+	}
+	return r, nil
+}
+
+// Skip returns a copy of the rule with an exception added for day.
+func (r *Rule) Skip(day time.Time) *Rule {
+	out := *r
+	out.Exdates = append(append([]string(nil), r.Exdates...), day.Format("2006-01-02"))
+	sort.Strings(out.Exdates)
+	return &out
+}
+
+// String formats the rule back into its textual form.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, 0, len(r.ByDay))
+		for _, wn := range r.ByDay {
+			code := strings.ToUpper(wn.Weekday.String()[:2])
+			if wn.N != 0 {
+				code = strconv.Itoa(wn.N) + code
+			}
+			codes = append(codes, code)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByMonth) > 0 {
+		parts = append(parts, "BYMONTH="+joinInts(r.ByMonth))
+	}
+	if len(r.Exdates) > 0 {
+		days := make([]string, 0, len(r.Exdates))
+		for _, d := range r.Exdates {
+			days = append(days, strings.ReplaceAll(d, "-", ""))
+		}
+		parts = append(parts, "EXDATE="+strings.Join(days, ","))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Between returns the occurrences of a series anchored at dtstart that fall
+// in [from, to). dtstart supplies the time of day and is an occurrence itself
+// when it matches the rule. Skipped dates are left out but still count
+// towards COUNT.
+func (r *Rule) Between(dtstart, from, to time.Time) []time.Time {
+	var out []time.Time
+	r.iterate(dtstart, func(t time.Time) bool {
+		if !t.Before(to) {
+			return false
+		}
+		if !t.Before(from) && !r.skipped(t) {
+			out = append(out, t)
+		}
+		return true
+	})
+	return out
+}
+
+// Next returns the first occurrence strictly after the given time.
+func (r *Rule) Next(dtstart, after time.Time) (time.Time, bool) {
+	var next time.Time
+	r.iterate(dtstart, func(t time.Time) bool {
+		if t.After(after) && !r.skipped(t) {
+			next = t
+			return false
+		}
+		return true
+	})
+	return next, !next.IsZero()
+}
+
+// iterate calls fn for each occurrence in order until fn returns false or
+// the series ends.
+func (r *Rule) iterate(dtstart time.Time, fn func(time.Time) bool) {
+	emitted := 0
+	for period := 0; period < maxPeriods; period++ {
+		for _, t := range r.candidates(dtstart, period) {
+			if t.Before(dtstart) {
+				continue
+			}
+			if !r.Until.IsZero() && t.After(r.untilIn(t.Location())) {
+				return
+			}
+			emitted++
+			if !fn(t) {
+				return
+			}
+			if r.Count > 0 && emitted >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+// candidates returns the sorted occurrences of the given period, before the
+// dtstart check.
+func (r *Rule) candidates(dtstart time.Time, period int) []time.Time {
+	loc := dtstart.Location()
+	h, m, sec := dtstart.Clock()
+	at := func(y int, mo time.Month, d int) time.Time {
+		return time.Date(y, mo, d, h, m, sec, 0, loc)
+	}
+	step := period * r.Interval
+
+	var out []time.Time
+	switch r.Freq {
+	case Daily:
+		t := at(dtstart.Year(), dtstart.Month(), dtstart.Day()+step)
+		if r.matchesDay(t) && r.matchesMonth(t) && r.matchesMonthDay(t) {
+			out = append(out, t)
+		}
+	case Weekly:
+		offset := (int(dtstart.Weekday()) + 6) % 7
+		monday := at(dtstart.Year(), dtstart.Month(), dtstart.Day()-offset+7*step)
+		for i := 0; i < 7; i++ {
+			t := at(monday.Year(), monday.Month(), monday.Day()+i)
+			if len(r.ByDay) == 0 {
+				if t.Weekday() != dtstart.Weekday() {
+					continue
+				}
+			} else if !r.matchesDay(t) {
+				continue
+			}
+			if r.matchesMonth(t) {
+				out = append(out, t)
+			}
+		}
+	case Monthly:
+		first := at(dtstart.Year(), dtstart.Month()+time.Month(step), 1)
+		if r.matchesMonth(first) {
+			out = r.monthDays(first, dtstart, at)
+		}
+	case Yearly:
+		year := dtstart.Year() + step
+		months := r.ByMonth
+		if len(months) == 0 {
+			months = []int{int(dtstart.Month())}
+		}
+		for _, mo := range months {
+			out = append(out, r.monthDays(at(year, time.Month(mo), 1), dtstart, at)...)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	return out
+}
+
+// monthDays expands one month of a monthly or yearly rule.
+func (r *Rule) monthDays(first, dtstart time.Time, at func(int, time.Month, int) time.Time) []time.Time {
+	year, month := first.Year(), first.Month()
+	last := at(year, month+1, 0).Day()
+
+	var out []time.Time
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, d := range r.ByMonthDay {
+			if d < 0 {
+				d = last + d + 1
+			}
+			if d >= 1 && d <= last {
+				t := at(year, month, d)
+				if len(r.ByDay) == 0 || r.matchesDay(t) {
+					out = append(out, t)
+				}
+			}
+		}
+	case len(r.ByDay) > 0:
+		for _, wn := range r.ByDay {
+			var days []int
+			for d := 1; d <= last; d++ {
+				if at(year, month, d).Weekday() == wn.Weekday {
+					days = append(days, d)
+				}
+			}
+			switch {
+			case wn.N == 0:
+				for _, d := range days {
+					out = append(out, at(year, month, d))
+				}
+			case wn.N > 0 && wn.N <= len(days):
+				out = append(out, at(year, month, days[wn.N-1]))
+			case wn.N < 0 && -wn.N <= len(days):
+				out = append(out, at(year, month, days[len(days)+wn.N]))
+			}
+		}
+	default:
+		if dtstart.Day() <= last {
+			out = append(out, at(year, month, dtstart.Day()))
+		}
+	}
+	return out
+}
+
+func (r *Rule) matchesDay(t time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, wn := range r.ByDay {
+		if wn.Weekday == t.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchesMonth(t time.Time) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, m := range r.ByMonth {
+		if time.Month(m) == t.Month() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchesMonthDay(t time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	last := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	for _, d := range r.ByMonthDay {
+		if d == t.Day() || (d < 0 && last+d+1 == t.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) skipped(t time.Time) bool {
+	day := t.Format("2006-01-02")
+	for _, d := range r.Exdates {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// untilIn interprets a date-only UNTIL as the end of that day in loc.
+func (r *Rule) untilIn(loc *time.Location) time.Time {
+	u := r.Until
+	if u.Hour() == 0 && u.Minute() == 0 && u.Second() == 0 && u.Location() == time.UTC {
+		return time.Date(u.Year(), u.Month(), u.Day(), 23, 59, 59, 0, loc)
+	}
+	return u
+}
+
+func parseWeekdayNum(code string) (WeekdayNum, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) < 2 {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", code)
+	}
+	wd, ok := dayCodes[code[len(code)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", code)
+	}
+	wn := WeekdayNum{Weekday: wd}
+	if prefix := code[:len(code)-2]; prefix != "" {
+		n, err := strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -53 || n > 53 {
+			return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", code)
+		}
+		wn.N = n
+	}
+	return wn, nil
+}
+
+// parseRuleDate accepts the iCalendar forms 20261020 and 20261020T090000Z as
+// well as 2026-10-20.
+func parseRuleDate(s string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+func joinInts(ns []int) string {
+	parts := make([]string, 0, len(ns))
+	for _, n := range ns {
+		parts = append(parts, strconv.Itoa(n))
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,157 @@
+package recur
+
+import (
+	"testing"
+	"time"
+)
+
+// A Monday
+var dtstart = time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+func at(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 9, 0, 0, 0, time.UTC) }
+
+func TestParseErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"RRULE:",
+		"INTERVAL=2",
+		"FREQ",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20261101",
+		"FREQ=DAILY;UNTIL=soon",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=MONTHLY;BYDAY=0MO",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=YEARLY;BYMONTH=13",
+		"FREQ=WEEKLY;EXDATE=20261301",
+		"FREQ=DAILY;WKST=SU",
+		"FREQ=DAILY;BYSETPOS=1",
+	} {
+		if r, err := Parse(s); err == nil {
+			t.Errorf("Parse(%q) = %v, want an error", s, r)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{"RRULE:freq=daily", "FREQ=DAILY"},
+		{"FREQ=DAILY;INTERVAL=1", "FREQ=DAILY"},
+		{"FREQ=MONTHLY;INTERVAL=2;BYDAY=1MO,-1FR", "FREQ=MONTHLY;INTERVAL=2;BYDAY=1MO,-1FR"},
+		{"FREQ=WEEKLY;BYDAY=mo,we;COUNT=4;EXDATE=2026-10-26", "FREQ=WEEKLY;COUNT=4;BYDAY=MO,WE;EXDATE=20261026"},
+		{"FREQ=YEARLY;BYMONTH=1,7;BYMONTHDAY=1;UNTIL=20271231T000000Z", "FREQ=YEARLY;UNTIL=20271231;BYMONTHDAY=1;BYMONTH=1,7"},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			r, err := Parse(tc.in)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tc.in, err)
+			}
+			if got := r.String(); got != tc.want {
+				t.Errorf("String() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	for _, tc := range []struct {
+		rule string
+		to   time.Time
+		want []time.Time
+	}{
+		{"FREQ=DAILY;COUNT=3", at(2026, 12, 1), []time.Time{at(2026, 10, 19), at(2026, 10, 20), at(2026, 10, 21)}},
+		{"FREQ=DAILY;UNTIL=20261021", at(2026, 12, 1), []time.Time{at(2026, 10, 19), at(2026, 10, 20), at(2026, 10, 21)}},
+		{"FREQ=DAILY;BYDAY=SA,SU", at(2026, 10, 26), []time.Time{at(2026, 10, 24), at(2026, 10, 25)}},
+		{"FREQ=WEEKLY;BYDAY=MO,WE", at(2026, 11, 2), []time.Time{at(2026, 10, 19), at(2026, 10, 21), at(2026, 10, 26), at(2026, 10, 28)}},
+		{"FREQ=WEEKLY;INTERVAL=2", at(2026, 11, 20), []time.Time{at(2026, 10, 19), at(2026, 11, 2), at(2026, 11, 16)}},
+		{"FREQ=WEEKLY;BYDAY=MO;COUNT=3;EXDATE=20261026", at(2026, 12, 31), []time.Time{at(2026, 10, 19), at(2026, 11, 2)}},
+		{"FREQ=MONTHLY", at(2027, 1, 1), []time.Time{at(2026, 10, 19), at(2026, 11, 19), at(2026, 12, 19)}},
+		{"FREQ=MONTHLY;BYDAY=-1FR", at(2027, 1, 1), []time.Time{at(2026, 10, 30), at(2026, 11, 27), at(2026, 12, 25)}},
+		{"FREQ=MONTHLY;BYDAY=1MO", at(2027, 1, 1), []time.Time{at(2026, 11, 2), at(2026, 12, 7)}},
+		{"FREQ=MONTHLY;BYMONTHDAY=31", at(2027, 1, 1), []time.Time{at(2026, 10, 31), at(2026, 12, 31)}},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1", at(2027, 1, 1), []time.Time{at(2026, 10, 31), at(2026, 11, 30), at(2026, 12, 31)}},
+		{"FREQ=YEARLY;BYMONTH=1,7;BYDAY=1MO", at(2028, 1, 1), []time.Time{at(2027, 1, 4), at(2027, 7, 5)}},
+		{"FREQ=YEARLY;COUNT=2", at(2030, 1, 1), []time.Time{at(2026, 10, 19), at(2027, 10, 19)}},
+	} {
+		t.Run(tc.rule, func(t *testing.T) {
+			r, err := Parse(tc.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tc.rule, err)
+			}
+			got := r.Between(dtstart, dtstart, tc.to)
+			if len(got) != len(tc.want) {
+				t.Fatalf("Between = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tc.want[i]) {
+					t.Errorf("Between[%d] = %v, want %v", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBetweenKeepsLocation(t *testing.T) {
+	oslo := time.FixedZone("CET", 60*60)
+	start := time.Date(2026, 10, 19, 23, 30, 0, 0, oslo)
+	r, err := Parse("FREQ=DAILY;UNTIL=20261020")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := r.Between(start, start, start.AddDate(0, 0, 5))
+	want := []time.Time{start, start.AddDate(0, 0, 1)}
+	if len(got) != len(want) || !got[0].Equal(want[0]) || !got[1].Equal(want[1]) {
+		t.Fatalf("Between = %v, want %v", got, want)
+	}
+	if got[1].Location() != oslo {
+		t.Errorf("occurrence location = %v, want %v", got[1].Location(), oslo)
+	}
+}
+
+func TestNext(t *testing.T) {
+	for _, tc := range []struct {
+		rule  string
+		after time.Time
+		want  time.Time
+		ok    bool
+	}{
+		{"FREQ=WEEKLY;BYDAY=MO,WE", at(2026, 10, 19), at(2026, 10, 21), true},
+		{"FREQ=WEEKLY;BYDAY=MO,WE", at(2026, 10, 21), at(2026, 10, 26), true},
+		{"FREQ=WEEKLY;BYDAY=MO,WE", dtstart.Add(-time.Hour), at(2026, 10, 19), true},
+		{"FREQ=WEEKLY;BYDAY=MO,WE;EXDATE=20261021", at(2026, 10, 19), at(2026, 10, 26), true},
+		{"FREQ=DAILY;COUNT=2", at(2026, 10, 20), time.Time{}, false},
+		{"FREQ=DAILY;UNTIL=20261020", at(2026, 10, 20), time.Time{}, false},
+	} {
+		t.Run(tc.rule, func(t *testing.T) {
+			r, err := Parse(tc.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tc.rule, err)
+			}
+			got, ok := r.Next(dtstart, tc.after)
+			if ok != tc.ok || !got.Equal(tc.want) {
+				t.Errorf("Next(%v) = %v, %v, want %v, %v", tc.after, got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
+
+func TestSkip(t *testing.T) {
+	r, err := Parse("FREQ=WEEKLY;BYDAY=MO,WE;EXDATE=20261102")
+	if err != nil {
+		t.Fatal(err)
+	}
+	skipped := r.Skip(at(2026, 10, 21))
+	if got, want := skipped.String(), "FREQ=WEEKLY;BYDAY=MO,WE;EXDATE=20261021,20261102"; got != want {
+		t.Errorf("Skip().String() = %q, want %q", got, want)
+	}
+	if got, want := r.String(), "FREQ=WEEKLY;BYDAY=MO,WE;EXDATE=20261102"; got != want {
+		t.Errorf("Skip changed the original rule to %q", got)
+	}
+	if next, _ := skipped.Next(dtstart, dtstart); !next.Equal(at(2026, 10, 26)) {
+		t.Errorf("Next after skip = %v, want %v", next, at(2026, 10, 26))
+	}
+}
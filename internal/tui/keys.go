@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+type keyCode int
+
+const (
+	keyRune keyCode = iota
+	keyUp
+	keyDown
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyEnter
+	keyEsc
+	keyBackspace
+	keyTab
+	keyBackTab
+	keyCtrlC
+	keyUnknown
+)
+
+// key is a decoded key press.
+type key struct {
+	code keyCode
+	r    rune
+}
+
+// escapes maps the escape sequences of common terminals to keys
+var escapes = map[string]keyCode{
+	"\x1b[A":  keyUp,
+	"\x1b[B":  keyDown,
+	"\x1bOA":  keyUp,
+	"\x1bOB":  keyDown,
+	"\x1b[5~": keyPageUp,
+	"\x1b[6~": keyPageDown,
+	"\x1b[H":  keyHome,
+	"\x1b[F":  keyEnd,
+	"\x1b[1~": keyHome,
+	"\x1b[4~": keyEnd,
+	"\x1bOH":  keyHome,
+	"\x1bOF":  keyEnd,
+	"\x1b[Z":  keyBackTab,
+}
+
+// readKeys decodes key presses from r until it fails, then closes keys
+func readKeys(r io.Reader, keys chan<- key) {
+	defer close(keys)
+	buf := make([]byte, 64)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, k := range decodeKeys(buf[:n]) {
+			keys <- k
+		}
+	}
+}
+
+// decodeKeys splits one read from the terminal into key presses. An escape
+// sequence arrives in a single read, so a lone ESC is the Escape key.
+func decodeKeys(b []byte) []key {
+	var keys []key
+	for len(b) > 0 {
+		switch c := b[0]; {
+		case c == 0x1b:
+			if len(b) == 1 {
+				keys = append(keys, key{code: keyEsc})
+				return keys
+			}
+			matched := false
+			for seq, code := range escapes {
+				if len(b) >= len(seq) && string(b[:len(seq)]) == seq {
+					keys = append(keys, key{code: code})
+					b = b[len(seq):]
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				// Skip an unknown CSI sequence up to its final byte
+				end := 1
+				if len(b) > 1 && (b[1] == '[' || b[1] == 'O') {
+					end = 2
+					for end < len(b) && (b[end] < 0x40 || b[end] > 0x7e) {
+						end++
+					}
+					end++
+				}
+				if end > len(b) {
+					end = len(b)
+				}
+				keys = append(keys, key{code: keyUnknown})
+				b = b[end:]
+			}
+		case c == '\r' || c == '\n':
+			keys = append(keys, key{code: keyEnter})
+			b = b[1:]
+		case c == '\t':
+			keys = append(keys, key{code: keyTab})
+			b = b[1:]
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, key{code: keyBackspace})
+			b = b[1:]
+		case c == 0x03:
+			keys = append(keys, key{code: keyCtrlC})
+			b = b[1:]
+		case c < 0x20:
+			keys = append(keys, key{code: keyUnknown})
+			b = b[1:]
+		default:
+			r, size := utf8.DecodeRune(b)
+			keys = append(keys, key{code: keyRune, r: r})
+			b = b[size:]
+		}
+	}
+	return keys
+}
@@ -0,0 +1,227 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	reverse = "\x1b[7m"
+	bold    = "\x1b[1m"
+	dim     = "\x1b[2m"
+	red     = "\x1b[31m"
+	reset   = "\x1b[0m"
+)
+
+// listHeight is the number of rows available to the list
+func (a *app) listHeight() int {
+	if h := a.height - 2; h > 1 {
+		return h
+	}
+	return 1
+}
+
+// draw renders the whole screen in one write
+func (a *app) draw(w io.Writer) {
+	width, height := a.width, a.height
+	if width < 20 || height < 4 {
+		io.WriteString(w, "\x1b[H\x1b[2Jterminal too small")
+		return
+	}
+	v := a.views[a.current]
+	items := v.visible()
+	pos := v.cursor(items)
+	if len(items) > 0 {
+		v.selected = items[pos].ID
+	}
+
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+
+	// Tab bar
+	var tabs []string
+	for i, view := range a.views {
+		label := fmt.Sprintf(" %d %s ", i+1, view.source.Name())
+		if view.loaded {
+			label = fmt.Sprintf(" %d %s (%d) ", i+1, view.source.Name(), len(view.items))
+		}
+		if i == a.current {
+			label = reverse + label + reset
+		}
+		tabs = append(tabs, label)
+	}
+	header := strings.Join(tabs, " ")
+	switch {
+	case a.searching:
+		header += "  /" + v.filter + "▏"
+	case v.filter != "":
+		header += "  filter: " + v.filter
+	}
+	if v.loading {
+		header += dim + "  loading…" + reset
+	}
+	b.WriteString(header + "\x1b[K\r\n")
+
+	// List and detail panes
+	rows := a.listHeight()
+	listWidth := width * 2 / 5
+	if listWidth < 16 {
+		listWidth = 16
+	}
+	detailWidth := width - listWidth - 3
+
+	if pos < v.offset {
+		v.offset = pos
+	}
+	if pos >= v.offset+rows {
+		v.offset = pos - rows + 1
+	}
+	if v.offset > 0 && v.offset > len(items)-rows {
+		v.offset = len(items) - rows
+		if v.offset < 0 {
+			v.offset = 0
+		}
+	}
+
+	var detail []string
+	if len(items) > 0 {
+		detail = detailLines(items[pos], detailWidth)
+	}
+
+	statusWidth := listWidth / 3
+	if statusWidth > 14 {
+		statusWidth = 14
+	}
+	for r := 0; r < rows; r++ {
+		left := strings.Repeat(" ", listWidth)
+		if i := v.offset + r; i < len(items) {
+			it := items[i]
+			title := fit(it.Title, listWidth-statusWidth-1)
+			left = title + " " + fit(it.Status, statusWidth)
+			if i == pos {
+				left = reverse + left + reset
+			} else if v.pending[it.ID] > 0 {
+				left = dim + left + reset
+			}
+		} else if r == 0 && len(items) == 0 {
+			msg := "No items"
+			if !v.loaded {
+				msg = "Loading…"
+			}
+			left = fit(msg, listWidth)
+		}
+		right := ""
+		if r < len(detail) {
+			right = detail[r]
+		}
+		b.WriteString(left + " │ " + right + "\x1b[K\r\n")
+	}
+
+	// Status line
+	msg := a.message
+	switch {
+	case a.searching:
+		msg = "type to search · enter keep · esc clear"
+	case msg == "":
+		msg = "? help · s status · p priority · / search · o open · q quit"
+	}
+	if a.isError {
+		b.WriteString(red + fit(msg, width) + reset + "\x1b[K")
+	} else {
+		b.WriteString(dim + fit(msg, width) + reset + "\x1b[K")
+	}
+
+	io.WriteString(w, b.String())
+}
+
+// detailLines lays out an item for the detail pane
+func detailLines(it *Item, width int) []string {
+	if width < 10 {
+		return nil
+	}
+	var lines []string
+	for _, l := range wrap(it.Title, width) {
+		lines = append(lines, bold+l+reset)
+	}
+	lines = append(lines, "")
+
+	fields := []Field{{"Status", it.Status}}
+	if it.Priority != "" {
+		fields = append(fields, Field{"Priority", it.Priority})
+	}
+	fields = append(fields, it.Fields...)
+	for _, f := range fields {
+		if f.Value == "" {
+			continue
+		}
+		label := f.Label + ": "
+		for i, l := range wrap(f.Value, width-utf8.RuneCountInString(label)) {
+			if i == 0 {
+				lines = append(lines, dim+label+reset+l)
+			} else {
+				lines = append(lines, strings.Repeat(" ", utf8.RuneCountInString(label))+l)
+			}
+		}
+	}
+
+	if it.Body != "" {
+		lines = append(lines, "")
+		for _, para := range strings.Split(it.Body, "\n") {
+			lines = append(lines, wrap(para, width)...)
+		}
+	}
+	return lines
+}
+
+// fit cuts or pads s to exactly width characters
+func fit(s string, width int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	n := utf8.RuneCountInString(s)
+	switch {
+	case width <= 0:
+		return ""
+	case n > width:
+		return string([]rune(s)[:width-1]) + "…"
+	default:
+		return s + strings.Repeat(" ", width-n)
+	}
+}
+
+// wrap breaks text into lines of at most width characters at spaces
+func wrap(s string, width int) []string {
+	if width < 1 {
+		return nil
+	}
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return []string{""}
+	}
+	var lines []string
+	cur := ""
+	for _, w := range words {
+		for utf8.RuneCountInString(w) > width {
+			if cur != "" {
+				lines = append(lines, cur)
+				cur = ""
+			}
+			r := []rune(w)
+			lines = append(lines, string(r[:width]))
+			w = string(r[width:])
+		}
+		switch {
+		case cur == "":
+			cur = w
+		case utf8.RuneCountInString(cur)+1+utf8.RuneCountInString(w) <= width:
+			cur += " " + w
+		default:
+			lines = append(lines, cur)
+			cur = w
+		}
+	}
+	if cur != "" {
+		lines = append(lines, cur)
+	}
+	return lines
+}
@@ -0,0 +1,17 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package tui
+
+import "os"
+
+type terminal struct{}
+
+func openTerminal(f *os.File) (*terminal, error) {
+	return nil, ErrUnsupported
+}
+
+func (t *terminal) restore() error { return nil }
+
+func (t *terminal) size() (int, int) { return 80, 24 }
+
+func notifyResize(c chan<- os.Signal) {}
@@ -0,0 +1,503 @@
+// Package tui is a full-screen terminal interface for browsing and editing
+// Notion pages. It knows nothing about Notion itself: each database is
+// plugged in as a Source.
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// ErrUnsupported is returned by Run on platforms without terminal support.
+var ErrUnsupported = errors.New("the interactive interface is not supported on this platform")
+
+// Field is a labelled value shown in the detail pane.
+type Field struct {
+	Label string
+	Value string
+}
+
+// Item is one page in a list.
+type Item struct {
+	ID       string
+	Title    string
+	Status   string
+	Priority string
+	URL      string
+	// Fields are shown in the detail pane below status and priority.
+	Fields []Field
+	// Body is free text shown at the bottom of the detail pane.
+	Body string
+}
+
+// Source loads and edits the items of one view.
+type Source interface {
+	// Name labels the view in the tab bar.
+	Name() string
+	// Load fetches the current items.
+	Load(ctx context.Context) ([]Item, error)
+	// Statuses lists the status values to cycle through, in order.
+	Statuses() []string
+	// Priorities lists the priority values to cycle through; empty when
+	// the items have no priority.
+	Priorities() []string
+	// SetStatus changes the status of an item and returns it as saved.
+	SetStatus(ctx context.Context, id, status string) (Item, error)
+	// SetPriority changes the priority of an item and returns it as saved.
+	SetPriority(ctx context.Context, id, priority string) (Item, error)
+}
+
+// Options controls the interface.
+type Options struct {
+	// Refresh is how often every loaded view is reloaded in the
+	// background. Zero turns background refresh off.
+	Refresh time.Duration
+	// View is the index of the view shown first.
+	View int
+}
+
+// view is the state of one source's list.
+type view struct {
+	source   Source
+	items    []Item
+	loaded   bool
+	loading  bool
+	selected string // ID of the selected item
+	offset   int
+	filter   string
+	// pending counts the edits in flight per item; refreshes leave those
+	// items alone so optimistic changes don't flicker back
+	pending map[string]int
+}
+
+type app struct {
+	ctx       context.Context
+	views     []*view
+	current   int
+	searching bool
+	// quitting is set when the user quit with edits still being saved
+	quitting bool
+	message  string
+	isError  bool
+	width    int
+	height   int
+	results  chan func(*app)
+	// done is closed when Run returns, so background work stops waiting
+	// to hand in its results
+	done chan struct{}
+}
+
+// Run shows the interface until the user quits. Edits are applied to the
+// screen at once and saved in the background; failed saves are rolled back
+// and reported. Quitting waits for the saves in flight unless asked twice.
+func Run(ctx context.Context, sources []Source, opts Options) error {
+	if len(sources) == 0 {
+		return fmt.Errorf("nothing to show")
+	}
+
+	term, err := openTerminal(os.Stdin)
+	if err != nil {
+		return err
+	}
+	defer term.restore()
+
+	// Alternate screen, hidden cursor; undone on the way out
+	fmt.Fprint(os.Stdout, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(os.Stdout, "\x1b[?25h\x1b[?1049l")
+
+	a := newApp(ctx, sources)
+	defer close(a.done)
+	if opts.View >= 0 && opts.View < len(a.views) {
+		a.current = opts.View
+	}
+	a.width, a.height = term.size()
+
+	keys := make(chan key, 16)
+	go readKeys(os.Stdin, keys)
+	resize := make(chan os.Signal, 1)
+	notifyResize(resize)
+
+	var tick <-chan time.Time
+	if opts.Refresh > 0 {
+		ticker := time.NewTicker(opts.Refresh)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	a.load(a.current)
+	for {
+		if a.quitting && a.saving() == 0 {
+			return nil
+		}
+		a.draw(os.Stdout)
+		select {
+		case <-ctx.Done():
+			return nil
+		case k, ok := <-keys:
+			if !ok {
+				// The terminal is gone: finish saving, then stop
+				keys = nil
+				a.quitting = true
+			} else if a.handleKey(k) && a.quit() {
+				return nil
+			}
+		case f := <-a.results:
+			f(a)
+		case <-resize:
+			a.width, a.height = term.size()
+		case <-tick:
+			for i, v := range a.views {
+				if v.loaded {
+					a.load(i)
+				}
+			}
+		}
+	}
+}
+
+func newApp(ctx context.Context, sources []Source) *app {
+	a := &app{
+		ctx:     ctx,
+		results: make(chan func(*app), 32),
+		done:    make(chan struct{}),
+	}
+	for _, src := range sources {
+		a.views = append(a.views, &view{source: src, pending: make(map[string]int)})
+	}
+	return a
+}
+
+// send hands a result from background work to the event loop, or drops it
+// once Run has returned
+func (a *app) send(f func(*app)) {
+	select {
+	case a.results <- f:
+	case <-a.done:
+	}
+}
+
+// saving counts the edits still being saved
+func (a *app) saving() int {
+	n := 0
+	for _, v := range a.views {
+		for _, count := range v.pending {
+			n += count
+		}
+	}
+	return n
+}
+
+// quit reports whether the interface can close. With edits still being
+// saved it waits for them instead, unless the user asks a second time.
+func (a *app) quit() bool {
+	n := a.saving()
+	if n == 0 || a.quitting {
+		return true
+	}
+	a.quitting = true
+	changes := "change"
+	if n > 1 {
+		changes += "s"
+	}
+	a.message, a.isError = fmt.Sprintf("Saving %d %s… press q again to quit without waiting", n, changes), false
+	return false
+}
+
+// load fetches a view's items in the background
+func (a *app) load(i int) {
+	v := a.views[i]
+	if v.loading {
+		return
+	}
+	v.loading = true
+	go func() {
+		items, err := v.source.Load(a.ctx)
+		a.send(func(a *app) {
+			v.loading = false
+			if err != nil {
+				a.setError(fmt.Sprintf("%s: %v", v.source.Name(), err))
+				return
+			}
+			v.merge(items)
+		})
+	}()
+}
+
+// merge replaces the items with freshly loaded ones, keeping the local
+// version of items with edits in flight
+func (v *view) merge(items []Item) {
+	local := make(map[string]Item, len(v.items))
+	for _, it := range v.items {
+		if v.pending[it.ID] > 0 {
+			local[it.ID] = it
+		}
+	}
+	for i, it := range items {
+		if l, ok := local[it.ID]; ok {
+			items[i] = l
+		}
+	}
+	v.items = items
+	v.loaded = true
+}
+
+// visible returns the items matching the filter
+func (v *view) visible() []*Item {
+	filter := strings.ToLower(v.filter)
+	var out []*Item
+	for i := range v.items {
+		if filter == "" || matches(&v.items[i], filter) {
+			out = append(out, &v.items[i])
+		}
+	}
+	return out
+}
+
+// cursor returns the position of the selected item among the visible ones,
+// falling back to the first
+func (v *view) cursor(items []*Item) int {
+	for i, it := range items {
+		if it.ID == v.selected {
+			return i
+		}
+	}
+	return 0
+}
+
+func (v *view) find(id string) *Item {
+	for i := range v.items {
+		if v.items[i].ID == id {
+			return &v.items[i]
+		}
+	}
+	return nil
+}
+
+func matches(it *Item, filter string) bool {
+	if strings.Contains(strings.ToLower(it.Title), filter) ||
+		strings.Contains(strings.ToLower(it.Status), filter) {
+		return true
+	}
+	for _, f := range it.Fields {
+		if strings.Contains(strings.ToLower(f.Value), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleKey acts on a key press and reports whether to quit
+func (a *app) handleKey(k key) bool {
+	if a.quitting {
+		// Only quitting without waiting is left to do
+		return k.code == keyCtrlC || (k.code == keyRune && k.r == 'q')
+	}
+	v := a.views[a.current]
+	a.message, a.isError = "", false
+
+	if a.searching {
+		switch k.code {
+		case keyEnter:
+			a.searching = false
+		case keyEsc:
+			a.searching = false
+			v.filter = ""
+		case keyBackspace:
+			if r := []rune(v.filter); len(r) > 0 {
+				v.filter = string(r[:len(r)-1])
+			}
+		case keyRune:
+			v.filter += string(k.r)
+		case keyCtrlC:
+			return true
+		}
+		return false
+	}
+
+	items := v.visible()
+	pos := v.cursor(items)
+	move := func(to int) {
+		if len(items) == 0 {
+			return
+		}
+		if to < 0 {
+			to = 0
+		}
+		if to >= len(items) {
+			to = len(items) - 1
+		}
+		v.selected = items[to].ID
+	}
+	page := a.listHeight()
+
+	switch k.code {
+	case keyCtrlC:
+		return true
+	case keyUp:
+		move(pos - 1)
+	case keyDown:
+		move(pos + 1)
+	case keyPageUp:
+		move(pos - page)
+	case keyPageDown:
+		move(pos + page)
+	case keyHome:
+		move(0)
+	case keyEnd:
+		move(len(items) - 1)
+	case keyTab:
+		a.switchView((a.current + 1) % len(a.views))
+	case keyBackTab:
+		a.switchView((a.current + len(a.views) - 1) % len(a.views))
+	case keyEsc:
+		v.filter = ""
+	case keyRune:
+		switch k.r {
+		case 'q':
+			return true
+		case 'k':
+			move(pos - 1)
+		case 'j':
+			move(pos + 1)
+		case 'g':
+			move(0)
+		case 'G':
+			move(len(items) - 1)
+		case '/':
+			a.searching = true
+			v.filter = ""
+		case 's':
+			a.cycle(items, pos, false, 1)
+		case 'S':
+			a.cycle(items, pos, false, -1)
+		case 'p':
+			a.cycle(items, pos, true, 1)
+		case 'P':
+			a.cycle(items, pos, true, -1)
+		case 'o':
+			if len(items) > 0 {
+				if err := openBrowser(items[pos].URL); err != nil {
+					a.setError(err.Error())
+				}
+			}
+		case 'r':
+			a.load(a.current)
+			a.message = "Refreshing…"
+		case '?':
+			a.message = "j/k move · g/G top/bottom · s/S status · p/P priority · / search · o open · r refresh · tab view · q quit"
+		default:
+			if k.r >= '1' && k.r <= '9' && int(k.r-'1') < len(a.views) {
+				a.switchView(int(k.r - '1'))
+			}
+		}
+	}
+	return false
+}
+
+func (a *app) switchView(i int) {
+	a.current = i
+	if !a.views[i].loaded {
+		a.load(i)
+	}
+}
+
+// cycle moves the status or priority of the item at pos to the next value,
+// shows the change at once and saves it in the background
+func (a *app) cycle(items []*Item, pos int, priority bool, dir int) {
+	if len(items) == 0 {
+		return
+	}
+	v := a.views[a.current]
+	src := v.source
+	it := items[pos]
+
+	values, current, name := src.Statuses(), it.Status, "status"
+	if priority {
+		values, current, name = src.Priorities(), it.Priority, "priority"
+	}
+	if len(values) == 0 {
+		a.setError(fmt.Sprintf("%s has no %s values to cycle through", src.Name(), name))
+		return
+	}
+	next := values[0]
+	for i, val := range values {
+		if strings.EqualFold(val, current) {
+			next = values[(i+dir+len(values))%len(values)]
+			break
+		}
+	}
+
+	id := it.ID
+	if priority {
+		it.Priority = next
+	} else {
+		it.Status = next
+	}
+	v.pending[id]++
+
+	go func() {
+		var saved Item
+		var err error
+		if priority {
+			saved, err = src.SetPriority(a.ctx, id, next)
+		} else {
+			saved, err = src.SetStatus(a.ctx, id, next)
+		}
+		a.send(func(a *app) {
+			v.pending[id]--
+			if v.pending[id] <= 0 {
+				delete(v.pending, id)
+			}
+			it := v.find(id)
+			if it == nil {
+				return
+			}
+			if err != nil {
+				// Roll back unless a later edit has replaced the value
+				if priority && it.Priority == next {
+					it.Priority = current
+				} else if !priority && it.Status == next {
+					it.Status = current
+				}
+				// Stay open so a user who was quitting sees the edit was lost
+				a.quitting = false
+				a.setError(fmt.Sprintf("failed to save %s: %v", name, err))
+				return
+			}
+			if v.pending[id] == 0 {
+				*it = saved
+			}
+		})
+	}()
+}
+
+func (a *app) setError(msg string) {
+	a.message, a.isError = msg, true
+}
+
+// openBrowser opens a URL with the platform's default handler
+func openBrowser(url string) error {
+	if url == "" {
+		return fmt.Errorf("this page has no URL")
+	}
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	go cmd.Wait()
+	return nil
+}
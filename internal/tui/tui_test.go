@@ -0,0 +1,284 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSource keeps items in memory. Saves wait for release when it is set
+// and fail with err when it is set.
+type fakeSource struct {
+	mu      sync.Mutex
+	items   []Item
+	err     error
+	release chan struct{}
+}
+
+func (s *fakeSource) Name() string         { return "Tasks" }
+func (s *fakeSource) Statuses() []string   { return []string{"Todo", "In Progress", "Done"} }
+func (s *fakeSource) Priorities() []string { return nil }
+
+func (s *fakeSource) Load(ctx context.Context) ([]Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Item(nil), s.items...), nil
+}
+
+func (s *fakeSource) SetStatus(ctx context.Context, id, status string) (Item, error) {
+	if s.release != nil {
+		<-s.release
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return Item{}, s.err
+	}
+	for i := range s.items {
+		if s.items[i].ID == id {
+			s.items[i].Status = status
+			s.items[i].Fields = []Field{{Label: "Saved", Value: "yes"}}
+			return s.items[i], nil
+		}
+	}
+	return Item{}, errors.New("not found")
+}
+
+func (s *fakeSource) SetPriority(ctx context.Context, id, priority string) (Item, error) {
+	return Item{}, errors.New("no priorities")
+}
+
+func newTestApp(t *testing.T, src *fakeSource) *app {
+	t.Helper()
+	a := newApp(context.Background(), []Source{src})
+	t.Cleanup(func() { close(a.done) })
+	a.width, a.height = 80, 24
+	a.load(0)
+	step(t, a)
+	return a
+}
+
+// step applies the next result of background work
+func step(t *testing.T, a *app) {
+	t.Helper()
+	select {
+	case f := <-a.results:
+		f(a)
+	case <-time.After(time.Second):
+		t.Fatal("no result from background work")
+	}
+}
+
+// press types keys and reports whether the last one asked to quit
+func press(a *app, keys string) bool {
+	quit := false
+	for _, k := range decodeKeys([]byte(keys)) {
+		quit = a.handleKey(k)
+	}
+	return quit
+}
+
+func testItems() []Item {
+	return []Item{
+		{ID: "1", Title: "Write report", Status: "Todo"},
+		{ID: "2", Title: "Review draft", Status: "Done"},
+	}
+}
+
+func TestCycleStatus(t *testing.T) {
+	for _, tc := range []struct {
+		keys string
+		want string
+	}{
+		{"s", "In Progress"},
+		{"S", "Done"},
+		{"ss", "Done"},
+		{"sss", "Todo"},
+	} {
+		t.Run(tc.keys, func(t *testing.T) {
+			a := newTestApp(t, &fakeSource{items: testItems()})
+			v := a.views[0]
+
+			for _, k := range tc.keys {
+				press(a, string(k))
+				if a.saving() != 1 {
+					t.Errorf("saving %d edits, want 1", a.saving())
+				}
+				step(t, a)
+			}
+			if a.saving() != 0 || v.items[0].Status != tc.want || len(v.items[0].Fields) != 1 {
+				t.Errorf("after saving: %d pending, item %+v", a.saving(), v.items[0])
+			}
+		})
+	}
+}
+
+func TestCycleRollsBack(t *testing.T) {
+	a := newTestApp(t, &fakeSource{items: testItems(), err: errors.New("conflict")})
+	v := a.views[0]
+
+	press(a, "s")
+	if v.items[0].Status != "In Progress" {
+		t.Errorf("status = %q before saving, want In Progress", v.items[0].Status)
+	}
+	step(t, a)
+	if v.items[0].Status != "Todo" {
+		t.Errorf("status = %q after a failed save, want it rolled back to Todo", v.items[0].Status)
+	}
+	if !a.isError || !strings.Contains(a.message, "failed to save status: conflict") {
+		t.Errorf("message = %q (error %v)", a.message, a.isError)
+	}
+
+	press(a, "p")
+	if !a.isError || !strings.Contains(a.message, "no priority values") {
+		t.Errorf("cycling priority without values: message = %q", a.message)
+	}
+}
+
+func TestRefreshKeepsPendingEdit(t *testing.T) {
+	src := &fakeSource{items: testItems(), release: make(chan struct{})}
+	a := newTestApp(t, src)
+	v := a.views[0]
+
+	press(a, "s")
+	a.load(0)
+	step(t, a)
+	if v.items[0].Status != "In Progress" {
+		t.Errorf("refresh replaced the edit in flight: status = %q", v.items[0].Status)
+	}
+
+	close(src.release)
+	step(t, a)
+	if v.items[0].Status != "In Progress" || a.saving() != 0 {
+		t.Errorf("after saving: status %q, %d pending", v.items[0].Status, a.saving())
+	}
+}
+
+func TestSearch(t *testing.T) {
+	titles := func(a *app) []string {
+		var out []string
+		for _, it := range a.views[0].visible() {
+			out = append(out, it.Title)
+		}
+		return out
+	}
+	for _, tc := range []struct {
+		name string
+		keys string
+		want []string
+	}{
+		{"title", "/REV\r", []string{"Review draft"}},
+		{"status", "/todo\r", []string{"Write report"}},
+		{"no match", "/zzz\r", nil},
+		{"backspace", "/revx\x7f\r", []string{"Review draft"}},
+		{"escape clears", "/rev\x1b", []string{"Write report", "Review draft"}},
+		{"escape after search", "/rev\r\x1b", []string{"Write report", "Review draft"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			a := newTestApp(t, &fakeSource{items: testItems()})
+			press(a, tc.keys)
+			if a.searching {
+				t.Error("still searching")
+			}
+			if got := titles(a); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("visible = %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	a := newTestApp(t, &fakeSource{items: testItems()})
+	press(a, "/rev\rs")
+	if got := a.views[0].items[1].Status; got != "Todo" {
+		t.Errorf("cycling the filtered item set status %q on it, want Todo", got)
+	}
+}
+
+func TestQuitWaitsForSaves(t *testing.T) {
+	src := &fakeSource{items: testItems(), release: make(chan struct{})}
+	a := newTestApp(t, src)
+
+	if !press(a, "q") || !a.quit() {
+		t.Fatal("q with nothing to save didn't quit")
+	}
+	a.quitting = false
+
+	press(a, "s")
+	if !press(a, "q") {
+		t.Fatal("q didn't ask to quit")
+	}
+	if a.quit() {
+		t.Fatal("quit with an edit being saved")
+	}
+	if !a.quitting || !strings.Contains(a.message, "Saving 1 change…") {
+		t.Errorf("quitting %v, message %q", a.quitting, a.message)
+	}
+	if v := a.views[0]; press(a, "j") || v.cursor(v.visible()) != 0 {
+		t.Error("keys other than quit still act while saving")
+	}
+
+	close(src.release)
+	step(t, a)
+	if a.saving() != 0 || src.items[0].Status != "In Progress" {
+		t.Errorf("after saving: %d pending, stored status %q", a.saving(), src.items[0].Status)
+	}
+	if !press(a, "\x03") || !a.quit() {
+		t.Error("asking again didn't quit")
+	}
+}
+
+func TestQuitStopsOnFailedSave(t *testing.T) {
+	src := &fakeSource{items: testItems(), release: make(chan struct{}), err: errors.New("offline")}
+	a := newTestApp(t, src)
+
+	press(a, "s")
+	if press(a, "q") && a.quit() {
+		t.Fatal("quit with an edit being saved")
+	}
+	close(src.release)
+	step(t, a)
+	if a.quitting || !a.isError {
+		t.Errorf("a failed save while quitting: quitting %v, message %q", a.quitting, a.message)
+	}
+}
+
+func TestSendAfterRun(t *testing.T) {
+	a := newApp(context.Background(), nil)
+	close(a.done)
+	sent := make(chan struct{})
+	go func() {
+		for i := 0; i < 2*cap(a.results); i++ {
+			a.send(func(*app) {})
+		}
+		close(sent)
+	}()
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("send blocked after Run returned")
+	}
+}
+
+func TestDecodeKeys(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want []key
+	}{
+		{"j", []key{{code: keyRune, r: 'j'}}},
+		{"é", []key{{code: keyRune, r: 'é'}}},
+		{"\x1b", []key{{code: keyEsc}}},
+		{"\x1b[A\x1bOB", []key{{code: keyUp}, {code: keyDown}}},
+		{"\x1b[5~\x1b[6~", []key{{code: keyPageUp}, {code: keyPageDown}}},
+		{"\x1b[Z\t", []key{{code: keyBackTab}, {code: keyTab}}},
+		{"\r\x7f\x03", []key{{code: keyEnter}, {code: keyBackspace}, {code: keyCtrlC}}},
+		{"\x1b[1;5Cq", []key{{code: keyUnknown}, {code: keyRune, r: 'q'}}},
+		{"\x01", []key{{code: keyUnknown}}},
+	} {
+		if got := decodeKeys([]byte(tc.in)); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("decodeKeys(%q) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
@@ -4,11 +4,17 @@ import (
 	"os"
 
 	"github.com/jontk/notion-cli/cmd"
+	_ "github.com/jontk/notion-cli/cmd/agenda"
+	_ "github.com/jontk/notion-cli/cmd/blocks"
+	_ "github.com/jontk/notion-cli/cmd/codegen"
 	_ "github.com/jontk/notion-cli/cmd/config"
 	_ "github.com/jontk/notion-cli/cmd/databases"
 	_ "github.com/jontk/notion-cli/cmd/events"
 	_ "github.com/jontk/notion-cli/cmd/posts"
+	_ "github.com/jontk/notion-cli/cmd/search"
 	_ "github.com/jontk/notion-cli/cmd/tasks"
+	_ "github.com/jontk/notion-cli/cmd/tui"
+	_ "github.com/jontk/notion-cli/cmd/users"
 )
 
 func main() {
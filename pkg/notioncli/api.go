@@ -0,0 +1,152 @@
+package notioncli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jomei/notionapi"
+)
+
+// defaultVersion is the Notion API version requests are sent with unless
+// WithVersion sets another
+const defaultVersion = "2022-06-28"
+
+// defaultBaseURL is where requests go unless WithBaseURL sets another
+// address. notionapi always sends its requests here.
+var defaultBaseURL = &url.URL{Scheme: "https", Host: "api.notion.com", Path: "/v1/"}
+
+// request sends a request straight to the Notion API and returns the
+// response body. It is for the calls where notionapi's request or response
+// types lose something: its search request always sends a filter, and its
+// pages parse dates and times alike. Errors are *notionapi.Error or
+// *notionapi.RateLimitedError, as from the notionapi client.
+func (c *Client) request(ctx context.Context, method, path string, body any) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	u, err := c.baseURL.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Notion-Version", c.version)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode == http.StatusTooManyRequests {
+		// retryTransport has already waited and retried
+		return nil, &notionapi.RateLimitedError{Message: fmt.Sprintf("rate limited after %d retries", c.retries)}
+	}
+	if res.StatusCode != http.StatusOK {
+		var apiErr notionapi.Error
+		if err := json.Unmarshal(data, &apiErr); err != nil || apiErr.Message == "" {
+			return nil, fmt.Errorf("notion API returned %s", res.Status)
+		}
+		return nil, &apiErr
+	}
+	return data, nil
+}
+
+// rebaseTransport moves notionapi's requests, which always go to
+// defaultBaseURL, onto the base URL set by WithBaseURL
+type rebaseTransport struct {
+	base http.RoundTripper
+	to   *url.URL
+}
+
+func (t *rebaseTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host == defaultBaseURL.Host && strings.HasPrefix(req.URL.Path, defaultBaseURL.Path) {
+		u := *t.to
+		u.Path += strings.TrimPrefix(req.URL.Path, defaultBaseURL.Path)
+		u.RawPath = ""
+		u.RawQuery = req.URL.RawQuery
+		req = req.Clone(req.Context())
+		req.URL, req.Host = &u, ""
+	}
+	return t.base.RoundTrip(req)
+}
+
+// post sends a POST request and decodes the response into v
+func (c *Client) post(ctx context.Context, path string, body, v any) error {
+	data, err := c.request(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// getPage reads a page; see decodePage
+func (c *Client) getPage(ctx context.Context, pageID string) (*notionapi.Page, error) {
+	data, err := c.request(ctx, http.MethodGet, "pages/"+pageID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodePage(data)
+}
+
+// createPage creates a page and returns it as decodePage reads it
+func (c *Client) createPage(ctx context.Context, req *notionapi.PageCreateRequest) (*notionapi.Page, error) {
+	data, err := c.request(ctx, http.MethodPost, "pages", req)
+	if err != nil {
+		return nil, err
+	}
+	return decodePage(data)
+}
+
+// updatePage updates a page and returns it as decodePage reads it
+func (c *Client) updatePage(ctx context.Context, pageID string, req *notionapi.PageUpdateRequest) (*notionapi.Page, error) {
+	data, err := c.request(ctx, http.MethodPatch, "pages/"+pageID, req)
+	if err != nil {
+		return nil, err
+	}
+	return decodePage(data)
+}
+
+// queryDatabase runs one page of a database query, with the results read as
+// decodePage reads a page
+func (c *Client) queryDatabase(ctx context.Context, databaseID string, req *notionapi.DatabaseQueryRequest) (*notionapi.DatabaseQueryResponse, error) {
+	data, err := c.request(ctx, http.MethodPost, "databases/"+databaseID+"/query", req)
+	if err != nil {
+		return nil, err
+	}
+	var resp notionapi.DatabaseQueryResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+	var raw struct {
+		Results []rawPage `json:"results"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	for i := range resp.Results {
+		if i < len(raw.Results) {
+			markDates(&resp.Results[i], raw.Results[i])
+		}
+	}
+	return &resp, nil
+}
@@ -1,4 +1,4 @@
-package notion
+package notioncli
 
 import (
 	"context"
@@ -27,6 +27,16 @@ func (c *Client) GetPageContent(ctx context.Context, pageID string) (string, err
 	return strings.TrimSpace(content.String()), nil
 }
 
+// GetPageMarkdown retrieves the blocks of a page and renders them as
+// Markdown; see BlocksToMarkdown
+func (c *Client) GetPageMarkdown(ctx context.Context, pageID string) (string, error) {
+	blocks, err := c.getAllBlocks(ctx, notionapi.BlockID(pageID))
+	if err != nil {
+		return "", err
+	}
+	return BlocksToMarkdown(blocks), nil
+}
+
 // getAllBlocks retrieves all blocks for a page, handling pagination
 func (c *Client) getAllBlocks(ctx context.Context, blockID notionapi.BlockID) ([]notionapi.Block, error) {
 	var allBlocks []notionapi.Block
@@ -57,39 +67,6 @@ func (c *Client) getAllBlocks(ctx context.Context, blockID notionapi.BlockID) ([
 	return allBlocks, nil
 }
 
-// extractTextFromBlock extracts plain text from various block types
-func extractTextFromBlock(block notionapi.Block) string {
-	switch b := block.(type) {
-	case *notionapi.ParagraphBlock:
-		return extractRichText(b.Paragraph.RichText)
-	case *notionapi.Heading1Block:
-		return extractRichText(b.Heading1.RichText)
-	case *notionapi.Heading2Block:
-		return extractRichText(b.Heading2.RichText)
-	case *notionapi.Heading3Block:
-		return extractRichText(b.Heading3.RichText)
-	case *notionapi.BulletedListItemBlock:
-		return "• " + extractRichText(b.BulletedListItem.RichText)
-	case *notionapi.NumberedListItemBlock:
-		return extractRichText(b.NumberedListItem.RichText)
-	case *notionapi.QuoteBlock:
-		return "> " + extractRichText(b.Quote.RichText)
-	case *notionapi.CodeBlock:
-		return "```\n" + extractRichText(b.Code.RichText) + "\n```"
-	default:
-		return ""
-	}
-}
-
-// extractRichText converts RichText array to plain text
-func extractRichText(richTexts []notionapi.RichText) string {
-	var result strings.Builder
-	for _, rt := range richTexts {
-		result.WriteString(rt.PlainText)
-	}
-	return result.String()
-}
-
 // contentToBlocks converts a content string to paragraph blocks
 func contentToBlocks(content string) []notionapi.Block {
 	if content == "" {
@@ -125,3 +102,50 @@ func contentToBlocks(content string) []notionapi.Block {
 
 	return blocks
 }
+
+// extractTextFromBlock extracts plain text from various block types
+func extractTextFromBlock(block notionapi.Block) string {
+	switch b := block.(type) {
+	case *notionapi.ParagraphBlock:
+		return extractRichText(b.Paragraph.RichText)
+	case *notionapi.Heading1Block:
+		return extractRichText(b.Heading1.RichText)
+	case *notionapi.Heading2Block:
+		return extractRichText(b.Heading2.RichText)
+	case *notionapi.Heading3Block:
+		return extractRichText(b.Heading3.RichText)
+	case *notionapi.BulletedListItemBlock:
+		return "• " + extractRichText(b.BulletedListItem.RichText)
+	case *notionapi.NumberedListItemBlock:
+		return extractRichText(b.NumberedListItem.RichText)
+	case *notionapi.ToDoBlock:
+		if b.ToDo.Checked {
+			return "[x] " + extractRichText(b.ToDo.RichText)
+		}
+		return "[ ] " + extractRichText(b.ToDo.RichText)
+	case *notionapi.QuoteBlock:
+		return "> " + extractRichText(b.Quote.RichText)
+	case *notionapi.CodeBlock:
+		return "```\n" + extractRichText(b.Code.RichText) + "\n```"
+	default:
+		return ""
+	}
+}
+
+// extractRichText converts RichText array to plain text
+func extractRichText(richTexts []notionapi.RichText) string {
+	var result strings.Builder
+	for _, rt := range richTexts {
+		result.WriteString(plainText(rt))
+	}
+	return result.String()
+}
+
+// plainText returns the text of a rich text object. Rich text built locally,
+// rather than read from the API, only has its content set.
+func plainText(rt notionapi.RichText) string {
+	if rt.PlainText == "" && rt.Text != nil {
+		return rt.Text.Content
+	}
+	return rt.PlainText
+}
@@ -0,0 +1,65 @@
+package notioncli
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/jontk/notion-cli/internal/notiontest"
+)
+
+func TestGetAllBlocks(t *testing.T) {
+	client, srv := newTestClient(t)
+	page := srv.AddWorkspacePage("Long page")
+	// Notion appends at most 100 blocks at a time
+	for batch := 0; batch < 3; batch++ {
+		var blocks []map[string]any
+		for i := 0; i < 84; i++ {
+			blocks = append(blocks, notiontest.Paragraph(fmt.Sprintf("line %d", batch*84+i)))
+		}
+		srv.AddBlocks(page, blocks...)
+	}
+
+	blocks, err := client.getAllBlocks(context.Background(), notionapi.BlockID(page))
+	if err != nil {
+		t.Fatalf("getAllBlocks: %v", err)
+	}
+	if len(blocks) != 252 {
+		t.Fatalf("got %d blocks, want 252", len(blocks))
+	}
+	for i, block := range blocks {
+		if got, want := extractTextFromBlock(block), fmt.Sprintf("line %d", i); got != want {
+			t.Fatalf("block %d reads %q, want %q", i, got, want)
+		}
+	}
+
+	listings := 0
+	for _, r := range srv.Requests() {
+		if r.Path == "blocks/"+page+"/children" {
+			listings++
+		}
+	}
+	if listings != 3 {
+		t.Errorf("listed children %d times, want 3 pages of 100", listings)
+	}
+}
+
+func TestGetPageContent(t *testing.T) {
+	client, srv := newTestClient(t)
+	page := srv.AddWorkspacePage("Notes")
+	srv.AddBlocks(page,
+		notiontest.Heading(1, "Title"),
+		notiontest.Paragraph("Body"),
+		notiontest.ToDo("Pending", false),
+		notiontest.BulletedItem("Point"),
+	)
+
+	content, err := client.GetPageContent(context.Background(), page)
+	if err != nil {
+		t.Fatalf("GetPageContent: %v", err)
+	}
+	if want := "Title\nBody\n[ ] Pending\n• Point"; content != want {
+		t.Errorf("content = %q, want %q", content, want)
+	}
+}
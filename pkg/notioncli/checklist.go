@@ -0,0 +1,120 @@
+package notioncli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// TaskChecklist returns the to-do blocks in the body of a task page, in page
+// order and including nested ones
+func (c *Client) TaskChecklist(ctx context.Context, taskID string) (*Checklist, error) {
+	blocks, err := c.ListBlocks(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	list := &Checklist{TaskID: taskID, Items: []ChecklistItem{}}
+	collectChecklist(blocks, list)
+	list.Progress = checklistProgress(list.Items)
+	return list, nil
+}
+
+// CheckTaskItem ticks or clears one item of a task's checklist, chosen by its
+// 1-based position or, when index is 0, by a case-insensitive match on its
+// text. It returns the checklist after the change.
+func (c *Client) CheckTaskItem(ctx context.Context, taskID string, index int, match string, checked bool) (*Checklist, error) {
+	list, err := c.TaskChecklist(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	item, err := list.find(index, match)
+	if err != nil {
+		return nil, err
+	}
+	if item.Checked != checked {
+		if _, err := c.UpdateBlock(ctx, item.ID, BlockUpdate{Checked: &checked}); err != nil {
+			return nil, err
+		}
+		item.Checked = checked
+	}
+	list.Progress = checklistProgress(list.Items)
+	return list, nil
+}
+
+// taskChecklistProgress fills in the checklist progress of a task
+func (c *Client) taskChecklistProgress(ctx context.Context, task *Task) error {
+	list, err := c.TaskChecklist(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	task.ChecklistProgress = list.Progress
+	return nil
+}
+
+// find returns the item at a 1-based position, or the one item whose text
+// contains match. An item whose whole text equals match wins over others
+// that only contain it.
+func (l *Checklist) find(index int, match string) (*ChecklistItem, error) {
+	if index != 0 {
+		if index < 0 || index > len(l.Items) {
+			return nil, fmt.Errorf("task %s has %d checklist items, no item %d", l.TaskID, len(l.Items), index)
+		}
+		return &l.Items[index-1], nil
+	}
+	if match == "" {
+		return nil, fmt.Errorf("an item number or text to match is required")
+	}
+
+	var found []*ChecklistItem
+	for i := range l.Items {
+		item := &l.Items[i]
+		if strings.EqualFold(item.Text, match) {
+			return item, nil
+		}
+		if strings.Contains(strings.ToLower(item.Text), strings.ToLower(match)) {
+			found = append(found, item)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("checklist item matching %q %w", match, ErrNotFound)
+	case 1:
+		return found[0], nil
+	}
+	names := make([]string, 0, len(found))
+	for _, item := range found {
+		names = append(names, fmt.Sprintf("%d. %s", item.Index, item.Text))
+	}
+	return nil, fmt.Errorf("%q matches %d checklist items (%s); give its number or a longer match", match, len(found), strings.Join(names, "; "))
+}
+
+// collectChecklist appends the to-do blocks of a block tree to a checklist,
+// numbering them as it goes
+func collectChecklist(blocks []BlockInfo, list *Checklist) {
+	for _, b := range blocks {
+		if b.Checked != nil {
+			list.Items = append(list.Items, ChecklistItem{
+				Index:   len(list.Items) + 1,
+				ID:      b.ID,
+				Text:    b.Text,
+				Checked: *b.Checked,
+			})
+		}
+		collectChecklist(b.Children, list)
+	}
+}
+
+// checklistProgress reports how many items are checked, as in "3/5", or ""
+// when there are none
+func checklistProgress(items []ChecklistItem) string {
+	if len(items) == 0 {
+		return ""
+	}
+	done := 0
+	for _, item := range items {
+		if item.Checked {
+			done++
+		}
+	}
+	return fmt.Sprintf("%d/%d", done, len(items))
+}
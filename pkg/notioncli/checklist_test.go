@@ -0,0 +1,101 @@
+package notioncli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jontk/notion-cli/internal/notiontest"
+)
+
+func TestTaskChecklist(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	task := srv.AddPage(db, map[string]any{"Title": "Release", "Status": "In Progress"})
+	ids := srv.AddBlocks(task,
+		notiontest.Heading(2, "Steps"),
+		notiontest.ToDo("Tag the release", true),
+		notiontest.ToDo("Write release notes", false),
+		notiontest.Paragraph("Then tell people."),
+	)
+	srv.AddBlocks(ids[2], notiontest.ToDo("Draft the release email", false))
+	ctx := context.Background()
+
+	list, err := client.TaskChecklist(ctx, task)
+	if err != nil {
+		t.Fatalf("TaskChecklist: %v", err)
+	}
+	if len(list.Items) != 3 || list.Progress != "1/3" {
+		t.Fatalf("checklist = %+v, want 3 items at 1/3", list)
+	}
+	if item := list.Items[2]; item.Index != 3 || item.Text != "Draft the release email" || item.Checked {
+		t.Errorf("nested item = %+v", item)
+	}
+
+	list, err = client.CheckTaskItem(ctx, task, 2, "", true)
+	if err != nil {
+		t.Fatalf("CheckTaskItem by index: %v", err)
+	}
+	if !list.Items[1].Checked || list.Progress != "2/3" {
+		t.Errorf("after checking item 2: %+v", list)
+	}
+
+	list, err = client.CheckTaskItem(ctx, task, 0, "EMAIL", true)
+	if err != nil {
+		t.Fatalf("CheckTaskItem by match: %v", err)
+	}
+	if list.Progress != "3/3" {
+		t.Errorf("progress = %q, want 3/3", list.Progress)
+	}
+
+	list, err = client.CheckTaskItem(ctx, task, 0, "tag the release", false)
+	if err != nil {
+		t.Fatalf("CheckTaskItem by exact match: %v", err)
+	}
+	if list.Items[0].Checked || list.Progress != "2/3" {
+		t.Errorf("after clearing item 1: %+v", list)
+	}
+	if list, err = client.TaskChecklist(ctx, task); err != nil || list.Progress != "2/3" {
+		t.Errorf("re-read progress = %v (%v), want 2/3", list, err)
+	}
+
+	if _, err := client.CheckTaskItem(ctx, task, 0, "release", true); err == nil {
+		t.Error("ambiguous match succeeded")
+	}
+	if _, err := client.CheckTaskItem(ctx, task, 0, "deploy", true); !IsNotFound(err) {
+		t.Errorf("unmatched item: err = %v, want not found", err)
+	}
+	if _, err := client.CheckTaskItem(ctx, task, 4, "", true); err == nil {
+		t.Error("checking item 4 of 3 succeeded")
+	}
+}
+
+func TestQueryTasksChecklist(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	task := srv.AddPage(db, map[string]any{"Title": "Release", "Status": "Todo"})
+	srv.AddBlocks(task, notiontest.ToDo("Tag", true), notiontest.ToDo("Announce", false))
+	srv.AddPage(db, map[string]any{"Title": "Plain", "Status": "Todo"})
+	ctx := context.Background()
+
+	tasks, err := client.QueryTasks(ctx, db, TaskQueryOptions{})
+	if err != nil {
+		t.Fatalf("QueryTasks: %v", err)
+	}
+	for _, task := range tasks {
+		if task.ChecklistProgress != "" {
+			t.Errorf("%s has progress %q without Checklist", task.Title, task.ChecklistProgress)
+		}
+	}
+
+	tasks, err = client.QueryTasks(ctx, db, TaskQueryOptions{Checklist: true})
+	if err != nil {
+		t.Fatalf("QueryTasks with Checklist: %v", err)
+	}
+	progress := map[string]string{}
+	for _, task := range tasks {
+		progress[task.Title] = task.ChecklistProgress
+	}
+	if progress["Release"] != "1/2" || progress["Plain"] != "" {
+		t.Errorf("progress = %v, want Release at 1/2 and Plain without", progress)
+	}
+}
@@ -0,0 +1,177 @@
+package notioncli
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/jomei/notionapi"
+)
+
+// Client reads and writes the posts, tasks and events databases, and the
+// schemas of any database shared with the integration. Once configured,
+// its methods may be called concurrently.
+type Client struct {
+	api        *notionapi.Client
+	apiOptions []notionapi.ClientOption
+	httpClient *http.Client
+	retries    int
+	token      string
+	http       *http.Client
+	version    string
+	baseURL    *url.URL
+	location   *time.Location
+	settings   Settings
+
+	mu               sync.Mutex
+	checkedDatabases map[string]bool
+	schemas          map[string]notionapi.PropertyConfigs
+	users            []User
+	userCachePath    string
+	userCacheTTL     time.Duration
+}
+
+// Settings holds the workspace-specific property names and defaults the
+// client relies on beyond the fixed database schemas
+type Settings struct {
+	// RepeatProperty is the rich text property holding a recurrence rule
+	RepeatProperty string
+	// SeriesProperty is the rich text property linking a generated
+	// occurrence to the page its series started from
+	SeriesProperty string
+	// DefaultTaskStatus is the status given to newly rolled task instances
+	DefaultTaskStatus string
+	// TaskStatuses groups the task statuses by meaning
+	TaskStatuses StatusGroups
+	// EventStatuses groups the event statuses by meaning
+	EventStatuses StatusGroups
+	// UIDProperty is the rich text property holding the iCalendar UID of
+	// events imported from other calendars
+	UIDProperty string
+	// ParentProperty is the task relation pointing at a task's parent
+	ParentProperty string
+	// BlockedByProperty is the task relation listing the tasks that have to
+	// be finished first
+	BlockedByProperty string
+	// AssigneeProperty is the task people property holding assignees
+	AssigneeProperty string
+	// Me is the email or name "me" stands for when resolving users
+	Me string
+}
+
+// DefaultSettings returns the settings matching the documented schemas
+func DefaultSettings() Settings {
+	return Settings{
+		RepeatProperty:    "Repeat",
+		SeriesProperty:    "Series",
+		DefaultTaskStatus: "Todo",
+		TaskStatuses: StatusGroups{
+			Open:       []string{"Todo"},
+			InProgress: []string{"In Progress", "Blocked"},
+			Done:       []string{"Done"},
+		},
+		EventStatuses: StatusGroups{
+			Open:      []string{"Scheduled"},
+			Done:      []string{"Completed"},
+			Cancelled: []string{"Cancelled"},
+		},
+		UIDProperty:       "UID",
+		ParentProperty:    "Parent",
+		BlockedByProperty: "Blocked By",
+		AssigneeProperty:  "Assignee",
+	}
+}
+
+// New returns a client for the given integration token, configured by opts
+func New(token string, opts ...Option) *Client {
+	c := &Client{
+		retries:  defaultRetries,
+		version:  defaultVersion,
+		baseURL:  defaultBaseURL,
+		location: time.Local,
+		settings: DefaultSettings(),
+
+		checkedDatabases: make(map[string]bool),
+		schemas:          make(map[string]notionapi.PropertyConfigs),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	// Rate limited requests are retried by retryTransport; notionapi's
+	// own retries would resend an empty body
+	hc := http.DefaultClient
+	if c.httpClient != nil {
+		hc = c.httpClient
+	}
+	retrying := *hc
+	retrying.Transport = &retryTransport{base: hc.Transport, retries: c.retries}
+	if c.baseURL.String() != defaultBaseURL.String() {
+		retrying.Transport = &rebaseTransport{base: retrying.Transport, to: c.baseURL}
+	}
+	// The version goes after apiOptions, so that notionapi's requests and
+	// the client's own always agree on it
+	apiOptions := []notionapi.ClientOption{
+		notionapi.WithHTTPClient(&retrying),
+		notionapi.WithRetry(1),
+	}
+	apiOptions = append(apiOptions, c.apiOptions...)
+	apiOptions = append(apiOptions, notionapi.WithVersion(c.version))
+	c.api = notionapi.NewClient(notionapi.Token(token), apiOptions...)
+	c.token, c.http = token, &retrying
+	c.apiOptions = nil
+	c.httpClient = nil
+	return c
+}
+
+// API returns the underlying notionapi client, for calls this package
+// doesn't cover
+func (c *Client) API() *notionapi.Client {
+	return c.api
+}
+
+// SetLocation sets the timezone used to resolve dates that carry no zone
+func (c *Client) SetLocation(loc *time.Location) {
+	if loc != nil {
+		c.location = loc
+	}
+}
+
+// Settings returns the client's settings with defaults filled in
+func (c *Client) Settings() Settings {
+	return c.settings
+}
+
+// SetSettings replaces the client's settings. Empty fields keep their
+// defaults, and status groups are checked against the schema again.
+func (c *Client) SetSettings(s Settings) {
+	defaults := DefaultSettings()
+	if s.RepeatProperty == "" {
+		s.RepeatProperty = defaults.RepeatProperty
+	}
+	if s.SeriesProperty == "" {
+		s.SeriesProperty = defaults.SeriesProperty
+	}
+	if s.DefaultTaskStatus == "" {
+		s.DefaultTaskStatus = defaults.DefaultTaskStatus
+	}
+	s.TaskStatuses = s.TaskStatuses.withDefaults(defaults.TaskStatuses)
+	s.EventStatuses = s.EventStatuses.withDefaults(defaults.EventStatuses)
+	if s.UIDProperty == "" {
+		s.UIDProperty = defaults.UIDProperty
+	}
+	if s.ParentProperty == "" {
+		s.ParentProperty = defaults.ParentProperty
+	}
+	if s.BlockedByProperty == "" {
+		s.BlockedByProperty = defaults.BlockedByProperty
+	}
+	if s.AssigneeProperty == "" {
+		s.AssigneeProperty = defaults.AssigneeProperty
+	}
+	c.settings = s
+
+	c.mu.Lock()
+	c.checkedDatabases = make(map[string]bool)
+	c.mu.Unlock()
+}
@@ -0,0 +1,73 @@
+package notioncli
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jomei/notionapi"
+	"github.com/jontk/notion-cli/internal/notiontest"
+)
+
+const testToken = "secret_test"
+
+// newTestClient returns a client talking to a fresh fake server. Dates are
+// read in UTC so that expectations don't depend on the machine running the
+// tests.
+func newTestClient(t *testing.T) (*Client, *notiontest.Server) {
+	t.Helper()
+	srv := notiontest.NewServer()
+	srv.Token = testToken
+	client := New(testToken, WithHTTPClient(srv.HTTPClient()), WithLocation(time.UTC))
+	return client, srv
+}
+
+func TestNewOptions(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	client := New(testToken,
+		WithLocation(loc),
+		WithSettings(Settings{AssigneeProperty: "Owner"}),
+		WithUserCache("", time.Hour),
+	)
+	if client.location != loc {
+		t.Errorf("location = %v, want %v", client.location, loc)
+	}
+	s := client.Settings()
+	if s.AssigneeProperty != "Owner" || s.ParentProperty != "Parent" {
+		t.Errorf("settings = %+v, want Owner as assignee and the default parent", s)
+	}
+	if client.userCacheTTL != time.Hour {
+		t.Errorf("user cache TTL = %v, want 1h", client.userCacheTTL)
+	}
+}
+
+func TestVersionAndBaseURL(t *testing.T) {
+	srv := notiontest.NewServer()
+	srv.AddUser("Ada Lovelace", "ada@example.com")
+	client := New(testToken,
+		WithHTTPClient(srv.HTTPClient()),
+		WithAPIOptions(notionapi.WithVersion("2021-05-13")),
+		WithVersion("2025-09-03"),
+		WithBaseURL(&url.URL{Scheme: "https", Host: "notion.example.com", Path: "/v1"}),
+	)
+	ctx := context.Background()
+
+	// ListUsers goes through notionapi, Search through the client's own
+	// requests
+	if _, err := client.ListUsers(ctx, true); err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+	if _, err := client.Search(ctx, SearchOptions{}); err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	for _, r := range srv.Requests() {
+		if r.Host != "notion.example.com" || r.Header.Get("Notion-Version") != "2025-09-03" {
+			t.Errorf("%s %s sent to %s with version %q, want notion.example.com and 2025-09-03",
+				r.Method, r.Path, r.Host, r.Header.Get("Notion-Version"))
+		}
+	}
+	if n := len(srv.Requests()); n != 2 {
+		t.Errorf("sent %d requests, want 2", n)
+	}
+}
@@ -0,0 +1,206 @@
+package notioncli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultEventLength is how long an event without an end is taken to block
+// when looking for conflicts and free time
+const defaultEventLength = 30 * time.Minute
+
+// ConflictError is returned when an event would overlap existing events
+type ConflictError struct {
+	Conflicts []Event
+}
+
+func (e *ConflictError) Error() string {
+	parts := make([]string, 0, len(e.Conflicts))
+	for _, event := range e.Conflicts {
+		parts = append(parts, fmt.Sprintf("%q (%s)", event.Title, event.Start))
+	}
+	return fmt.Sprintf("event overlaps %s; use --allow-conflict to schedule it anyway", strings.Join(parts, ", "))
+}
+
+// Conflict is a pair of overlapping events
+type Conflict struct {
+	Start  string  `json:"start"`
+	End    string  `json:"end"`
+	Events []Event `json:"events"`
+}
+
+// AttendeeConflicts lists the double bookings of one attendee
+type AttendeeConflicts struct {
+	Attendee  string     `json:"attendee"`
+	Conflicts []Conflict `json:"conflicts"`
+}
+
+// CheckConflicts returns the existing events that the event described by
+// input would overlap. When eventID is set the input is an update of that
+// event, which is left out of the comparison. Cancelled and all-day events
+// never conflict with timed ones, since they mark days rather than booked
+// time. With attendees given only events sharing one of them count.
+// Occurrences of recurring events count whether or not they have been
+// expanded into pages yet.
+func (c *Client) CheckConflicts(ctx context.Context, databaseID, eventID string, input EventInput) ([]Event, error) {
+	if eventID != "" {
+		var err error
+		input, err = c.completeEventTiming(ctx, eventID, input)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if input.Date == "" || c.settings.EventStatuses.IsCancelled(input.Status) {
+		return nil, nil
+	}
+
+	prop, err := c.eventDateProperty(input)
+	if err != nil {
+		return nil, err
+	}
+	format := c.formatTime
+	if prop.Dates {
+		format = func(t time.Time) string { return t.Format(dateLayout) }
+	}
+	candidate := Event{Start: format(prop.Start), AllDay: prop.Dates}
+	if !prop.End.IsZero() {
+		candidate.End = format(prop.End)
+	}
+	start, end, ok := c.blockedSpan(candidate)
+	if !ok {
+		return nil, nil
+	}
+
+	events, err := c.QueryEvents(ctx, databaseID, EventQueryOptions{
+		DateAfter:  c.dateInput(start.Add(-defaultEventLength), true),
+		DateBefore: c.dateInput(end, true),
+		Limit:      1000,
+	})
+	if err != nil {
+		return nil, err
+	}
+	pending, err := c.pendingOccurrences(ctx, databaseID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, pending...)
+
+	var conflicts []Event
+	for _, event := range events {
+		if eventID != "" && (sameID(event.ID, eventID) || sameID(event.SeriesID, eventID)) {
+			continue
+		}
+		if c.settings.EventStatuses.IsCancelled(event.Status) || event.AllDay != candidate.AllDay {
+			continue
+		}
+		if len(input.Attendees) > 0 && !sharesAttendee(event.Attendees, input.Attendees) {
+			continue
+		}
+		s, e, ok := c.blockedSpan(event)
+		if ok && s.Before(end) && start.Before(e) {
+			conflicts = append(conflicts, event)
+		}
+	}
+	return conflicts, nil
+}
+
+// FindConflicts reports, per attendee, the events between from and to that
+// overlap each other. Cancelled and all-day events are ignored.
+func (c *Client) FindConflicts(ctx context.Context, databaseID, from, to string) ([]AttendeeConflicts, error) {
+	events, err := c.QueryEvents(ctx, databaseID, EventQueryOptions{
+		DateAfter:  from,
+		DateBefore: to,
+		Limit:      1000,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type span struct {
+		event      Event
+		start, end time.Time
+	}
+	byAttendee := make(map[string][]span)
+	for _, event := range events {
+		if event.AllDay || c.settings.EventStatuses.IsCancelled(event.Status) {
+			continue
+		}
+		start, end, ok := c.blockedSpan(event)
+		if !ok {
+			continue
+		}
+		for _, attendee := range event.Attendees {
+			byAttendee[attendee] = append(byAttendee[attendee], span{event, start, end})
+		}
+	}
+
+	attendees := make([]string, 0, len(byAttendee))
+	for attendee := range byAttendee {
+		attendees = append(attendees, attendee)
+	}
+	sort.Strings(attendees)
+
+	report := []AttendeeConflicts{}
+	for _, attendee := range attendees {
+		spans := byAttendee[attendee]
+		sort.SliceStable(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+
+		var conflicts []Conflict
+		for i := range spans {
+			for j := i + 1; j < len(spans) && spans[j].start.Before(spans[i].end); j++ {
+				end := spans[i].end
+				if spans[j].end.Before(end) {
+					end = spans[j].end
+				}
+				conflicts = append(conflicts, Conflict{
+					Start:  c.formatTime(spans[j].start),
+					End:    c.formatTime(end),
+					Events: []Event{spans[i].event, spans[j].event},
+				})
+			}
+		}
+		if len(conflicts) > 0 {
+			report = append(report, AttendeeConflicts{Attendee: attendee, Conflicts: conflicts})
+		}
+	}
+	return report, nil
+}
+
+// blockedSpan returns the time an event blocks. All-day events cover whole
+// days and timed events without an end last defaultEventLength.
+func (c *Client) blockedSpan(event Event) (time.Time, time.Time, bool) {
+	if event.Start == "" {
+		return time.Time{}, time.Time{}, false
+	}
+	start, err := c.parseDate(event.Start)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	end := start
+	if event.End != "" {
+		if end, err = c.parseDate(event.End); err != nil {
+			return time.Time{}, time.Time{}, false
+		}
+	}
+
+	if event.AllDay {
+		return dayStart(start.Time), dayStart(end.Time).AddDate(0, 0, 1), true
+	}
+	if !end.Time.After(start.Time) {
+		return start.Time, start.Time.Add(defaultEventLength), true
+	}
+	return start.Time, end.Time, true
+}
+
+// sharesAttendee reports whether any of others is among attendees
+func sharesAttendee(attendees, others []string) bool {
+	for _, other := range others {
+		if hasAttendee(attendees, other) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package notioncli
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jontk/notion-cli/internal/notiontest"
+)
+
+// span is an AddPage value for a date range
+func span(start, end string) map[string]any {
+	return map[string]any{"date": map[string]any{"start": start, "end": end}}
+}
+
+func titles(events []Event) []string {
+	var out []string
+	for _, e := range events {
+		out = append(out, e.Title)
+	}
+	return out
+}
+
+func TestCheckConflicts(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Events", notiontest.EventsSchema())
+	standup := srv.AddPage(db, map[string]any{"Title": "Standup", "Date": span("2026-10-20T09:00:00Z", "2026-10-20T09:30:00Z"), "Attendees": []string{"Ada"}})
+	srv.AddPage(db, map[string]any{"Title": "Review", "Date": span("2026-10-20T10:00:00Z", "2026-10-20T11:00:00Z"), "Attendees": []string{"Grace"}})
+	srv.AddPage(db, map[string]any{"Title": "Lunch", "Date": span("2026-10-20T12:00:00Z", "2026-10-20T13:00:00Z"), "Status": "Cancelled"})
+	srv.AddPage(db, map[string]any{"Title": "Offsite", "Date": "2026-10-22"})
+	srv.AddPage(db, map[string]any{"Title": "Call", "Date": "2026-10-20T16:00:00Z"})
+	// A weekly series from the week before; only one occurrence, moved an
+	// hour later, has been expanded
+	sync := srv.AddPage(db, map[string]any{"Title": "Sync", "Date": span("2026-10-13T14:00:00Z", "2026-10-13T15:00:00Z"),
+		"Attendees": []string{"Ada"}, "Repeat": "FREQ=WEEKLY"})
+	srv.AddPage(db, map[string]any{"Title": "Sync", "Date": span("2026-10-27T15:00:00Z", "2026-10-27T16:00:00Z"),
+		"Attendees": []string{"Ada"}, "Series": sync})
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name    string
+		eventID string
+		input   EventInput
+		want    []string
+	}{
+		{"overlap", "", EventInput{Date: "2026-10-20 09:15", Duration: "30m"}, []string{"Standup"}},
+		{"back to back", "", EventInput{Date: "2026-10-20 09:30", Duration: "30m"}, nil},
+		{"spanning two", "", EventInput{Date: "2026-10-20 09:15", End: "10:30"}, []string{"Standup", "Review"}},
+		{"cancelled event", "", EventInput{Date: "2026-10-20 12:15", Duration: "30m"}, nil},
+		{"cancelled input", "", EventInput{Date: "2026-10-20 09:00", Duration: "1h", Status: "Cancelled"}, nil},
+		{"no end blocks 30m", "", EventInput{Date: "2026-10-20 16:15", Duration: "1h"}, []string{"Call"}},
+		{"all-day", "", EventInput{Date: "2026-10-22", AllDay: true}, []string{"Offsite"}},
+		{"all-day and timed", "", EventInput{Date: "2026-10-22 09:00", Duration: "1h"}, nil},
+		{"shared attendee", "", EventInput{Date: "2026-10-20 09:00", End: "11:00", Attendees: []string{"ada"}}, []string{"Standup"}},
+		{"other attendee", "", EventInput{Date: "2026-10-20 09:00", Duration: "30m", Attendees: []string{"Grace"}}, nil},
+		{"update of itself", standup, EventInput{Date: "2026-10-20 09:10"}, nil},
+		{"series start", "", EventInput{Date: "2026-10-13 14:30", Duration: "30m"}, []string{"Sync"}},
+		{"unexpanded occurrence", "", EventInput{Date: "2026-10-20 14:30", Duration: "30m"}, []string{"Sync"}},
+		{"occurrence for another attendee", "", EventInput{Date: "2026-10-20 14:30", Duration: "30m", Attendees: []string{"Grace"}}, nil},
+		{"expanded occurrence moved", "", EventInput{Date: "2026-10-27 14:00", Duration: "30m"}, nil},
+		{"expanded occurrence", "", EventInput{Date: "2026-10-27 15:30", Duration: "30m"}, []string{"Sync"}},
+		{"series moving", sync, EventInput{Date: "2026-10-13 14:30"}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			conflicts, err := client.CheckConflicts(ctx, db, tc.eventID, tc.input)
+			if err != nil {
+				t.Fatalf("CheckConflicts: %v", err)
+			}
+			if got := titles(conflicts); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("CheckConflicts = %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	conflicts, err := client.CheckConflicts(ctx, db, "", EventInput{Date: "2026-11-03 14:30", Duration: "30m"})
+	if err != nil || len(conflicts) != 1 {
+		t.Fatalf("CheckConflicts = %+v, %v, want the Sync occurrence", conflicts, err)
+	}
+	want := Event{Title: "Sync", Date: "2026-11-03T14:00:00Z", Start: "2026-11-03T14:00:00Z", End: "2026-11-03T15:00:00Z",
+		DurationMinutes: 60, Attendees: []string{"Ada"}, SeriesID: sync}
+	if got := conflicts[0]; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpanded occurrence = %+v, want %+v", got, want)
+	}
+}
+
+func TestFindConflicts(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Events", notiontest.EventsSchema())
+	srv.AddPage(db, map[string]any{"Title": "Standup", "Date": span("2026-10-20T09:00:00Z", "2026-10-20T09:30:00Z"), "Attendees": []string{"Ada"}})
+	srv.AddPage(db, map[string]any{"Title": "Pairing", "Date": span("2026-10-20T09:15:00Z", "2026-10-20T10:00:00Z"), "Attendees": []string{"Ada", "Grace"}})
+	srv.AddPage(db, map[string]any{"Title": "Review", "Date": span("2026-10-20T10:00:00Z", "2026-10-20T11:00:00Z"), "Attendees": []string{"Grace"}})
+	srv.AddPage(db, map[string]any{"Title": "Lunch", "Date": "2026-10-20T09:00:00Z", "Attendees": []string{"Grace"}, "Status": "Cancelled"})
+	srv.AddPage(db, map[string]any{"Title": "Offsite", "Date": "2026-10-20", "Attendees": []string{"Ada"}})
+	srv.AddPage(db, map[string]any{"Title": "Planning", "Date": "2026-10-22T09:00:00Z", "Attendees": []string{"Ada"}})
+	srv.AddPage(db, map[string]any{"Title": "Planning overlap", "Date": "2026-10-22T09:20:00Z", "Attendees": []string{"Ada"}})
+
+	report, err := client.FindConflicts(context.Background(), db, "2026-10-20", "2026-10-21")
+	if err != nil {
+		t.Fatalf("FindConflicts: %v", err)
+	}
+	if len(report) != 1 || report[0].Attendee != "Ada" || len(report[0].Conflicts) != 1 {
+		t.Fatalf("FindConflicts = %+v, want one conflict for Ada", report)
+	}
+	c := report[0].Conflicts[0]
+	if c.Start != "2026-10-20T09:15:00Z" || c.End != "2026-10-20T09:30:00Z" || !reflect.DeepEqual(titles(c.Events), []string{"Standup", "Pairing"}) {
+		t.Errorf("conflict = %s-%s %q, want Standup and Pairing from 09:15 to 09:30", c.Start, c.End, titles(c.Events))
+	}
+
+	report, err = client.FindConflicts(context.Background(), db, "2026-10-22", "2026-10-22")
+	if err != nil {
+		t.Fatalf("FindConflicts: %v", err)
+	}
+	if len(report) != 1 || len(report[0].Conflicts) != 1 || report[0].Conflicts[0].End != "2026-10-22T09:30:00Z" {
+		t.Errorf("FindConflicts with events without an end = %+v, want one conflict until 09:30", report)
+	}
+}
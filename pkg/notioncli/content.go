@@ -0,0 +1,381 @@
+package notioncli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jomei/notionapi"
+)
+
+// BlockUpdate lists the changes to make to a block. Nil fields are left as
+// they are.
+type BlockUpdate struct {
+	// Markdown replaces the text of the block. It is read as inline
+	// Markdown, except in code blocks, which take it as it is.
+	Markdown *string
+	// Checked ticks or clears a to-do
+	Checked *bool
+}
+
+// ListBlocks returns the blocks of a page or block, with the blocks nested
+// in them
+func (c *Client) ListBlocks(ctx context.Context, parentID string) ([]BlockInfo, error) {
+	blocks, err := c.getAllBlocks(ctx, notionapi.BlockID(parentID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blocks: %w", err)
+	}
+	infos := make([]BlockInfo, 0, len(blocks))
+	for _, block := range blocks {
+		info := blockInfo(block)
+		if block.GetHasChildren() {
+			if info.Children, err = c.ListBlocks(ctx, info.ID); err != nil {
+				return nil, err
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// AppendMarkdown adds Markdown to the end of a page or block, as the blocks
+// MarkdownToBlocks reads from it
+func (c *Client) AppendMarkdown(ctx context.Context, parentID, md string) ([]BlockInfo, error) {
+	blocks := MarkdownToBlocks(md)
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("no content to add")
+	}
+	return c.appendBlocks(ctx, parentID, "", blocks)
+}
+
+// InsertMarkdown adds Markdown right after a block, under the same parent
+func (c *Client) InsertMarkdown(ctx context.Context, afterID, md string) ([]BlockInfo, error) {
+	blocks := MarkdownToBlocks(md)
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("no content to add")
+	}
+	after, err := c.api.Block.Get(ctx, notionapi.BlockID(afterID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block: %w", err)
+	}
+	parentID, err := blockParent(after)
+	if err != nil {
+		return nil, err
+	}
+	return c.appendBlocks(ctx, parentID, string(after.GetID()), blocks)
+}
+
+// appendBlocks adds blocks to a parent, after the given block or at the
+// end, in as many requests as Notion needs
+func (c *Client) appendBlocks(ctx context.Context, parentID, afterID string, blocks []notionapi.Block) ([]BlockInfo, error) {
+	added := make([]BlockInfo, 0, len(blocks))
+	for start := 0; start < len(blocks); start += 100 {
+		end := start + 100
+		if end > len(blocks) {
+			end = len(blocks)
+		}
+		resp, err := c.api.Block.AppendChildren(ctx, notionapi.BlockID(parentID), &notionapi.AppendBlockChildrenRequest{
+			After:    notionapi.BlockID(afterID),
+			Children: blocks[start:end],
+		})
+		if err != nil {
+			return added, fmt.Errorf("failed to append blocks: %w", err)
+		}
+		for _, block := range resp.Results {
+			added = append(added, blockInfo(block))
+		}
+		if afterID != "" && len(added) > 0 {
+			afterID = added[len(added)-1].ID
+		}
+	}
+	return added, nil
+}
+
+// UpdateBlock changes the text of a block or ticks a to-do
+func (c *Client) UpdateBlock(ctx context.Context, blockID string, update BlockUpdate) (*BlockInfo, error) {
+	block, err := c.api.Block.Get(ctx, notionapi.BlockID(blockID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block: %w", err)
+	}
+
+	text := func(current []notionapi.RichText) []notionapi.RichText {
+		if update.Markdown == nil {
+			return current
+		}
+		return inlineText(*update.Markdown)
+	}
+	if update.Checked != nil && block.GetType() != notionapi.BlockTypeToDo {
+		return nil, fmt.Errorf("block %s is a %s block, not a to-do", blockID, block.GetType())
+	}
+
+	req := &notionapi.BlockUpdateRequest{}
+	switch b := block.(type) {
+	case *notionapi.ParagraphBlock:
+		req.Paragraph = &notionapi.Paragraph{RichText: text(b.Paragraph.RichText)}
+	case *notionapi.Heading1Block:
+		req.Heading1 = &notionapi.Heading{RichText: text(b.Heading1.RichText)}
+	case *notionapi.Heading2Block:
+		req.Heading2 = &notionapi.Heading{RichText: text(b.Heading2.RichText)}
+	case *notionapi.Heading3Block:
+		req.Heading3 = &notionapi.Heading{RichText: text(b.Heading3.RichText)}
+	case *notionapi.BulletedListItemBlock:
+		req.BulletedListItem = &notionapi.ListItem{RichText: text(b.BulletedListItem.RichText)}
+	case *notionapi.NumberedListItemBlock:
+		req.NumberedListItem = &notionapi.ListItem{RichText: text(b.NumberedListItem.RichText)}
+	case *notionapi.ToDoBlock:
+		checked := b.ToDo.Checked
+		if update.Checked != nil {
+			checked = *update.Checked
+		}
+		req.ToDo = &notionapi.ToDo{RichText: text(b.ToDo.RichText), Checked: checked}
+	case *notionapi.ToggleBlock:
+		req.Toggle = &notionapi.Toggle{RichText: text(b.Toggle.RichText)}
+	case *notionapi.QuoteBlock:
+		req.Quote = &notionapi.Quote{RichText: text(b.Quote.RichText)}
+	case *notionapi.CalloutBlock:
+		req.Callout = &notionapi.Callout{RichText: text(b.Callout.RichText)}
+	case *notionapi.CodeBlock:
+		code := b.Code.RichText
+		if update.Markdown != nil {
+			code = appendText(nil, *update.Markdown, notionapi.Annotations{}, "")
+		}
+		req.Code = &notionapi.Code{RichText: code, Language: b.Code.Language}
+	default:
+		return nil, fmt.Errorf("block %s is a %s block, which has no text to change", blockID, block.GetType())
+	}
+
+	updated, err := c.api.Block.Update(ctx, notionapi.BlockID(blockID), req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update block: %w", err)
+	}
+	info := blockInfo(updated)
+	return &info, nil
+}
+
+// DeleteBlock moves a block and the blocks nested in it to the trash
+func (c *Client) DeleteBlock(ctx context.Context, blockID string) (*BlockInfo, error) {
+	block, err := c.api.Block.Delete(ctx, notionapi.BlockID(blockID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete block: %w", err)
+	}
+	info := blockInfo(block)
+	return &info, nil
+}
+
+// MoveBlock moves a block, with the blocks nested in it, to the end of
+// another page or block, or right after afterID when that is given. The API
+// can't move blocks, so the block is copied and the original deleted: the
+// copy has a new ID, and only blocks with text, dividers and callouts can be
+// moved.
+func (c *Client) MoveBlock(ctx context.Context, blockID, parentID, afterID string) (*BlockInfo, error) {
+	block, err := c.api.Block.Get(ctx, notionapi.BlockID(blockID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block: %w", err)
+	}
+	if afterID != "" {
+		after, err := c.api.Block.Get(ctx, notionapi.BlockID(afterID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block: %w", err)
+		}
+		afterParent, err := blockParent(after)
+		if err != nil {
+			return nil, err
+		}
+		if parentID != "" && !sameID(parentID, afterParent) {
+			return nil, fmt.Errorf("block %s is not in %s", afterID, parentID)
+		}
+		parentID = afterParent
+	}
+	if parentID == "" {
+		return nil, fmt.Errorf("a page or block to move to is required")
+	}
+
+	tree, err := c.blockTree(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range append([]string{string(block.GetID())}, tree.descendants()...) {
+		if sameID(id, parentID) || (afterID != "" && sameID(id, afterID)) {
+			return nil, fmt.Errorf("can't move a block into or next to itself")
+		}
+	}
+
+	added, err := c.appendBlocks(ctx, parentID, afterID, []notionapi.Block{tree.copy})
+	if err != nil {
+		return nil, err
+	}
+	moved := added[0]
+	if moved.Children, err = c.copyChildren(ctx, moved.ID, tree.children); err != nil {
+		return nil, fmt.Errorf("block copied to %s but its children were not: %w", moved.ID, err)
+	}
+	if _, err := c.api.Block.Delete(ctx, block.GetID()); err != nil {
+		return nil, fmt.Errorf("block copied to %s but the original was not deleted: %w", moved.ID, err)
+	}
+	return &moved, nil
+}
+
+// blockNode is a block read for copying: its ID, a copy without it, and the
+// blocks nested in it
+type blockNode struct {
+	id       string
+	copy     notionapi.Block
+	children []blockNode
+}
+
+// blockTree reads a block and the blocks nested in it
+func (c *Client) blockTree(ctx context.Context, block notionapi.Block) (blockNode, error) {
+	copied, err := copyBlock(block)
+	if err != nil {
+		return blockNode{}, err
+	}
+	node := blockNode{id: string(block.GetID()), copy: copied}
+	if !block.GetHasChildren() {
+		return node, nil
+	}
+	children, err := c.getAllBlocks(ctx, block.GetID())
+	if err != nil {
+		return blockNode{}, fmt.Errorf("failed to list blocks: %w", err)
+	}
+	for _, child := range children {
+		n, err := c.blockTree(ctx, child)
+		if err != nil {
+			return blockNode{}, err
+		}
+		node.children = append(node.children, n)
+	}
+	return node, nil
+}
+
+// descendants returns the IDs of the blocks nested in a node
+func (n blockNode) descendants() []string {
+	var ids []string
+	for _, child := range n.children {
+		ids = append(ids, child.id)
+		ids = append(ids, child.descendants()...)
+	}
+	return ids
+}
+
+// copyChildren adds copies of nodes to a parent, level by level
+func (c *Client) copyChildren(ctx context.Context, parentID string, nodes []blockNode) ([]BlockInfo, error) {
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+	blocks := make([]notionapi.Block, 0, len(nodes))
+	for _, n := range nodes {
+		blocks = append(blocks, n.copy)
+	}
+	added, err := c.appendBlocks(ctx, parentID, "", blocks)
+	if err != nil {
+		return nil, err
+	}
+	for i := range added {
+		if added[i].Children, err = c.copyChildren(ctx, added[i].ID, nodes[i].children); err != nil {
+			return nil, err
+		}
+	}
+	return added, nil
+}
+
+// copyBlock returns a block with the content of another, to be added
+// elsewhere
+func copyBlock(block notionapi.Block) (notionapi.Block, error) {
+	basic := basicBlock(block.GetType())
+	switch b := block.(type) {
+	case *notionapi.ParagraphBlock:
+		return &notionapi.ParagraphBlock{BasicBlock: basic, Paragraph: notionapi.Paragraph{RichText: b.Paragraph.RichText, Color: b.Paragraph.Color}}, nil
+	case *notionapi.Heading1Block:
+		return &notionapi.Heading1Block{BasicBlock: basic, Heading1: copyHeading(b.Heading1)}, nil
+	case *notionapi.Heading2Block:
+		return &notionapi.Heading2Block{BasicBlock: basic, Heading2: copyHeading(b.Heading2)}, nil
+	case *notionapi.Heading3Block:
+		return &notionapi.Heading3Block{BasicBlock: basic, Heading3: copyHeading(b.Heading3)}, nil
+	case *notionapi.BulletedListItemBlock:
+		return &notionapi.BulletedListItemBlock{BasicBlock: basic, BulletedListItem: notionapi.ListItem{RichText: b.BulletedListItem.RichText, Color: b.BulletedListItem.Color}}, nil
+	case *notionapi.NumberedListItemBlock:
+		return &notionapi.NumberedListItemBlock{BasicBlock: basic, NumberedListItem: notionapi.ListItem{RichText: b.NumberedListItem.RichText, Color: b.NumberedListItem.Color}}, nil
+	case *notionapi.ToDoBlock:
+		return &notionapi.ToDoBlock{BasicBlock: basic, ToDo: notionapi.ToDo{RichText: b.ToDo.RichText, Checked: b.ToDo.Checked, Color: b.ToDo.Color}}, nil
+	case *notionapi.ToggleBlock:
+		return &notionapi.ToggleBlock{BasicBlock: basic, Toggle: notionapi.Toggle{RichText: b.Toggle.RichText, Color: b.Toggle.Color}}, nil
+	case *notionapi.QuoteBlock:
+		return &notionapi.QuoteBlock{BasicBlock: basic, Quote: notionapi.Quote{RichText: b.Quote.RichText, Color: b.Quote.Color}}, nil
+	case *notionapi.CodeBlock:
+		return &notionapi.CodeBlock{BasicBlock: basic, Code: notionapi.Code{RichText: b.Code.RichText, Caption: b.Code.Caption, Language: b.Code.Language}}, nil
+	case *notionapi.CalloutBlock:
+		return &notionapi.CalloutBlock{BasicBlock: basic, Callout: notionapi.Callout{RichText: b.Callout.RichText, Icon: b.Callout.Icon, Color: b.Callout.Color}}, nil
+	case *notionapi.DividerBlock:
+		return &notionapi.DividerBlock{BasicBlock: basic}, nil
+	}
+	return nil, fmt.Errorf("can't move a %s block", block.GetType())
+}
+
+func copyHeading(h notionapi.Heading) notionapi.Heading {
+	return notionapi.Heading{RichText: h.RichText, Color: h.Color, IsToggleable: h.IsToggleable}
+}
+
+// blockInfo describes a block, without the blocks nested in it
+func blockInfo(block notionapi.Block) BlockInfo {
+	info := BlockInfo{
+		ID:       string(block.GetID()),
+		Type:     string(block.GetType()),
+		Text:     extractRichText(blockRichText(block)),
+		Archived: block.GetArchived(),
+	}
+	if b, ok := block.(*notionapi.ToDoBlock); ok {
+		checked := b.ToDo.Checked
+		info.Checked = &checked
+	}
+	return info
+}
+
+// blockRichText returns the text of the block types that have one
+func blockRichText(block notionapi.Block) []notionapi.RichText {
+	switch b := block.(type) {
+	case *notionapi.ParagraphBlock:
+		return b.Paragraph.RichText
+	case *notionapi.Heading1Block:
+		return b.Heading1.RichText
+	case *notionapi.Heading2Block:
+		return b.Heading2.RichText
+	case *notionapi.Heading3Block:
+		return b.Heading3.RichText
+	case *notionapi.BulletedListItemBlock:
+		return b.BulletedListItem.RichText
+	case *notionapi.NumberedListItemBlock:
+		return b.NumberedListItem.RichText
+	case *notionapi.ToDoBlock:
+		return b.ToDo.RichText
+	case *notionapi.ToggleBlock:
+		return b.Toggle.RichText
+	case *notionapi.QuoteBlock:
+		return b.Quote.RichText
+	case *notionapi.CodeBlock:
+		return b.Code.RichText
+	case *notionapi.CalloutBlock:
+		return b.Callout.RichText
+	}
+	return nil
+}
+
+// blockParent returns the ID of the page or block a block sits in. The
+// parent is read from the block's JSON form, which has it for every type.
+func blockParent(block notionapi.Block) (string, error) {
+	data, err := json.Marshal(block)
+	if err != nil {
+		return "", err
+	}
+	var b struct {
+		Parent notionapi.Parent `json:"parent"`
+	}
+	if err := json.Unmarshal(data, &b); err != nil {
+		return "", err
+	}
+	switch {
+	case b.Parent.BlockID != "":
+		return string(b.Parent.BlockID), nil
+	case b.Parent.PageID != "":
+		return string(b.Parent.PageID), nil
+	}
+	return "", fmt.Errorf("block %s is not in a page or block", block.GetID())
+}
@@ -0,0 +1,196 @@
+package notioncli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jontk/notion-cli/internal/notiontest"
+)
+
+// texts returns the text of each block, nested blocks indented below
+// their parent
+func texts(blocks []BlockInfo) []string {
+	var out []string
+	for _, b := range blocks {
+		out = append(out, b.Text)
+		for _, child := range texts(b.Children) {
+			out = append(out, "  "+child)
+		}
+	}
+	return out
+}
+
+func TestListBlocks(t *testing.T) {
+	client, srv := newTestClient(t)
+	page := srv.AddWorkspacePage("Plan")
+	ids := srv.AddBlocks(page,
+		notiontest.Heading(2, "Checklist"),
+		notiontest.ToDo("Write tests", true),
+	)
+	srv.AddBlocks(ids[1], notiontest.Paragraph("Fakes first"))
+
+	blocks, err := client.ListBlocks(context.Background(), page)
+	if err != nil {
+		t.Fatalf("ListBlocks: %v", err)
+	}
+	if got, want := strings.Join(texts(blocks), "|"), "Checklist|Write tests|  Fakes first"; got != want {
+		t.Errorf("blocks = %q, want %q", got, want)
+	}
+	if blocks[0].Type != "heading_2" || blocks[0].Checked != nil {
+		t.Errorf("heading = %+v", blocks[0])
+	}
+	if blocks[1].Checked == nil || !*blocks[1].Checked {
+		t.Errorf("to-do = %+v, want it checked", blocks[1])
+	}
+}
+
+func TestAppendAndInsertMarkdown(t *testing.T) {
+	client, srv := newTestClient(t)
+	page := srv.AddWorkspacePage("Notes")
+	ctx := context.Background()
+
+	var md strings.Builder
+	for i := 0; i < 150; i++ {
+		md.WriteString("- item\n")
+	}
+	added, err := client.AppendMarkdown(ctx, page, "# Items\n\n"+md.String())
+	if err != nil {
+		t.Fatalf("AppendMarkdown: %v", err)
+	}
+	if len(added) != 151 {
+		t.Fatalf("appended %d blocks, want 151", len(added))
+	}
+	appends := 0
+	for _, r := range srv.Requests() {
+		if r.Method == "PATCH" && r.Path == "blocks/"+page+"/children" {
+			appends++
+		}
+	}
+	if appends != 2 {
+		t.Errorf("sent %d append requests, want 2 of at most 100 blocks", appends)
+	}
+
+	inserted, err := client.InsertMarkdown(ctx, added[0].ID, "Intro.\n\nMore intro.")
+	if err != nil {
+		t.Fatalf("InsertMarkdown: %v", err)
+	}
+	if len(inserted) != 2 {
+		t.Fatalf("inserted %d blocks, want 2", len(inserted))
+	}
+	blocks, err := client.ListBlocks(ctx, page)
+	if err != nil {
+		t.Fatalf("ListBlocks: %v", err)
+	}
+	if got := strings.Join(texts(blocks[:4]), "|"); got != "Items|Intro.|More intro.|item" {
+		t.Errorf("page starts %q, want the paragraphs after the heading", got)
+	}
+
+	if _, err := client.AppendMarkdown(ctx, page, "  \n"); err == nil {
+		t.Error("AppendMarkdown without content succeeded")
+	}
+}
+
+func TestUpdateBlock(t *testing.T) {
+	client, srv := newTestClient(t)
+	page := srv.AddWorkspacePage("Plan")
+	ids := srv.AddBlocks(page,
+		notiontest.ToDo("Write tests", false),
+		notiontest.Paragraph("Draft"),
+	)
+	ctx := context.Background()
+
+	checked := true
+	block, err := client.UpdateBlock(ctx, ids[0], BlockUpdate{Checked: &checked})
+	if err != nil {
+		t.Fatalf("UpdateBlock: %v", err)
+	}
+	if block.Checked == nil || !*block.Checked || block.Text != "Write tests" {
+		t.Errorf("to-do = %+v, want it checked with its text kept", block)
+	}
+
+	text := "Final **version**"
+	block, err = client.UpdateBlock(ctx, ids[1], BlockUpdate{Markdown: &text})
+	if err != nil {
+		t.Fatalf("UpdateBlock: %v", err)
+	}
+	if block.Text != "Final version" {
+		t.Errorf("text = %q, want %q", block.Text, "Final version")
+	}
+	md, err := client.GetPageMarkdown(ctx, page)
+	if err != nil {
+		t.Fatalf("GetPageMarkdown: %v", err)
+	}
+	if want := "- [x] Write tests\n\nFinal **version**"; md != want {
+		t.Errorf("page = %q, want %q", md, want)
+	}
+
+	if _, err := client.UpdateBlock(ctx, ids[1], BlockUpdate{Checked: &checked}); err == nil {
+		t.Error("UpdateBlock ticked a paragraph")
+	}
+}
+
+func TestDeleteBlock(t *testing.T) {
+	client, srv := newTestClient(t)
+	page := srv.AddWorkspacePage("Plan")
+	ids := srv.AddBlocks(page, notiontest.Paragraph("Keep"), notiontest.Paragraph("Drop"))
+	ctx := context.Background()
+
+	block, err := client.DeleteBlock(ctx, ids[1])
+	if err != nil {
+		t.Fatalf("DeleteBlock: %v", err)
+	}
+	if !block.Archived {
+		t.Errorf("deleted block = %+v, want it archived", block)
+	}
+	blocks, err := client.ListBlocks(ctx, page)
+	if err != nil {
+		t.Fatalf("ListBlocks: %v", err)
+	}
+	if got := strings.Join(texts(blocks), "|"); got != "Keep" {
+		t.Errorf("blocks = %q, want only Keep", got)
+	}
+}
+
+func TestMoveBlock(t *testing.T) {
+	client, srv := newTestClient(t)
+	page := srv.AddWorkspacePage("Plan")
+	ids := srv.AddBlocks(page,
+		notiontest.Paragraph("One"),
+		notiontest.ToDo("Two", true),
+		notiontest.Paragraph("Three"),
+	)
+	srv.AddBlocks(ids[1], notiontest.Paragraph("Detail"))
+	other := srv.AddWorkspacePage("Archive")
+	ctx := context.Background()
+
+	moved, err := client.MoveBlock(ctx, ids[1], "", ids[2])
+	if err != nil {
+		t.Fatalf("MoveBlock: %v", err)
+	}
+	if moved.ID == ids[1] || moved.Checked == nil || !*moved.Checked {
+		t.Errorf("moved block = %+v, want a checked copy", moved)
+	}
+	blocks, err := client.ListBlocks(ctx, page)
+	if err != nil {
+		t.Fatalf("ListBlocks: %v", err)
+	}
+	if got := strings.Join(texts(blocks), "|"); got != "One|Three|Two|  Detail" {
+		t.Errorf("blocks = %q, want Two and its child after Three", got)
+	}
+
+	if _, err := client.MoveBlock(ctx, ids[0], other, ""); err != nil {
+		t.Fatalf("MoveBlock to another page: %v", err)
+	}
+	if blocks, _ = client.ListBlocks(ctx, other); len(blocks) != 1 || blocks[0].Text != "One" {
+		t.Errorf("other page = %+v, want One", blocks)
+	}
+
+	child := moved.Children[0].ID
+	if _, err := client.MoveBlock(ctx, moved.ID, child, ""); err == nil {
+		t.Error("MoveBlock into its own child succeeded")
+	}
+	if _, err := client.MoveBlock(ctx, ids[2], "", ""); err == nil {
+		t.Error("MoveBlock without a destination succeeded")
+	}
+}
@@ -0,0 +1,140 @@
+package notioncli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jomei/notionapi"
+)
+
+// DatabaseListOptions holds options for listing databases
+type DatabaseListOptions struct {
+	// Query matches database titles
+	Query string
+	// Sort is "last_edited" for the most recently edited first
+	Sort string
+}
+
+// ListDatabases lists all databases accessible to the integration
+func (c *Client) ListDatabases(ctx context.Context, opts DatabaseListOptions) ([]DatabaseInfo, error) {
+	databases := []DatabaseInfo{}
+	err := c.search(ctx, SearchOptions{Query: opts.Query, Type: "database", Sort: opts.Sort}, func(obj notionapi.Object) bool {
+		if db, ok := obj.(*notionapi.Database); ok {
+			databases = append(databases, DatabaseInfo{
+				ID:    string(db.ID),
+				Title: extractRichText(db.Title),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return databases, nil
+}
+
+// GetSchema retrieves the schema of a database
+func (c *Client) GetSchema(ctx context.Context, databaseID string) (*Schema, error) {
+	db, err := c.api.Database.Get(ctx, notionapi.DatabaseID(databaseID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	return schemaInfo(db), nil
+}
+
+// schemaInfo describes a database and its properties
+func schemaInfo(db *notionapi.Database) *Schema {
+	parentType, parentID := parentRef(db.Parent)
+	schema := &Schema{
+		ID:          string(db.ID),
+		Title:       extractRichText(db.Title),
+		Description: extractRichText(db.Description),
+		Icon:        iconText(db.Icon),
+		ParentType:  parentType,
+		ParentID:    parentID,
+		URL:         db.URL,
+		Properties:  make(map[string]PropertyInfo),
+	}
+
+	for name, prop := range db.Properties {
+		propInfo := PropertyInfo{
+			ID:   string(prop.GetID()),
+			Type: string(prop.GetType()),
+		}
+
+		switch p := prop.(type) {
+		case *notionapi.SelectPropertyConfig:
+			propInfo.Options = optionInfo(p.Select.Options)
+		case *notionapi.MultiSelectPropertyConfig:
+			propInfo.Options = optionInfo(p.MultiSelect.Options)
+		case *notionapi.StatusPropertyConfig:
+			propInfo.Options = optionInfo(p.Status.Options)
+			names := make(map[string]string, len(p.Status.Options))
+			for _, opt := range p.Status.Options {
+				names[string(opt.ID)] = opt.Name
+			}
+			for _, g := range p.Status.Groups {
+				group := StatusGroupInfo{
+					ID:      string(g.ID),
+					Name:    g.Name,
+					Color:   g.Color,
+					Options: make([]string, 0, len(g.OptionIDs)),
+				}
+				for _, id := range g.OptionIDs {
+					group.Options = append(group.Options, names[string(id)])
+				}
+				propInfo.Groups = append(propInfo.Groups, group)
+			}
+		case *notionapi.NumberPropertyConfig:
+			propInfo.Format = string(p.Number.Format)
+		case *notionapi.RelationPropertyConfig:
+			propInfo.Relation = &RelationInfo{
+				DatabaseID:     string(p.Relation.DatabaseID),
+				Type:           string(p.Relation.Type),
+				SyncedProperty: p.Relation.SyncedPropertyName,
+			}
+		case *notionapi.RollupPropertyConfig:
+			propInfo.Rollup = &RollupInfo{
+				Relation: p.Rollup.RelationPropertyName,
+				Property: p.Rollup.RollupPropertyName,
+				Function: string(p.Rollup.Function),
+			}
+		case *notionapi.FormulaPropertyConfig:
+			propInfo.Expression = p.Formula.Expression
+		}
+
+		schema.Properties[name] = propInfo
+	}
+
+	return schema
+}
+
+func optionInfo(options []notionapi.Option) []OptionInfo {
+	out := make([]OptionInfo, 0, len(options))
+	for _, opt := range options {
+		out = append(out, OptionInfo{ID: string(opt.ID), Name: opt.Name, Color: string(opt.Color)})
+	}
+	return out
+}
+
+// StatusOptions returns the options of a database's Status property in the
+// order Notion lists them
+func (c *Client) StatusOptions(ctx context.Context, databaseID string) ([]string, error) {
+	return c.PropertyOptions(ctx, databaseID, "Status")
+}
+
+// PropertyOptions returns the options of a select, multi-select or status
+// property in the order Notion lists them
+func (c *Client) PropertyOptions(ctx context.Context, databaseID, property string) ([]string, error) {
+	schema, err := c.GetSchema(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	prop, ok := schema.Properties[property]
+	if !ok {
+		return nil, nil
+	}
+	return optionNames(prop), nil
+}
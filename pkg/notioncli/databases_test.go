@@ -0,0 +1,135 @@
+package notioncli
+
+import (
+	"context"
+	"github.com/jontk/notion-cli/internal/notiontest"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestListDatabases(t *testing.T) {
+	client, srv := newTestClient(t)
+	srv.MaxPageSize = 2
+	tasks := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	events := srv.AddDatabase("Events", notiontest.EventsSchema())
+	srv.AddWorkspacePage("Not a database")
+	posts := srv.AddDatabase("Posts", notiontest.PostsSchema())
+	archive := srv.AddDatabase("Task archive", notiontest.TasksSchema())
+	srv.AddPage(tasks, map[string]any{"Title": "Touch the tasks database's page"})
+	ctx := context.Background()
+
+	dbs, err := client.ListDatabases(ctx, DatabaseListOptions{})
+	if err != nil {
+		t.Fatalf("ListDatabases: %v", err)
+	}
+	sort.Slice(dbs, func(i, j int) bool { return dbs[i].Title < dbs[j].Title })
+	want := []DatabaseInfo{
+		{ID: events, Title: "Events"},
+		{ID: posts, Title: "Posts"},
+		{ID: archive, Title: "Task archive"},
+		{ID: tasks, Title: "Tasks"},
+	}
+	if !reflect.DeepEqual(dbs, want) {
+		t.Errorf("ListDatabases = %+v, want %+v", dbs, want)
+	}
+
+	dbs, err = client.ListDatabases(ctx, DatabaseListOptions{Query: "task", Sort: "last_edited"})
+	if err != nil {
+		t.Fatalf("ListDatabases: %v", err)
+	}
+	want = []DatabaseInfo{{ID: archive, Title: "Task archive"}, {ID: tasks, Title: "Tasks"}}
+	if !reflect.DeepEqual(dbs, want) {
+		t.Errorf("ListDatabases matching task = %+v, want %+v", dbs, want)
+	}
+
+	if _, err := client.ListDatabases(ctx, DatabaseListOptions{Sort: "title"}); err == nil {
+		t.Error("ListDatabases with an unknown sort succeeded")
+	}
+}
+
+func TestGetSchema(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+
+	schema, err := client.GetSchema(context.Background(), db)
+	if err != nil {
+		t.Fatalf("GetSchema: %v", err)
+	}
+	if len(schema.Properties) != len(notiontest.TasksSchema()) {
+		t.Errorf("schema has %d properties, want %d", len(schema.Properties), len(notiontest.TasksSchema()))
+	}
+	for name, typ := range map[string]string{
+		"Title": "title", "Status": "status", "Priority": "select", "Due Date": "date",
+		"Tags": "multi_select", "Notes": "rich_text", "Parent": "relation", "Assignee": "people",
+	} {
+		if got := schema.Properties[name].Type; got != typ {
+			t.Errorf("%s has type %q, want %q", name, got, typ)
+		}
+	}
+	for name, want := range map[string][]string{
+		"Status":   {"Todo", "In Progress", "Blocked", "Done"},
+		"Priority": {"High", "Medium", "Low"},
+		"Tags":     {"urgent", "review"},
+	} {
+		if got := optionNames(schema.Properties[name]); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s options = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestGetSchemaDetails(t *testing.T) {
+	client, srv := newTestClient(t)
+	schema := notiontest.TasksSchema()
+	schema["Estimate"] = notiontest.Number()
+	schema["Label"] = notiontest.Formula(`prop("Title")`)
+	schema["Parent estimate"] = notiontest.Rollup("Parent", "Estimate", "sum")
+	db := srv.AddDatabase("Tasks", schema)
+	srv.SetIcon(db, "✅")
+	srv.SetDescription(db, "Everything to do")
+
+	got, err := client.GetSchema(context.Background(), db)
+	if err != nil {
+		t.Fatalf("GetSchema: %v", err)
+	}
+	if got.ID != db || got.Title != "Tasks" || got.Description != "Everything to do" || got.Icon != "✅" ||
+		got.ParentType != "workspace" || got.URL == "" {
+		t.Errorf("GetSchema described the database as %+v", *got)
+	}
+
+	if got.Properties["Title"].ID != "title" || got.Properties["Notes"].ID == "" {
+		t.Errorf("property IDs = %q, %q", got.Properties["Title"].ID, got.Properties["Notes"].ID)
+	}
+	for _, opt := range got.Properties["Priority"].Options {
+		if opt.ID == "" || opt.Color == "" {
+			t.Errorf("Priority option %+v has no ID or color", opt)
+		}
+	}
+	groups := got.Properties["Status"].Groups
+	if len(groups) != 3 || !reflect.DeepEqual(groups[1].Options, []string{"In Progress", "Blocked"}) {
+		t.Errorf("Status groups = %+v", groups)
+	}
+	if got.Properties["Estimate"].Format != "number" {
+		t.Errorf("Estimate format = %q", got.Properties["Estimate"].Format)
+	}
+	if r := got.Properties["Parent"].Relation; r == nil || normalizeID(r.DatabaseID) != normalizeID(db) || r.Type != "single_property" {
+		t.Errorf("Parent relation = %+v", r)
+	}
+	if r := got.Properties["Parent estimate"].Rollup; r == nil || *r != (RollupInfo{Relation: "Parent", Property: "Estimate", Function: "sum"}) {
+		t.Errorf("Parent estimate rollup = %+v", r)
+	}
+	if got.Properties["Label"].Expression != `prop("Title")` {
+		t.Errorf("Label expression = %q", got.Properties["Label"].Expression)
+	}
+}
+
+func TestGetSchemaNotFound(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	if _, err := client.GetSchema(context.Background(), "not-an-id"); err == nil {
+		t.Error("GetSchema of a malformed ID succeeded")
+	}
+	if _, err := client.GetSchema(context.Background(), "0123456789abcdef0123456789abcdef"); !IsNotFound(err) {
+		t.Errorf("GetSchema of an unknown database: error = %v, want a not found error", err)
+	}
+}
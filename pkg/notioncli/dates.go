@@ -0,0 +1,127 @@
+package notioncli
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/jomei/notionapi"
+)
+
+// dateLayout is how Notion writes a date without a time of day
+const dateLayout = "2006-01-02"
+
+// dateValue is a date property value. notionapi.Date always marshals a full
+// RFC 3339 time, which Notion keeps as a time of day even at midnight, so
+// calendar dates are written here as YYYY-MM-DD instead.
+type dateValue struct {
+	Start time.Time
+	// End is zero for a single date or time
+	End time.Time
+	// Dates writes the calendar days of Start and End, without times
+	Dates bool
+}
+
+func (d dateValue) GetID() string { return "" }
+
+func (d dateValue) GetType() notionapi.PropertyType { return notionapi.PropertyTypeDate }
+
+func (d dateValue) MarshalJSON() ([]byte, error) {
+	type object struct {
+		Start string  `json:"start"`
+		End   *string `json:"end"`
+	}
+	date := object{Start: d.format(d.Start)}
+	if !d.End.IsZero() {
+		end := d.format(d.End)
+		date.End = &end
+	}
+	return json.Marshal(struct {
+		Date object `json:"date"`
+	}{date})
+}
+
+// format writes one end of the value
+func (d dateValue) format(t time.Time) string {
+	if d.Dates {
+		return t.Format(dateLayout)
+	}
+	return t.Format(time.RFC3339)
+}
+
+// dayFilter compares a date column with a calendar day. notionapi writes
+// filter dates as full times, which Notion compares as instants, so local
+// midnight away from UTC would move the bound onto the day before; the day
+// is written as YYYY-MM-DD instead.
+type dayFilter struct {
+	notionapi.PropertyFilter
+	// Condition is the date condition, such as "equals" or "on_or_before"
+	Condition string
+	Day       time.Time
+}
+
+func (f dayFilter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"property": f.Property,
+		"date":     map[string]string{f.Condition: dateValue{Dates: true}.format(f.Day)},
+	})
+}
+
+// rawPage holds the date properties of a page as the API wrote them
+type rawPage struct {
+	Properties map[string]struct {
+		Date *struct {
+			Start string `json:"start"`
+		} `json:"date"`
+	} `json:"properties"`
+}
+
+// dateProperty is a date property of a page read by decodePage. notionapi
+// parses "2024-03-20" and "2024-03-20T00:00:00Z" into the same time, so
+// whether the API gave calendar dates is kept alongside.
+type dateProperty struct {
+	notionapi.DateProperty
+	// DateOnly is set when the value has no time of day
+	DateOnly bool `json:"-"`
+}
+
+// pageDate returns a date property of a page read by decodePage, if it is
+// set
+func pageDate(page *notionapi.Page, name string) (*dateProperty, bool) {
+	p, ok := page.Properties[name].(*dateProperty)
+	if !ok || p.Date == nil || p.Date.Start == nil {
+		return nil, false
+	}
+	return p, true
+}
+
+// decodePage reads a page from the API, with its date properties as
+// *dateProperty
+func decodePage(data []byte) (*notionapi.Page, error) {
+	var page notionapi.Page
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, err
+	}
+	var raw rawPage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	markDates(&page, raw)
+	return &page, nil
+}
+
+// markDates replaces the date properties of a page with *dateProperty,
+// recording from the raw values which of them are calendar dates
+func markDates(page *notionapi.Page, raw rawPage) {
+	for name, prop := range page.Properties {
+		p, ok := prop.(*notionapi.DateProperty)
+		if !ok {
+			continue
+		}
+		date := raw.Properties[name].Date
+		page.Properties[name] = &dateProperty{
+			DateProperty: *p,
+			DateOnly:     date != nil && !strings.Contains(date.Start, "T"),
+		}
+	}
+}
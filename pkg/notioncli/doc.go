@@ -0,0 +1,37 @@
+// Package notioncli is the library behind notion-cli: a client for content
+// pipeline, task and event databases in Notion, the typed models it reads
+// and writes, Markdown conversion for page content and the JSON and table
+// output the CLI prints.
+//
+// Create a client with an integration token and options, then pass a
+// context to every call; cancelling it aborts the request:
+//
+//	client := notioncli.New(os.Getenv("NOTION_API_TOKEN"),
+//		notioncli.WithLocation(time.UTC),
+//		notioncli.WithSettings(notioncli.Settings{AssigneeProperty: "Owner"}),
+//	)
+//	tasks, err := client.QueryTasks(ctx, tasksDatabaseID, notioncli.TaskQueryOptions{
+//		Status: "Todo",
+//	})
+//
+// Queries take an options struct per kind of page: QueryOptions for posts,
+// TaskQueryOptions, EventQueryOptions, SearchOptions and
+// DatabaseListOptions. Properties are matched by name, then written and
+// filtered on in whatever form the database uses, so a Status can be a
+// status, select or multi-select property; see Settings for the names that
+// can be changed.
+//
+// Requests Notion rate limits are sent again after the delay it asks for,
+// up to three times; see WithRetries. Once retries run out, calls return a
+// *notionapi.RateLimitedError.
+//
+// Errors from the Notion API wrap *notionapi.Error. Beyond those, calls
+// return:
+//
+//   - errors matched by IsNotFound, for missing pages, databases and
+//     properties
+//   - *PropertyError, when values don't fit the database's properties
+//   - *BlockedError, when completing a task with open blockers
+//   - *ConflictError, when an event would overlap others
+//   - *DestructiveError, when applying schema changes that lose values
+package notioncli
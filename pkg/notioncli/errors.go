@@ -0,0 +1,46 @@
+package notioncli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jomei/notionapi"
+)
+
+// ErrNotFound is wrapped by errors for properties the client looked up and
+// didn't find. Use IsNotFound to also match pages and databases the API
+// doesn't know or that aren't shared with the integration.
+var ErrNotFound = errors.New("not found")
+
+// IsNotFound reports whether err is caused by a missing page, database or
+// property
+func IsNotFound(err error) bool {
+	var apiErr *notionapi.Error
+	if errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound {
+		return true
+	}
+	return errors.Is(err, ErrNotFound)
+}
+
+// PropertyError is returned when values can't be written because the
+// database's properties are missing or have other types, e.g. a date written
+// to a formula. Problems names the property in each case.
+type PropertyError struct {
+	Problems []string
+}
+
+func (e *PropertyError) Error() string {
+	return fmt.Sprintf("properties don't match the database: %s", strings.Join(e.Problems, "; "))
+}
+
+// DestructiveError is returned by ApplySchema when changes would lose values
+// on existing pages and weren't allowed
+type DestructiveError struct {
+	Changes []SchemaChange
+}
+
+func (e *DestructiveError) Error() string {
+	return fmt.Sprintf("%d of the changes are destructive and would lose values on existing pages; review the plan and allow them to apply it", len(e.Changes))
+}
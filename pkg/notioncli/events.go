@@ -0,0 +1,463 @@
+package notioncli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jomei/notionapi"
+	"github.com/jontk/notion-cli/internal/dateparse"
+)
+
+// maxEventSpanDays bounds how far before a query window a multi-day event may
+// start and still be found. Notion date filters only look at the start date,
+// so overlapping events are fetched with this lookback and filtered locally.
+const maxEventSpanDays = 31
+
+// CreateEvent creates a new event in the Notion database
+func (c *Client) CreateEvent(ctx context.Context, input EventInput, databaseID string) (*Event, error) {
+	enc, err := c.encoder(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	properties, err := c.eventProperties(enc, input)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &notionapi.PageCreateRequest{
+		Parent: notionapi.Parent{
+			Type:       notionapi.ParentTypeDatabaseID,
+			DatabaseID: notionapi.DatabaseID(databaseID),
+		},
+		Properties: properties,
+	}
+
+	page, err := c.createPage(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event: %w", err)
+	}
+
+	return c.pageToEvent(ctx, page)
+}
+
+// GetEvent retrieves a single event by ID
+func (c *Client) GetEvent(ctx context.Context, eventID string) (*Event, error) {
+	page, err := c.getPage(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	return c.pageToEvent(ctx, page)
+}
+
+// UpdateEvent updates an existing event
+func (c *Client) UpdateEvent(ctx context.Context, eventID string, input EventInput) (*Event, error) {
+	input, err := c.completeEventTiming(ctx, eventID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := c.pageEncoder(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	properties, err := c.eventProperties(enc, input)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &notionapi.PageUpdateRequest{
+		Properties: properties,
+	}
+
+	page, err := c.updatePage(ctx, eventID, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update event: %w", err)
+	}
+
+	return c.pageToEvent(ctx, page)
+}
+
+// eventProperties encodes the fields set in an event input
+func (c *Client) eventProperties(enc *propertyEncoder, input EventInput) (notionapi.Properties, error) {
+	if input.Title != "" {
+		enc.title(input.Title)
+	}
+	if input.Date != "" {
+		prop, err := c.eventDateProperty(input)
+		if err != nil {
+			return nil, err
+		}
+		enc.date("Date", prop)
+	}
+	if input.Type != "" {
+		enc.choice("Type", input.Type, kindSelect)
+	}
+	if input.Status != "" {
+		enc.choice("Status", input.Status, kindMultiSelect)
+	}
+	if input.Location != "" {
+		enc.text("Location", input.Location)
+	}
+	if len(input.Attendees) > 0 {
+		enc.choices("Attendees", input.Attendees)
+	}
+	if input.Notes != "" {
+		enc.text("Notes", input.Notes)
+	}
+
+	if err := c.setRecurrence(enc, input.Repeat, input.SeriesID); err != nil {
+		return nil, err
+	}
+	if input.UID != "" {
+		enc.text(c.settings.UIDProperty, input.UID)
+	}
+
+	return enc.result()
+}
+
+// completeEventTiming fills in the parts of an event's timing an update leaves
+// out: the current start when only the end changes, and the current length
+// when only the start moves
+func (c *Client) completeEventTiming(ctx context.Context, eventID string, input EventInput) (EventInput, error) {
+	if input.Date == "" && input.End == "" && input.Duration == "" && !input.AllDay {
+		return input, nil
+	}
+
+	current, err := c.GetEvent(ctx, eventID)
+	if err != nil {
+		return input, err
+	}
+	if input.Date == "" {
+		input.Date = current.Start
+	}
+	if input.End == "" && input.Duration == "" && current.End != "" {
+		input.Duration = fmt.Sprintf("%dm", current.DurationMinutes)
+	}
+	if input.Date == "" {
+		return input, fmt.Errorf("event has no start date; specify one with the end or duration")
+	}
+	return input, nil
+}
+
+// CancelEvent sets an event to the first of the cancelled statuses
+func (c *Client) CancelEvent(ctx context.Context, eventID string) (*Event, error) {
+	page, err := c.getPage(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	groups, err := c.eventStatuses(ctx, string(page.Parent.DatabaseID))
+	if err != nil {
+		return nil, err
+	}
+	if len(groups.Cancelled) == 0 {
+		return nil, fmt.Errorf("no cancelled status is configured for events")
+	}
+
+	return c.UpdateEvent(ctx, eventID, EventInput{Status: groups.Cancelled[0]})
+}
+
+// EventQueryOptions holds options for querying events
+type EventQueryOptions struct {
+	Type       string
+	Status     string
+	DateAfter  string
+	DateBefore string
+	Limit      int
+}
+
+// QueryEvents queries events from a database with filters
+func (c *Client) QueryEvents(ctx context.Context, databaseID string, opts EventQueryOptions) ([]Event, error) {
+	filters, err := c.queryFilters(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Type != "" {
+		filters.is("Type", opts.Type, kindSelect)
+	}
+
+	if opts.Status != "" {
+		filters.is("Status", opts.Status, kindMultiSelect)
+	}
+
+	// Date bounds select events that overlap the window, so a multi-day
+	// event that started before it is still included
+	var windowStart, windowEnd time.Time
+	if opts.DateAfter != "" {
+		r, err := c.parseDate(opts.DateAfter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start date: %w", err)
+		}
+		windowStart = r.Time
+		lookback := notionapi.Date(dayStart(r.Time).AddDate(0, 0, -maxEventSpanDays))
+		filters.add(notionapi.PropertyFilter{
+			Property: "Date",
+			Date: &notionapi.DateFilterCondition{
+				OnOrAfter: &lookback,
+			},
+		})
+	}
+
+	if opts.DateBefore != "" {
+		r, err := c.parseDate(opts.DateBefore)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end date: %w", err)
+		}
+		windowEnd = r.Time
+		condition := &notionapi.DateFilterCondition{}
+		if r.HasTime {
+			before := notionapi.Date(r.Time)
+			condition.OnOrBefore = &before
+		} else {
+			// Events later on the last day start after its midnight, so
+			// bound by the next day instead
+			windowEnd = r.Time.AddDate(0, 0, 1)
+			before := notionapi.Date(windowEnd)
+			condition.Before = &before
+		}
+		filters.add(notionapi.PropertyFilter{
+			Property: "Date",
+			Date:     condition,
+		})
+	}
+
+	filter, err := filters.result()
+	if err != nil {
+		return nil, err
+	}
+
+	sorts := []notionapi.SortObject{
+		{
+			Property:  "Date",
+			Direction: notionapi.SortOrderASC,
+		},
+	}
+
+	var allEvents []Event
+	var cursor *string
+	limit := opts.Limit
+	if limit == 0 {
+		limit = 100
+	}
+
+	for {
+		pageSize := 100
+		remaining := limit - len(allEvents)
+		if remaining < pageSize {
+			pageSize = remaining
+		}
+
+		req := &notionapi.DatabaseQueryRequest{
+			Filter:   filter,
+			Sorts:    sorts,
+			PageSize: pageSize,
+		}
+
+		if cursor != nil {
+			req.StartCursor = notionapi.Cursor(*cursor)
+		}
+
+		resp, err := c.queryDatabase(ctx, databaseID, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query events: %w", err)
+		}
+
+		for _, page := range resp.Results {
+			if !windowStart.IsZero() || !windowEnd.IsZero() {
+				start, end, ok := c.eventSpan(&page)
+				if !ok || !overlaps(start, end, windowStart, windowEnd) {
+					continue
+				}
+			}
+			event, err := c.pageToEvent(ctx, &page)
+			if err != nil {
+				return nil, err
+			}
+			allEvents = append(allEvents, *event)
+			if len(allEvents) >= limit {
+				break
+			}
+		}
+
+		if !resp.HasMore || len(allEvents) >= limit {
+			break
+		}
+		cursorStr := string(resp.NextCursor)
+		cursor = &cursorStr
+	}
+
+	return allEvents, nil
+}
+
+// GetTodaysEvents returns events happening today
+func (c *Client) GetTodaysEvents(ctx context.Context, databaseID string) ([]Event, error) {
+	return c.QueryEvents(ctx, databaseID, EventQueryOptions{
+		DateAfter:  "today",
+		DateBefore: "today",
+		Limit:      100,
+	})
+}
+
+// GetWeeksEvents returns events for this week (Monday to Sunday)
+func (c *Client) GetWeeksEvents(ctx context.Context, databaseID string) ([]Event, error) {
+	today := dayStart(time.Now().In(c.location))
+	startOfWeek := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	endOfWeek := startOfWeek.AddDate(0, 0, 6)
+
+	return c.QueryEvents(ctx, databaseID, EventQueryOptions{
+		DateAfter:  startOfWeek.Format("2006-01-02"),
+		DateBefore: endOfWeek.Format("2006-01-02"),
+		Limit:      100,
+	})
+}
+
+// pageToEvent converts a Notion page to our Event model
+func (c *Client) pageToEvent(ctx context.Context, page *notionapi.Page) (*Event, error) {
+	event := &Event{
+		ID:        string(page.ID),
+		URL:       page.URL,
+		CreatedAt: c.formatTime(page.CreatedTime),
+		UpdatedAt: c.formatTime(page.LastEditedTime),
+	}
+
+	event.Title = pageTitle(page)
+
+	// Extract date range
+	if dateProp, ok := pageDate(page, "Date"); ok {
+		event.Date = c.formatDate(dateProp.Date.Start, dateProp.DateOnly)
+		event.Start = event.Date
+		event.AllDay = dateProp.DateOnly
+		if dateProp.Date.End != nil {
+			event.End = c.formatDate(dateProp.Date.End, dateProp.DateOnly)
+		}
+		if start, end, ok := c.eventSpan(page); ok {
+			event.DurationMinutes = int(end.Sub(start).Minutes())
+		}
+	}
+
+	event.Type = propertyValue(page, "Type")
+	if statuses := propertyValues(page, "Status"); len(statuses) > 0 {
+		event.Status = statuses[0]
+	}
+	event.Location = propertyValue(page, "Location")
+	event.Attendees = propertyValues(page, "Attendees")
+	event.Notes = propertyValue(page, "Notes")
+
+	event.Repeat, event.SeriesID = c.recurrence(page)
+	event.UID = propertyValue(page, c.settings.UIDProperty)
+
+	return event, nil
+}
+
+// eventDateProperty builds the Date property for an event from its start,
+// end, duration and all-day inputs
+func (c *Client) eventDateProperty(input EventInput) (dateValue, error) {
+	if input.End != "" && input.Duration != "" {
+		return dateValue{}, fmt.Errorf("specify either an end or a duration, not both")
+	}
+
+	start, err := c.parseDate(input.Date)
+	if err != nil {
+		return dateValue{}, fmt.Errorf("invalid event date: %w", err)
+	}
+	allDay := input.AllDay || !start.HasTime
+	startTime := start.Time
+	if allDay {
+		startTime = dayStart(startTime)
+	}
+
+	var endTime time.Time
+	switch {
+	case input.End != "":
+		if h, m, ok := dateparse.ParseClock(input.End); ok && !allDay {
+			// A bare time of day ends the event on the day it starts
+			endTime = time.Date(startTime.Year(), startTime.Month(), startTime.Day(), h, m, 0, 0, startTime.Location())
+			break
+		}
+		end, err := c.parseDate(input.End)
+		if err != nil {
+			return dateValue{}, fmt.Errorf("invalid end date: %w", err)
+		}
+		endTime = end.Time
+		if allDay {
+			endTime = dayStart(endTime)
+		}
+	case input.Duration != "":
+		d, err := dateparse.ParseDuration(input.Duration)
+		if err != nil {
+			return dateValue{}, err
+		}
+		if d <= 0 {
+			return dateValue{}, fmt.Errorf("duration must be positive")
+		}
+		if allDay {
+			// All-day ranges end on their last day, inclusive
+			days := int((d + 24*time.Hour - 1) / (24 * time.Hour))
+			if days > 1 {
+				endTime = startTime.AddDate(0, 0, days-1)
+			}
+		} else {
+			endTime = startTime.Add(d)
+		}
+	}
+
+	if !endTime.IsZero() && endTime.Before(startTime) {
+		return dateValue{}, fmt.Errorf("event ends before it starts")
+	}
+
+	value := dateValue{Start: startTime, Dates: allDay}
+	if !endTime.Equal(startTime) {
+		value.End = endTime
+	}
+	return value, nil
+}
+
+// eventSpan returns the interval an event occupies in the client's timezone.
+// All-day events run from the start of their first day to the end of their
+// last; timed events without an end are treated as instants.
+func (c *Client) eventSpan(page *notionapi.Page) (time.Time, time.Time, bool) {
+	dateProp, ok := pageDate(page, "Date")
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	start := time.Time(*dateProp.Date.Start)
+	end := start
+	if dateProp.Date.End != nil {
+		end = time.Time(*dateProp.Date.End)
+	}
+
+	if dateProp.DateOnly {
+		start = c.localDay(start)
+		end = c.localDay(end).AddDate(0, 0, 1)
+	}
+	return start.In(c.location), end.In(c.location), true
+}
+
+// localDay maps a date-only value onto midnight of the same calendar day in
+// the client's timezone
+func (c *Client) localDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, c.location)
+}
+
+// overlaps reports whether [start, end] intersects the window. A zero window
+// bound is open-ended.
+func overlaps(start, end, windowStart, windowEnd time.Time) bool {
+	if !windowEnd.IsZero() && !start.Before(windowEnd) {
+		return false
+	}
+	if !windowStart.IsZero() && end.Before(windowStart) {
+		return false
+	}
+	if !windowStart.IsZero() && end.Equal(windowStart) && end.After(start) {
+		return false
+	}
+	return true
+}
+
+// dayStart truncates t to midnight in its own location
+func dayStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,210 @@
+package notioncli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jontk/notion-cli/internal/notiontest"
+)
+
+func TestCreateEvent(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Events", notiontest.EventsSchema())
+	ctx := context.Background()
+
+	event, err := client.CreateEvent(ctx, EventInput{
+		Title:     "Planning",
+		Date:      "2026-10-20 09:30",
+		Duration:  "90m",
+		Type:      "Meeting",
+		Location:  "Room 4",
+		Attendees: []string{"Ada", "Grace"},
+		Status:    "Scheduled",
+	}, db)
+	if err != nil {
+		t.Fatalf("CreateEvent: %v", err)
+	}
+
+	want := Event{
+		ID:              event.ID,
+		Title:           "Planning",
+		Date:            "2026-10-20T09:30:00Z",
+		Start:           "2026-10-20T09:30:00Z",
+		End:             "2026-10-20T11:00:00Z",
+		DurationMinutes: 90,
+		Type:            "Meeting",
+		Location:        "Room 4",
+		Attendees:       []string{"Ada", "Grace"},
+		Status:          "Scheduled",
+		URL:             event.URL,
+		CreatedAt:       event.CreatedAt,
+		UpdatedAt:       event.UpdatedAt,
+	}
+	if !reflect.DeepEqual(*event, want) {
+		t.Errorf("CreateEvent returned %+v, want %+v", *event, want)
+	}
+}
+
+func TestCreateAllDayEvent(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Events", notiontest.EventsSchema())
+
+	event, err := client.CreateEvent(context.Background(), EventInput{
+		Title:  "Offsite",
+		Date:   "2026-10-20",
+		End:    "2026-10-21",
+		AllDay: true,
+	}, db)
+	if err != nil {
+		t.Fatalf("CreateEvent: %v", err)
+	}
+	if !event.AllDay || event.Date != "2026-10-20" || event.End != "2026-10-21" {
+		t.Errorf("CreateEvent returned %+v, want an all-day event over two days", *event)
+	}
+}
+
+func TestAllDayEventsOutsideUTC(t *testing.T) {
+	srv := notiontest.NewServer()
+	srv.Token = testToken
+	berlin := time.FixedZone("CEST", 2*60*60)
+	client := New(testToken, WithHTTPClient(srv.HTTPClient()), WithLocation(berlin))
+	db := srv.AddDatabase("Events", notiontest.EventsSchema())
+	ctx := context.Background()
+
+	event, err := client.CreateEvent(ctx, EventInput{Title: "Offsite", Date: "2026-10-20", End: "2026-10-21", AllDay: true}, db)
+	if err != nil {
+		t.Fatalf("CreateEvent: %v", err)
+	}
+	var sent struct {
+		Properties struct {
+			Date struct {
+				Date struct {
+					Start string `json:"start"`
+					End   string `json:"end"`
+				} `json:"date"`
+			} `json:"Date"`
+		} `json:"properties"`
+	}
+	for _, r := range srv.Requests() {
+		if r.Method == http.MethodPost && r.Path == "pages" {
+			if err := json.Unmarshal(r.Body, &sent); err != nil {
+				t.Fatalf("create body: %v", err)
+			}
+		}
+	}
+	if d := sent.Properties.Date.Date; d.Start != "2026-10-20" || d.End != "2026-10-21" {
+		t.Errorf("sent date %+v, want calendar dates", d)
+	}
+
+	got, err := client.GetEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("GetEvent: %v", err)
+	}
+	if !got.AllDay || got.Date != "2026-10-20" || got.End != "2026-10-21" {
+		t.Errorf("GetEvent = %+v, want an all-day event over two days", *got)
+	}
+
+	// A timed event at midnight UTC is not an all-day event
+	srv.AddPage(db, map[string]any{"Title": "Call", "Date": "2026-10-22T00:00:00.000Z"})
+	events, err := client.QueryEvents(ctx, db, EventQueryOptions{DateAfter: "2026-10-22"})
+	if err != nil {
+		t.Fatalf("QueryEvents: %v", err)
+	}
+	if len(events) != 1 || events[0].AllDay || events[0].Date != "2026-10-22T02:00:00+02:00" {
+		t.Errorf("QueryEvents = %+v, want the call at 02:00 local time", events)
+	}
+}
+
+func TestMidnightUTCEvents(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Events", notiontest.EventsSchema())
+	srv.AddPage(db, map[string]any{"Title": "Call", "Date": "2026-10-22T00:00:00.000Z"})
+	srv.AddPage(db, map[string]any{"Title": "Offsite", "Date": "2026-10-22"})
+
+	events, err := client.QueryEvents(context.Background(), db, EventQueryOptions{DateAfter: "2026-10-22"})
+	if err != nil {
+		t.Fatalf("QueryEvents: %v", err)
+	}
+	got := map[string]Event{}
+	for _, e := range events {
+		got[e.Title] = e
+	}
+	if call := got["Call"]; call.AllDay || call.Date != "2026-10-22T00:00:00Z" {
+		t.Errorf("call at midnight UTC = %+v, want a timed event", call)
+	}
+	if offsite := got["Offsite"]; !offsite.AllDay || offsite.Date != "2026-10-22" || offsite.DurationMinutes != 24*60 {
+		t.Errorf("offsite = %+v, want an all-day event", offsite)
+	}
+}
+
+func TestUpdateEventKeepsDuration(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Events", notiontest.EventsSchema())
+	ctx := context.Background()
+	event, err := client.CreateEvent(ctx, EventInput{Title: "Standup", Date: "2026-10-20 09:00", Duration: "15m"}, db)
+	if err != nil {
+		t.Fatalf("CreateEvent: %v", err)
+	}
+
+	moved, err := client.UpdateEvent(ctx, event.ID, EventInput{Date: "2026-10-21 10:00", Location: "Zoom"})
+	if err != nil {
+		t.Fatalf("UpdateEvent: %v", err)
+	}
+	if moved.Start != "2026-10-21T10:00:00Z" || moved.End != "2026-10-21T10:15:00Z" {
+		t.Errorf("moved event runs %s to %s, want the 15 minutes kept", moved.Start, moved.End)
+	}
+	if moved.Title != "Standup" || moved.Location != "Zoom" {
+		t.Errorf("UpdateEvent returned %+v", *moved)
+	}
+
+	cancelled, err := client.CancelEvent(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("CancelEvent: %v", err)
+	}
+	if cancelled.Status != "Cancelled" {
+		t.Errorf("status = %q, want Cancelled", cancelled.Status)
+	}
+}
+
+func TestQueryEvents(t *testing.T) {
+	client, srv := newTestClient(t)
+	srv.MaxPageSize = 1
+	db := srv.AddDatabase("Events", notiontest.EventsSchema())
+	srv.AddPage(db, map[string]any{"Title": "Lunch", "Date": "2026-10-21T12:00:00Z", "Type": "Personal"})
+	srv.AddPage(db, map[string]any{"Title": "Review", "Date": "2026-10-20T15:00:00Z", "Type": "Work"})
+	srv.AddPage(db, map[string]any{"Title": "Retro", "Date": "2026-10-23T10:00:00Z", "Type": "Work"})
+	srv.AddPage(db, map[string]any{"Title": "Conference", "Date": map[string]any{
+		"date": map[string]any{"start": "2026-10-18", "end": "2026-10-20"},
+	}, "Type": "Work"})
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		opts EventQueryOptions
+		want []string
+	}{
+		{"all", EventQueryOptions{}, []string{"Conference", "Review", "Lunch", "Retro"}},
+		{"type", EventQueryOptions{Type: "Work"}, []string{"Conference", "Review", "Retro"}},
+		{"window", EventQueryOptions{DateAfter: "2026-10-20", DateBefore: "2026-10-21"}, []string{"Conference", "Review", "Lunch"}},
+		{"limit", EventQueryOptions{Limit: 2}, []string{"Conference", "Review"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := client.QueryEvents(ctx, db, tt.opts)
+			if err != nil {
+				t.Fatalf("QueryEvents: %v", err)
+			}
+			var titles []string
+			for _, event := range events {
+				titles = append(titles, event.Title)
+			}
+			if !reflect.DeepEqual(titles, tt.want) {
+				t.Errorf("QueryEvents = %q, want %q", titles, tt.want)
+			}
+		})
+	}
+}
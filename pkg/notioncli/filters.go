@@ -0,0 +1,107 @@
+package notioncli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/jomei/notionapi"
+)
+
+// propertyFilters builds a database query filter, matching each value with
+// the condition its column's type takes. It is the query side of
+// propertyEncoder: columns missing from the schema get the type of the
+// documented schema, so Notion reports them by name.
+type propertyFilters struct {
+	schema  notionapi.PropertyConfigs
+	filters []notionapi.Filter
+	errs    []string
+}
+
+// queryFilters returns a filter builder for queries on a database
+func (c *Client) queryFilters(ctx context.Context, databaseID string) (*propertyFilters, error) {
+	schema, err := c.databaseSchema(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	return &propertyFilters{schema: schema}, nil
+}
+
+// add appends a filter whose condition doesn't depend on the column type
+func (f *propertyFilters) add(filter notionapi.Filter) {
+	f.filters = append(f.filters, filter)
+}
+
+// is matches pages whose column holds value: the option of a select or
+// status, one of the options of a multi-select, or the whole text of the
+// text-like types
+func (f *propertyFilters) is(name, value string, fallback propertyKind) {
+	if filter, ok := f.condition(name, value, fallback); ok {
+		f.add(filter)
+	}
+}
+
+// anyOf matches pages whose column holds any of values
+func (f *propertyFilters) anyOf(name string, values []string, fallback propertyKind) {
+	or := make(notionapi.OrCompoundFilter, 0, len(values))
+	for _, value := range values {
+		filter, ok := f.condition(name, value, fallback)
+		if !ok {
+			return
+		}
+		or = append(or, filter)
+	}
+	switch len(or) {
+	case 0:
+	case 1:
+		f.add(or[0])
+	default:
+		f.add(&or)
+	}
+}
+
+// condition builds the filter matching value in a column
+func (f *propertyFilters) condition(name, value string, fallback propertyKind) (notionapi.PropertyFilter, bool) {
+	filter := notionapi.PropertyFilter{Property: name}
+	switch schemaKind(f.schema, name, fallback) {
+	case kindStatus:
+		filter.Status = &notionapi.StatusFilterCondition{Equals: value}
+	case kindSelect:
+		filter.Select = &notionapi.SelectFilterCondition{Equals: value}
+	case kindMultiSelect:
+		filter.MultiSelect = &notionapi.MultiSelectFilterCondition{Contains: value}
+	case kindTitle, kindText:
+		filter.RichText = &notionapi.TextFilterCondition{Equals: value}
+	case kindNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			f.fail("%s is a number property, got %q", name, value)
+			return filter, false
+		}
+		filter.Number = &notionapi.NumberFilterCondition{Equals: &n}
+	default:
+		f.fail("%s is a %s property and can't be matched against text", name, schemaTypeName(f.schema, name))
+		return filter, false
+	}
+	return filter, true
+}
+
+func (f *propertyFilters) fail(format string, args ...any) {
+	f.errs = append(f.errs, fmt.Sprintf(format, args...))
+}
+
+// result returns the filter for a query, nil when there are no conditions,
+// or the type mismatches found
+func (f *propertyFilters) result() (notionapi.Filter, error) {
+	if len(f.errs) > 0 {
+		return nil, &PropertyError{Problems: f.errs}
+	}
+	switch len(f.filters) {
+	case 0:
+		return nil, nil
+	case 1:
+		return f.filters[0], nil
+	}
+	and := notionapi.AndCompoundFilter(f.filters)
+	return &and, nil
+}
@@ -0,0 +1,106 @@
+package notioncli
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jontk/notion-cli/internal/notiontest"
+)
+
+func TestQueryFiltersFollowColumnType(t *testing.T) {
+	columns := map[string]notiontest.Property{
+		"status":       notiontest.Status([]string{"Todo"}, []string{"In Progress", "Blocked"}, []string{"Done"}),
+		"select":       notiontest.Select("Todo", "In Progress", "Blocked", "Done"),
+		"multi_select": notiontest.MultiSelect("Todo", "In Progress", "Blocked", "Done"),
+		"rich_text":    notiontest.Text(),
+	}
+	for kind, column := range columns {
+		t.Run(kind, func(t *testing.T) {
+			client, srv := newTestClient(t)
+			ctx := context.Background()
+
+			tasksDB := srv.AddDatabase("Tasks", notiontest.Schema{
+				"Title":    notiontest.Title(),
+				"Status":   column,
+				"Due Date": notiontest.Date(),
+			})
+			srv.AddPage(tasksDB, map[string]any{"Title": "Finished", "Status": "Done"})
+			srv.AddPage(tasksDB, map[string]any{"Title": "Pending", "Status": "Todo"})
+
+			tasks, err := client.QueryTasks(ctx, tasksDB, TaskQueryOptions{Status: "Done"})
+			if err != nil {
+				t.Fatalf("QueryTasks: %v", err)
+			}
+			if len(tasks) != 1 || tasks[0].Title != "Finished" {
+				t.Errorf("QueryTasks by status = %+v, want Finished", tasks)
+			}
+			tasks, err = client.QueryTasks(ctx, tasksDB, TaskQueryOptions{Open: true})
+			if err != nil {
+				t.Fatalf("QueryTasks open: %v", err)
+			}
+			if len(tasks) != 1 || tasks[0].Title != "Pending" {
+				t.Errorf("QueryTasks open = %+v, want Pending", tasks)
+			}
+
+			eventsDB := srv.AddDatabase("Events", notiontest.Schema{
+				"Title":  notiontest.Title(),
+				"Date":   notiontest.Date(),
+				"Status": column,
+			})
+			srv.AddPage(eventsDB, map[string]any{"Title": "Standup", "Date": "2024-03-20", "Status": "Done"})
+			srv.AddPage(eventsDB, map[string]any{"Title": "Review", "Date": "2024-03-21", "Status": "Todo"})
+			events, err := client.QueryEvents(ctx, eventsDB, EventQueryOptions{Status: "Done"})
+			if err != nil {
+				t.Fatalf("QueryEvents: %v", err)
+			}
+			if len(events) != 1 || events[0].Title != "Standup" {
+				t.Errorf("QueryEvents by status = %+v, want Standup", events)
+			}
+
+			postsDB := srv.AddDatabase("Posts", notiontest.Schema{
+				"Title":  notiontest.Title(),
+				"Status": column,
+			})
+			srv.AddPage(postsDB, map[string]any{"Title": "Shipped", "Status": "Done"})
+			srv.AddPage(postsDB, map[string]any{"Title": "Idea", "Status": "Todo"})
+			posts, err := client.QueryPosts(ctx, postsDB, QueryOptions{Status: "Done"})
+			if err != nil {
+				t.Fatalf("QueryPosts: %v", err)
+			}
+			if len(posts) != 1 || posts[0].Title != "Shipped" {
+				t.Errorf("QueryPosts by status = %+v, want Shipped", posts)
+			}
+		})
+	}
+}
+
+func TestQueryFiltersRejectMismatchedColumns(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.Schema{
+		"Title":    notiontest.Title(),
+		"Status":   notiontest.People(),
+		"Priority": notiontest.Number(),
+		"Due Date": notiontest.Date(),
+	})
+	srv.AddPage(db, map[string]any{"Title": "Ranked", "Priority": "2"})
+
+	_, err := client.QueryTasks(context.Background(), db, TaskQueryOptions{Status: "Done", Priority: "High"})
+	var propErr *PropertyError
+	if !errors.As(err, &propErr) || len(propErr.Problems) != 2 {
+		t.Fatalf("err = %v, want a PropertyError for Status and Priority", err)
+	}
+
+	tasks, err := client.QueryTasks(context.Background(), db, TaskQueryOptions{Priority: "2"})
+	if err != nil {
+		t.Fatalf("QueryTasks by a number: %v", err)
+	}
+	var titles []string
+	for _, task := range tasks {
+		titles = append(titles, task.Title)
+	}
+	if !reflect.DeepEqual(titles, []string{"Ranked"}) {
+		t.Errorf("QueryTasks by a number = %q, want [Ranked]", titles)
+	}
+}
@@ -0,0 +1,143 @@
+package notioncli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jontk/notion-cli/internal/dateparse"
+)
+
+// FreeSlot is a gap between events long enough for the requested duration
+type FreeSlot struct {
+	Start           string `json:"start"`
+	End             string `json:"end"`
+	DurationMinutes int    `json:"duration_minutes"`
+}
+
+// FreeSlotOptions holds options for finding free time
+type FreeSlotOptions struct {
+	Date     string // day to search, defaults to today
+	Duration string // minimum slot length, e.g. 30m
+	Between  string // hours to search, e.g. 09:00-17:00
+	Attendee string // only count events this attendee is in
+}
+
+// FindFreeSlots returns the gaps between events on a day, within the given
+// hours, that are at least the requested duration long. Cancelled and
+// all-day events don't block time; events without an end block 30 minutes.
+func (c *Client) FindFreeSlots(ctx context.Context, databaseID string, opts FreeSlotOptions) ([]FreeSlot, error) {
+	if opts.Date == "" {
+		opts.Date = "today"
+	}
+	day, err := c.parseDate(opts.Date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date: %w", err)
+	}
+
+	length, err := dateparse.ParseDuration(opts.Duration)
+	if err != nil {
+		return nil, err
+	}
+	if length <= 0 {
+		return nil, fmt.Errorf("duration must be positive")
+	}
+
+	windowStart, windowEnd, err := clockRange(dayStart(day.Time), opts.Between)
+	if err != nil {
+		return nil, err
+	}
+	// Nothing before now is free any more
+	if now := time.Now().In(c.location); now.After(windowStart) {
+		windowStart = now.Truncate(5 * time.Minute)
+		if windowStart.Before(now) {
+			windowStart = windowStart.Add(5 * time.Minute)
+		}
+	}
+
+	events, err := c.QueryEvents(ctx, databaseID, EventQueryOptions{
+		DateAfter:  c.dateInput(windowStart.Add(-defaultEventLength), true),
+		DateBefore: c.dateInput(windowEnd, true),
+		Limit:      1000,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type interval struct{ start, end time.Time }
+	var busy []interval
+	for _, event := range events {
+		if event.AllDay || c.settings.EventStatuses.IsCancelled(event.Status) {
+			continue
+		}
+		if opts.Attendee != "" && !hasAttendee(event.Attendees, opts.Attendee) {
+			continue
+		}
+		if start, end, ok := c.blockedSpan(event); ok {
+			busy = append(busy, interval{start, end})
+		}
+	}
+	sort.Slice(busy, func(i, j int) bool { return busy[i].start.Before(busy[j].start) })
+
+	slots := []FreeSlot{}
+	addSlot := func(start, end time.Time) {
+		if end.Sub(start) >= length {
+			slots = append(slots, FreeSlot{
+				Start:           c.formatTime(start),
+				End:             c.formatTime(end),
+				DurationMinutes: int(end.Sub(start).Minutes()),
+			})
+		}
+	}
+
+	cursor := windowStart
+	for _, b := range busy {
+		if !b.end.After(cursor) {
+			continue
+		}
+		if b.start.After(windowEnd) {
+			break
+		}
+		if b.start.After(cursor) {
+			addSlot(cursor, b.start)
+		}
+		cursor = b.end
+	}
+	if cursor.Before(windowEnd) {
+		addSlot(cursor, windowEnd)
+	}
+
+	return slots, nil
+}
+
+// clockRange resolves a range of clock times such as "09:00-17:00" on day
+func clockRange(day time.Time, between string) (time.Time, time.Time, error) {
+	from, to, ok := strings.Cut(between, "-")
+	if !ok {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid hours %q: use a range such as 09:00-17:00", between)
+	}
+	fh, fm, ok1 := dateparse.ParseClock(from)
+	th, tm, ok2 := dateparse.ParseClock(to)
+	if !ok1 || !ok2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid hours %q: use a range such as 09:00-17:00", between)
+	}
+
+	start := time.Date(day.Year(), day.Month(), day.Day(), fh, fm, 0, 0, day.Location())
+	end := time.Date(day.Year(), day.Month(), day.Day(), th, tm, 0, 0, day.Location())
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid hours %q: the end must be after the start", between)
+	}
+	return start, end, nil
+}
+
+// hasAttendee reports whether attendees includes name, ignoring case
+func hasAttendee(attendees []string, name string) bool {
+	for _, a := range attendees {
+		if strings.EqualFold(a, name) {
+			return true
+		}
+	}
+	return false
+}
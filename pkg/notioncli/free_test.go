@@ -0,0 +1,100 @@
+package notioncli
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jontk/notion-cli/internal/notiontest"
+)
+
+func TestFindFreeSlots(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Events", notiontest.EventsSchema())
+	// A day far enough ahead that none of it has passed
+	srv.AddPage(db, map[string]any{"Title": "Late call", "Date": span("2030-06-02T23:00:00Z", "2030-06-03T09:15:00Z"), "Attendees": []string{"Lin"}})
+	srv.AddPage(db, map[string]any{"Title": "Standup", "Date": span("2030-06-03T09:00:00Z", "2030-06-03T10:00:00Z"), "Attendees": []string{"Ada"}})
+	srv.AddPage(db, map[string]any{"Title": "Review", "Date": span("2030-06-03T09:30:00Z", "2030-06-03T10:30:00Z"), "Attendees": []string{"Grace"}})
+	srv.AddPage(db, map[string]any{"Title": "Pairing", "Date": span("2030-06-03T10:15:00Z", "2030-06-03T10:45:00Z"), "Attendees": []string{"Ada"}})
+	srv.AddPage(db, map[string]any{"Title": "Call", "Date": "2030-06-03T12:00:00Z", "Attendees": []string{"Grace"}})
+	srv.AddPage(db, map[string]any{"Title": "Lunch", "Date": span("2030-06-03T13:00:00Z", "2030-06-03T14:00:00Z"), "Status": "Cancelled"})
+	srv.AddPage(db, map[string]any{"Title": "Holiday", "Date": "2030-06-03"})
+	srv.AddPage(db, map[string]any{"Title": "Offsite", "Date": span("2030-06-03T16:30:00Z", "2030-06-03T18:00:00Z"), "Attendees": []string{"Ada"}})
+	ctx := context.Background()
+
+	slot := func(start, end string, minutes int) FreeSlot {
+		return FreeSlot{Start: "2030-06-03T" + start + ":00Z", End: "2030-06-03T" + end + ":00Z", DurationMinutes: minutes}
+	}
+	for _, tc := range []struct {
+		name string
+		opts FreeSlotOptions
+		want []FreeSlot
+	}{
+		{"overlapping events", FreeSlotOptions{Duration: "30m", Between: "09:00-17:00"},
+			[]FreeSlot{slot("10:45", "12:00", 75), slot("12:30", "16:30", 240)}},
+		{"longer slots only", FreeSlotOptions{Duration: "1h30m", Between: "09:00-17:00"},
+			[]FreeSlot{slot("12:30", "16:30", 240)}},
+		{"other hours", FreeSlotOptions{Duration: "30m", Between: "7am-9:30"},
+			[]FreeSlot{}},
+		{"inside a gap", FreeSlotOptions{Duration: "30m", Between: "12:15-13:30"},
+			[]FreeSlot{slot("12:30", "13:30", 60)}},
+		{"attendee", FreeSlotOptions{Duration: "30m", Between: "09:00-17:00", Attendee: "ada"},
+			[]FreeSlot{slot("10:45", "16:30", 345)}},
+		{"other attendee", FreeSlotOptions{Duration: "30m", Between: "09:00-17:00", Attendee: "Grace"},
+			[]FreeSlot{slot("09:00", "09:30", 30), slot("10:30", "12:00", 90), slot("12:30", "17:00", 270)}},
+		{"attendee from the day before", FreeSlotOptions{Duration: "1h", Between: "08:00-10:00", Attendee: "Lin"},
+			[]FreeSlot{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.opts.Date = "2030-06-03"
+			slots, err := client.FindFreeSlots(ctx, db, tc.opts)
+			if err != nil {
+				t.Fatalf("FindFreeSlots: %v", err)
+			}
+			if !reflect.DeepEqual(slots, tc.want) {
+				t.Errorf("FindFreeSlots = %+v, want %+v", slots, tc.want)
+			}
+		})
+	}
+
+	for _, opts := range []FreeSlotOptions{
+		{Date: "2030-06-03", Duration: "30m", Between: "17:00-09:00"},
+		{Date: "2030-06-03", Duration: "30m", Between: "morning"},
+		{Date: "2030-06-03", Duration: "0m", Between: "09:00-17:00"},
+		{Date: "2030-06-03", Duration: "soon", Between: "09:00-17:00"},
+		{Date: "someday", Duration: "30m", Between: "09:00-17:00"},
+	} {
+		if slots, err := client.FindFreeSlots(ctx, db, opts); err == nil {
+			t.Errorf("FindFreeSlots(%+v) = %+v, want an error", opts, slots)
+		}
+	}
+}
+
+func TestFindFreeSlotsSkipsThePast(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Events", notiontest.EventsSchema())
+	ctx := context.Background()
+
+	slots, err := client.FindFreeSlots(ctx, db, FreeSlotOptions{Date: "yesterday", Duration: "30m", Between: "00:00-23:59"})
+	if err != nil || len(slots) != 0 {
+		t.Errorf("FindFreeSlots yesterday = %+v, %v, want no slots", slots, err)
+	}
+
+	before := time.Now().UTC()
+	slots, err = client.FindFreeSlots(ctx, db, FreeSlotOptions{Date: "today", Duration: "1m", Between: "00:00-23:59"})
+	if err != nil {
+		t.Fatalf("FindFreeSlots today: %v", err)
+	}
+	if len(slots) == 0 {
+		// Only the last minutes of the day are left
+		return
+	}
+	start, err := time.Parse(time.RFC3339, slots[0].Start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if start.Before(before) || start.Minute()%5 != 0 || start.Sub(before) > 5*time.Minute {
+		t.Errorf("first slot today starts at %s, want the next five minutes after %s", slots[0].Start, before.Format(time.RFC3339))
+	}
+}
@@ -0,0 +1,358 @@
+package notioncli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jomei/notionapi"
+	"github.com/jontk/notion-cli/internal/ical"
+	"github.com/jontk/notion-cli/internal/recur"
+)
+
+// ImportResult reports what happened to one event of an imported calendar
+type ImportResult struct {
+	UID    string `json:"uid"`
+	Action string `json:"action"` // created, updated or failed
+	Event  *Event `json:"event,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// WriteICS writes events as an iCalendar feed. Occurrences of a recurring
+// event that is part of the same export are written as overrides of that
+// event's series.
+func (c *Client) WriteICS(w io.Writer, name string, events []Event) error {
+	uids := make(map[string]string, len(events))
+	for _, event := range events {
+		if event.Repeat != "" && event.SeriesID == "" {
+			uids[event.ID] = eventUID(event)
+		}
+	}
+
+	cal := &ical.Calendar{Name: name, Location: c.location}
+	for _, event := range events {
+		if event.Start == "" {
+			continue
+		}
+		ev, err := c.eventToICS(event, uids)
+		if err != nil {
+			return fmt.Errorf("failed to export event %s: %w", event.ID, err)
+		}
+		cal.Events = append(cal.Events, ev)
+	}
+
+	return ical.Write(w, cal)
+}
+
+// eventToICS converts an event to a VEVENT
+func (c *Client) eventToICS(event Event, seriesUIDs map[string]string) (ical.Event, error) {
+	start, err := c.parseDate(event.Start)
+	if err != nil {
+		return ical.Event{}, err
+	}
+
+	ev := ical.Event{
+		UID:         eventUID(event),
+		Summary:     event.Title,
+		Description: event.Notes,
+		Location:    event.Location,
+		Status:      c.icsStatus(event.Status),
+		Start:       start.Time,
+		AllDay:      event.AllDay,
+		URL:         event.URL,
+	}
+	if event.Type != "" {
+		ev.Categories = []string{event.Type}
+	}
+	if t, err := time.Parse(time.RFC3339, event.CreatedAt); err == nil {
+		ev.Created = t
+	}
+	if t, err := time.Parse(time.RFC3339, event.UpdatedAt); err == nil {
+		ev.LastModified = t
+	}
+
+	// iCalendar ends are exclusive; all-day ranges in Notion end on their
+	// last day
+	switch {
+	case event.End != "":
+		end, err := c.parseDate(event.End)
+		if err != nil {
+			return ical.Event{}, err
+		}
+		ev.End = end.Time
+		if event.AllDay {
+			ev.End = dayStart(end.Time).AddDate(0, 0, 1)
+		}
+	case event.AllDay:
+		ev.End = start.Time.AddDate(0, 0, 1)
+	}
+
+	for _, attendee := range event.Attendees {
+		if strings.Contains(attendee, "@") {
+			ev.Attendees = append(ev.Attendees, ical.Attendee{Email: attendee})
+		} else {
+			ev.Attendees = append(ev.Attendees, ical.Attendee{Name: attendee})
+		}
+	}
+
+	if event.Repeat != "" {
+		rule, err := recur.Parse(event.Repeat)
+		if err != nil {
+			return ical.Event{}, err
+		}
+		for _, day := range rule.Exdates {
+			d, err := time.ParseInLocation("2006-01-02", day, c.location)
+			if err != nil {
+				continue
+			}
+			t := start.Time
+			ev.ExDates = append(ev.ExDates, time.Date(d.Year(), d.Month(), d.Day(), t.Hour(), t.Minute(), t.Second(), 0, c.location))
+		}
+		rule.Exdates = nil
+		ev.RRule = rule.String()
+		// UNTIL has to be a UTC date-time when DTSTART has a time of day
+		if !event.AllDay && !rule.Until.IsZero() {
+			u := rule.Until
+			last := time.Date(u.Year(), u.Month(), u.Day(), 23, 59, 59, 0, c.location)
+			ev.RRule = strings.Replace(ev.RRule, "UNTIL="+u.Format("20060102"), "UNTIL="+last.UTC().Format("20060102T150405Z"), 1)
+		}
+	}
+
+	if uid, ok := seriesUIDs[event.SeriesID]; ok && event.SeriesID != "" {
+		ev.UID = uid
+		ev.RecurrenceID = start.Time
+	}
+
+	return ev, nil
+}
+
+// ImportICS creates or updates events from an iCalendar stream. Events are
+// matched by UID: calendars exported by this tool use the page ID, and UIDs
+// from other calendars are stored in the UID property when the database has
+// one. Overrides of single occurrences update the matching occurrence of the
+// recurring event. Failed events are reported in the results and the import
+// carries on with the rest.
+func (c *Client) ImportICS(ctx context.Context, databaseID string, r io.Reader) ([]ImportResult, error) {
+	cal, err := ical.Read(r, c.location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse calendar: %w", err)
+	}
+
+	schema, err := c.databaseSchema(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	_, hasUID := schema[c.settings.UIDProperty]
+
+	ical.SortOverridesLast(cal.Events)
+
+	results := make([]ImportResult, 0, len(cal.Events))
+	failed := 0
+	for _, ev := range cal.Events {
+		event, action, err := c.importEvent(ctx, databaseID, ev, hasUID)
+		result := ImportResult{UID: ev.UID, Action: action, Event: event}
+		if err != nil {
+			result.Action = "failed"
+			result.Error = err.Error()
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf("%d of %d events failed to import", failed, len(results))
+	}
+	return results, nil
+}
+
+// importEvent creates or updates the page for one VEVENT
+func (c *Client) importEvent(ctx context.Context, databaseID string, ev ical.Event, hasUID bool) (*Event, string, error) {
+	input, err := c.icsToInput(ev)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pageID, byPageID, err := c.findByUID(ctx, databaseID, ev.UID, hasUID)
+	if err != nil {
+		return nil, "", err
+	}
+	if hasUID && !byPageID {
+		input.UID = ev.UID
+	}
+
+	if !ev.RecurrenceID.IsZero() {
+		if pageID == "" {
+			return nil, "", fmt.Errorf("recurring event %s is not in the database", ev.UID)
+		}
+		input.SeriesID, input.UID, input.Repeat = pageID, "", ""
+		pageID, err = c.findOccurrence(ctx, databaseID, pageID, ev.RecurrenceID)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	if pageID != "" {
+		event, err := c.UpdateEvent(ctx, pageID, input)
+		return event, "updated", err
+	}
+	event, err := c.CreateEvent(ctx, input, databaseID)
+	return event, "created", err
+}
+
+// icsToInput converts a VEVENT to event input
+func (c *Client) icsToInput(ev ical.Event) (EventInput, error) {
+	input := EventInput{
+		Title:    ev.Summary,
+		Date:     c.dateInput(ev.Start, !ev.AllDay),
+		AllDay:   ev.AllDay,
+		Location: ev.Location,
+		Status:   c.notionStatus(ev.Status),
+		Notes:    ev.Description,
+	}
+	if input.Title == "" {
+		input.Title = "(no title)"
+	}
+	if len(ev.Categories) > 0 {
+		input.Type = ev.Categories[0]
+	}
+	for _, a := range ev.Attendees {
+		if a.Email != "" {
+			input.Attendees = append(input.Attendees, a.Email)
+		} else {
+			input.Attendees = append(input.Attendees, a.Name)
+		}
+	}
+
+	if !ev.End.IsZero() && ev.End.After(ev.Start) {
+		if ev.AllDay {
+			// Back from an exclusive end to the last day of the range
+			if last := ev.End.AddDate(0, 0, -1); last.After(ev.Start) {
+				input.End = c.dateInput(last, false)
+			}
+		} else {
+			input.End = c.dateInput(ev.End, true)
+		}
+	}
+
+	if ev.RRule != "" {
+		rule, err := recur.Parse(ev.RRule)
+		if err != nil {
+			return input, fmt.Errorf("unsupported recurrence rule %q: %w", ev.RRule, err)
+		}
+		for _, d := range ev.ExDates {
+			rule = rule.Skip(d.In(c.location))
+		}
+		input.Repeat = rule.String()
+	}
+
+	return input, nil
+}
+
+// findByUID looks up the page an imported UID refers to. The boolean result
+// reports whether the UID was the page's own ID.
+func (c *Client) findByUID(ctx context.Context, databaseID, uid string, hasUID bool) (string, bool, error) {
+	if isPageID(uid) {
+		page, err := c.getPage(ctx, uid)
+		var apiErr *notionapi.Error
+		switch {
+		case err == nil:
+			if !page.Archived && sameID(string(page.Parent.DatabaseID), databaseID) {
+				return string(page.ID), true, nil
+			}
+		case errors.As(err, &apiErr) && (apiErr.Status == 404 || apiErr.Status == 400):
+			// Not a page of this workspace; fall back to the UID property
+		default:
+			return "", false, fmt.Errorf("failed to look up event %s: %w", uid, err)
+		}
+	}
+
+	if !hasUID {
+		return "", false, nil
+	}
+	pages, err := c.queryAllPages(ctx, databaseID, notionapi.PropertyFilter{
+		Property: c.settings.UIDProperty,
+		RichText: &notionapi.TextFilterCondition{Equals: uid},
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to look up event %s: %w", uid, err)
+	}
+	if len(pages) == 0 {
+		return "", false, nil
+	}
+	return string(pages[0].ID), false, nil
+}
+
+// findOccurrence returns the page of a series' occurrence on the day of the
+// given original start, or an empty ID when there is none
+func (c *Client) findOccurrence(ctx context.Context, databaseID, seriesID string, at time.Time) (string, error) {
+	pages, err := c.seriesPages(ctx, databaseID, seriesID)
+	if err != nil {
+		return "", err
+	}
+	day := at.In(c.location).Format("2006-01-02")
+	for i := range pages {
+		if start, _, ok := c.eventSpan(&pages[i]); ok && start.Format("2006-01-02") == day {
+			return string(pages[i].ID), nil
+		}
+	}
+	return "", nil
+}
+
+// eventUID returns the iCalendar UID of an event: the UID it was imported
+// with, or its page ID
+func eventUID(event Event) string {
+	if event.UID != "" {
+		return event.UID
+	}
+	return event.ID
+}
+
+// icsStatus maps an event status onto the iCalendar STATUS values
+func (c *Client) icsStatus(status string) string {
+	switch {
+	case status == "":
+		return ""
+	case c.settings.EventStatuses.IsCancelled(status):
+		return "CANCELLED"
+	case strings.EqualFold(status, "tentative"):
+		return "TENTATIVE"
+	default:
+		return "CONFIRMED"
+	}
+}
+
+// notionStatus maps an iCalendar STATUS onto the event statuses
+func (c *Client) notionStatus(status string) string {
+	groups := c.settings.EventStatuses
+	switch {
+	case status == "CANCELLED" && len(groups.Cancelled) > 0:
+		return groups.Cancelled[0]
+	case status == "TENTATIVE":
+		return "Tentative"
+	case status == "CONFIRMED" && len(groups.Open) > 0:
+		return groups.Open[0]
+	}
+	return ""
+}
+
+// isPageID reports whether s has the shape of a Notion page ID
+func isPageID(s string) bool {
+	s = strings.ReplaceAll(s, "-", "")
+	if len(s) != 32 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameID compares two Notion IDs regardless of dashes and case
+func sameID(a, b string) bool {
+	return normalizeID(a) == normalizeID(b)
+}
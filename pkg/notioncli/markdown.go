@@ -0,0 +1,389 @@
+package notioncli
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jomei/notionapi"
+)
+
+// maxTextLength is the most characters Notion accepts in one rich text
+// object
+const maxTextLength = 2000
+
+var (
+	headingRe  = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	toDoRe     = regexp.MustCompile(`^[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	bulletRe   = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	numberedRe = regexp.MustCompile(`^\d+[.)]\s+(.*)$`)
+	quoteRe    = regexp.MustCompile(`^>\s?(.*)$`)
+	dividerRe  = regexp.MustCompile(`^(?:-{3,}|\*{3,}|_{3,})$`)
+	linkRe     = regexp.MustCompile(`^\[([^\]]+)\]\(([^)\s]+)\)`)
+)
+
+// inlineMarks are the emphasis delimiters read in Markdown text, longest
+// first so that ** isn't taken for two *
+var inlineMarks = []struct {
+	delim string
+	set   func(*notionapi.Annotations)
+}{
+	{"**", func(a *notionapi.Annotations) { a.Bold = true }},
+	{"__", func(a *notionapi.Annotations) { a.Bold = true }},
+	{"~~", func(a *notionapi.Annotations) { a.Strikethrough = true }},
+	{"*", func(a *notionapi.Annotations) { a.Italic = true }},
+	{"_", func(a *notionapi.Annotations) { a.Italic = true }},
+}
+
+// MarkdownToBlocks converts Markdown to Notion blocks: headings, bulleted,
+// numbered and to-do list items, quotes, fenced code, rules and paragraphs,
+// with bold, italic, strikethrough, inline code and links in their text.
+// Lines of a paragraph are kept together, nested lists are flattened and
+// headings below ### become level 3.
+func MarkdownToBlocks(md string) []notionapi.Block {
+	var blocks []notionapi.Block
+	var para, quote []string
+	flush := func() {
+		if len(para) > 0 {
+			blocks = append(blocks, &notionapi.ParagraphBlock{
+				BasicBlock: basicBlock(notionapi.BlockTypeParagraph),
+				Paragraph:  notionapi.Paragraph{RichText: inlineText(strings.Join(para, "\n"))},
+			})
+			para = nil
+		}
+		if len(quote) > 0 {
+			blocks = append(blocks, &notionapi.QuoteBlock{
+				BasicBlock: basicBlock(notionapi.BlockQuote),
+				Quote:      notionapi.Quote{RichText: inlineText(strings.Join(quote, "\n"))},
+			})
+			quote = nil
+		}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if m := quoteRe.FindStringSubmatch(line); m != nil {
+			if len(para) > 0 {
+				flush()
+			}
+			quote = append(quote, m[1])
+			continue
+		}
+		if line == "" || len(quote) > 0 {
+			flush()
+		}
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "```") {
+			flush()
+			language := strings.TrimSpace(strings.TrimPrefix(line, "```"))
+			var code []string
+			for i++; i < len(lines) && strings.TrimSpace(lines[i]) != "```"; i++ {
+				code = append(code, lines[i])
+			}
+			blocks = append(blocks, codeBlock(strings.Join(code, "\n"), language))
+			continue
+		}
+
+		var block notionapi.Block
+		switch {
+		case dividerRe.MatchString(line):
+			block = &notionapi.DividerBlock{BasicBlock: basicBlock(notionapi.BlockTypeDivider)}
+		case headingRe.MatchString(line):
+			m := headingRe.FindStringSubmatch(line)
+			block = headingBlock(len(m[1]), inlineText(m[2]))
+		case toDoRe.MatchString(line):
+			m := toDoRe.FindStringSubmatch(line)
+			block = &notionapi.ToDoBlock{
+				BasicBlock: basicBlock(notionapi.BlockTypeToDo),
+				ToDo:       notionapi.ToDo{RichText: inlineText(m[2]), Checked: m[1] != " "},
+			}
+		case bulletRe.MatchString(line):
+			block = &notionapi.BulletedListItemBlock{
+				BasicBlock:       basicBlock(notionapi.BlockTypeBulletedListItem),
+				BulletedListItem: notionapi.ListItem{RichText: inlineText(bulletRe.FindStringSubmatch(line)[1])},
+			}
+		case numberedRe.MatchString(line):
+			block = &notionapi.NumberedListItemBlock{
+				BasicBlock:       basicBlock(notionapi.BlockTypeNumberedListItem),
+				NumberedListItem: notionapi.ListItem{RichText: inlineText(numberedRe.FindStringSubmatch(line)[1])},
+			}
+		default:
+			para = append(para, line)
+			continue
+		}
+		flush()
+		blocks = append(blocks, block)
+	}
+	flush()
+	return blocks
+}
+
+func basicBlock(t notionapi.BlockType) notionapi.BasicBlock {
+	return notionapi.BasicBlock{Object: notionapi.ObjectTypeBlock, Type: t}
+}
+
+// headingBlock returns a heading of level 1 to 3; deeper levels become 3
+func headingBlock(level int, text []notionapi.RichText) notionapi.Block {
+	heading := notionapi.Heading{RichText: text}
+	switch level {
+	case 1:
+		return &notionapi.Heading1Block{BasicBlock: basicBlock(notionapi.BlockTypeHeading1), Heading1: heading}
+	case 2:
+		return &notionapi.Heading2Block{BasicBlock: basicBlock(notionapi.BlockTypeHeading2), Heading2: heading}
+	}
+	return &notionapi.Heading3Block{BasicBlock: basicBlock(notionapi.BlockTypeHeading3), Heading3: heading}
+}
+
+func codeBlock(code, language string) notionapi.Block {
+	if language == "" {
+		language = "plain text"
+	}
+	return &notionapi.CodeBlock{
+		BasicBlock: basicBlock(notionapi.BlockTypeCode),
+		Code: notionapi.Code{
+			RichText: appendText(nil, code, notionapi.Annotations{}, ""),
+			Language: language,
+		},
+	}
+}
+
+// inlineText converts a line of Markdown to rich text
+func inlineText(s string) []notionapi.RichText {
+	var out []notionapi.RichText
+	parseInline(s, notionapi.Annotations{}, "", &out)
+	return out
+}
+
+// parseInline appends the rich text of s, annotated with ann and linking
+// to link, to out
+func parseInline(s string, ann notionapi.Annotations, link string, out *[]notionapi.RichText) {
+	var plain strings.Builder
+	flush := func() {
+		if plain.Len() > 0 {
+			*out = appendText(*out, plain.String(), ann, link)
+			plain.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		rest := s[i:]
+		switch {
+		case rest[0] == '\\' && len(rest) > 1 && strings.IndexByte("\\`*_~[]()#>-", rest[1]) >= 0:
+			plain.WriteByte(rest[1])
+			i += 2
+			continue
+		case rest[0] == '`':
+			if end := strings.IndexByte(rest[1:], '`'); end > 0 {
+				flush()
+				code := ann
+				code.Code = true
+				*out = appendText(*out, rest[1:1+end], code, link)
+				i += end + 2
+				continue
+			}
+		case rest[0] == '[' && link == "":
+			if m := linkRe.FindStringSubmatch(rest); m != nil {
+				flush()
+				parseInline(m[1], ann, m[2], out)
+				i += len(m[0])
+				continue
+			}
+		}
+
+		if n := emphasis(s, i, ann, link, flush, out); n > 0 {
+			i += n
+			continue
+		}
+		plain.WriteByte(rest[0])
+		i++
+	}
+	flush()
+}
+
+// emphasis parses an emphasized span starting at s[i], if there is one, and
+// returns its length
+func emphasis(s string, i int, ann notionapi.Annotations, link string, flush func(), out *[]notionapi.RichText) int {
+	rest := s[i:]
+	for _, m := range inlineMarks {
+		if !strings.HasPrefix(rest, m.delim) {
+			continue
+		}
+		end := strings.Index(rest[len(m.delim):], m.delim)
+		if end <= 0 {
+			continue
+		}
+		inner := rest[len(m.delim) : len(m.delim)+end]
+		if strings.TrimSpace(inner) != inner {
+			continue
+		}
+		// Underscores inside words, as in snake_case, aren't emphasis
+		after := i + 2*len(m.delim) + end
+		if m.delim[0] == '_' && (wordBefore(s, i) || wordAfter(s, after)) {
+			continue
+		}
+		flush()
+		a := ann
+		m.set(&a)
+		parseInline(inner, a, link, out)
+		return after - i
+	}
+	return 0
+}
+
+func wordBefore(s string, i int) bool {
+	r, _ := utf8.DecodeLastRuneInString(s[:i])
+	return i > 0 && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+func wordAfter(s string, i int) bool {
+	r, _ := utf8.DecodeRuneInString(s[i:])
+	return i < len(s) && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// appendText appends text to rich text, split into pieces Notion accepts
+func appendText(out []notionapi.RichText, text string, ann notionapi.Annotations, link string) []notionapi.RichText {
+	runes := []rune(text)
+	for len(runes) > 0 {
+		n := len(runes)
+		if n > maxTextLength {
+			n = maxTextLength
+		}
+		rt := notionapi.RichText{
+			Type: notionapi.ObjectTypeText,
+			Text: &notionapi.Text{Content: string(runes[:n])},
+		}
+		if link != "" {
+			rt.Text.Link = &notionapi.Link{Url: link}
+		}
+		if ann != (notionapi.Annotations{}) {
+			a := ann
+			rt.Annotations = &a
+		}
+		out = append(out, rt)
+		runes = runes[n:]
+	}
+	return out
+}
+
+// BlocksToMarkdown renders blocks as Markdown, the way MarkdownToBlocks
+// reads it. Toggles and callouts become paragraphs; blocks without text,
+// such as images, are left out.
+func BlocksToMarkdown(blocks []notionapi.Block) string {
+	var b strings.Builder
+	var prev notionapi.BlockType
+	number := 0
+	for _, block := range blocks {
+		text, ok := blockMarkdown(block)
+		if !ok {
+			continue
+		}
+		t := block.GetType()
+		if t == notionapi.BlockTypeNumberedListItem {
+			if prev != t {
+				number = 0
+			}
+			number++
+			text = strconv.Itoa(number) + ". " + text
+		}
+		if b.Len() > 0 {
+			if list := listKind(t); list != "" && list == listKind(prev) {
+				b.WriteString("\n")
+			} else {
+				b.WriteString("\n\n")
+			}
+		}
+		b.WriteString(text)
+		prev = t
+	}
+	return b.String()
+}
+
+// listKind returns the marker of list items of a block type, or "" for
+// blocks that aren't list items. Items of the same kind are written on
+// consecutive lines.
+func listKind(t notionapi.BlockType) string {
+	switch t {
+	case notionapi.BlockTypeBulletedListItem, notionapi.BlockTypeToDo:
+		return "-"
+	case notionapi.BlockTypeNumberedListItem:
+		return "1."
+	}
+	return ""
+}
+
+// blockMarkdown renders one block, without the number of a numbered list
+// item, and reports whether it has a Markdown form
+func blockMarkdown(block notionapi.Block) (string, bool) {
+	switch b := block.(type) {
+	case *notionapi.ParagraphBlock:
+		return markdownText(b.Paragraph.RichText), true
+	case *notionapi.Heading1Block:
+		return "# " + markdownText(b.Heading1.RichText), true
+	case *notionapi.Heading2Block:
+		return "## " + markdownText(b.Heading2.RichText), true
+	case *notionapi.Heading3Block:
+		return "### " + markdownText(b.Heading3.RichText), true
+	case *notionapi.BulletedListItemBlock:
+		return "- " + markdownText(b.BulletedListItem.RichText), true
+	case *notionapi.NumberedListItemBlock:
+		return markdownText(b.NumberedListItem.RichText), true
+	case *notionapi.ToDoBlock:
+		box := "[ ]"
+		if b.ToDo.Checked {
+			box = "[x]"
+		}
+		return "- " + box + " " + markdownText(b.ToDo.RichText), true
+	case *notionapi.QuoteBlock:
+		return "> " + strings.ReplaceAll(markdownText(b.Quote.RichText), "\n", "\n> "), true
+	case *notionapi.CodeBlock:
+		language := b.Code.Language
+		if language == "plain text" {
+			language = ""
+		}
+		return "```" + language + "\n" + extractRichText(b.Code.RichText) + "\n```", true
+	case *notionapi.DividerBlock:
+		return "---", true
+	case *notionapi.ToggleBlock:
+		return markdownText(b.Toggle.RichText), true
+	case *notionapi.CalloutBlock:
+		return markdownText(b.Callout.RichText), true
+	}
+	return "", false
+}
+
+// markdownText renders rich text as Markdown
+func markdownText(richTexts []notionapi.RichText) string {
+	var b strings.Builder
+	for _, rt := range richTexts {
+		text := plainText(rt)
+		if a := rt.Annotations; a != nil && strings.TrimSpace(text) != "" {
+			if a.Code {
+				text = "`" + text + "`"
+			}
+			if a.Strikethrough {
+				text = "~~" + text + "~~"
+			}
+			if a.Italic {
+				text = "*" + text + "*"
+			}
+			if a.Bold {
+				text = "**" + text + "**"
+			}
+		}
+		href := rt.Href
+		if href == "" && rt.Text != nil && rt.Text.Link != nil {
+			href = rt.Text.Link.Url
+		}
+		if href != "" {
+			text = "[" + text + "](" + href + ")"
+		}
+		b.WriteString(text)
+	}
+	return b.String()
+}
@@ -0,0 +1,98 @@
+package notioncli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/jontk/notion-cli/internal/notiontest"
+)
+
+const testMarkdown = "# Release notes\n\n" +
+	"Ships **faster** builds, *fewer* `allocs` and a [changelog](https://example.com/log).\n" +
+	"Second line of the same paragraph, with a snake_case_name.\n\n" +
+	"- First\n" +
+	"- Second\n" +
+	"- [x] Done\n" +
+	"- [ ] Not yet\n\n" +
+	"1. One\n" +
+	"2. Two\n\n" +
+	"> Quoted\n> twice\n\n" +
+	"---\n\n" +
+	"```go\nfmt.Println(\"*not emphasis*\")\n```"
+
+func TestMarkdownToBlocks(t *testing.T) {
+	blocks := MarkdownToBlocks(testMarkdown)
+
+	var types []string
+	for _, b := range blocks {
+		types = append(types, string(b.GetType()))
+	}
+	want := "heading_1 paragraph bulleted_list_item bulleted_list_item to_do to_do numbered_list_item numbered_list_item quote divider code"
+	if got := strings.Join(types, " "); got != want {
+		t.Fatalf("block types = %s\nwant %s", got, want)
+	}
+
+	para := blocks[1].(*notionapi.ParagraphBlock).Paragraph.RichText
+	var bold, italic, code, link string
+	for _, rt := range para {
+		switch {
+		case rt.Annotations != nil && rt.Annotations.Bold:
+			bold = rt.Text.Content
+		case rt.Annotations != nil && rt.Annotations.Italic:
+			italic = rt.Text.Content
+		case rt.Annotations != nil && rt.Annotations.Code:
+			code = rt.Text.Content
+		case rt.Text.Link != nil:
+			link = rt.Text.Content + " " + rt.Text.Link.Url
+		}
+	}
+	if bold != "faster" || italic != "fewer" || code != "allocs" || link != "changelog https://example.com/log" {
+		t.Errorf("inline text: bold %q, italic %q, code %q, link %q", bold, italic, code, link)
+	}
+	if text := extractRichText(para); !strings.Contains(text, "builds,") || !strings.Contains(text, "\nSecond line") || !strings.Contains(text, "snake_case_name") {
+		t.Errorf("paragraph text = %q", text)
+	}
+	if !blocks[4].(*notionapi.ToDoBlock).ToDo.Checked || blocks[5].(*notionapi.ToDoBlock).ToDo.Checked {
+		t.Error("to-do items aren't checked as written")
+	}
+	if c := blocks[10].(*notionapi.CodeBlock).Code; c.Language != "go" || extractRichText(c.RichText) != `fmt.Println("*not emphasis*")` {
+		t.Errorf("code block = %q in %q", extractRichText(c.RichText), c.Language)
+	}
+}
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	if got := BlocksToMarkdown(MarkdownToBlocks(testMarkdown)); got != testMarkdown {
+		t.Errorf("round trip changed the Markdown:\n%s\nwant\n%s", got, testMarkdown)
+	}
+}
+
+func TestMarkdownLongText(t *testing.T) {
+	blocks := MarkdownToBlocks(strings.Repeat("a", 4500))
+	rts := blocks[0].(*notionapi.ParagraphBlock).Paragraph.RichText
+	if len(rts) != 3 || len([]rune(rts[0].Text.Content)) != maxTextLength {
+		t.Errorf("4500 characters split into %d rich texts, want 3 of at most %d", len(rts), maxTextLength)
+	}
+}
+
+func TestGetPageMarkdown(t *testing.T) {
+	client, srv := newTestClient(t)
+	ctx := context.Background()
+	posts := srv.AddDatabase("Posts", notiontest.PostsSchema())
+	post, err := client.CreatePost(ctx, PostInput{Title: "Notes"}, posts)
+	if err != nil {
+		t.Fatalf("CreatePost: %v", err)
+	}
+	if _, err := client.AppendMarkdown(ctx, post.ID, testMarkdown); err != nil {
+		t.Fatalf("AppendMarkdown: %v", err)
+	}
+
+	md, err := client.GetPageMarkdown(ctx, post.ID)
+	if err != nil {
+		t.Fatalf("GetPageMarkdown: %v", err)
+	}
+	if md != testMarkdown {
+		t.Errorf("page Markdown:\n%s\nwant\n%s", md, testMarkdown)
+	}
+}
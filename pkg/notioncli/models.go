@@ -0,0 +1,294 @@
+package notioncli
+
+type Post struct {
+	ID              string   `json:"id"`
+	Title           string   `json:"title"`
+	Content         string   `json:"content,omitempty"`
+	Status          string   `json:"status"`
+	Week            int      `json:"week,omitempty"`
+	Pillar          string   `json:"pillar,omitempty"`
+	PublishDate     string   `json:"publish_date,omitempty"`
+	PublishedDate   string   `json:"published_date,omitempty"`
+	BlogURL         string   `json:"blog_url,omitempty"`
+	DistributedTo   []string `json:"distributed_to,omitempty"`
+	DistributedDate string   `json:"distributed_date,omitempty"`
+	LinkedInDraft   string   `json:"linkedin_draft,omitempty"`
+	TwitterThread   string   `json:"twitter_thread,omitempty"`
+	HNTitle         string   `json:"hn_title,omitempty"`
+	RedditTitle     string   `json:"reddit_title,omitempty"`
+	Hashtags        []string `json:"hashtags,omitempty"`
+	URL             string   `json:"url"`
+	CreatedAt       string   `json:"created_at"`
+	UpdatedAt       string   `json:"updated_at"`
+}
+
+type PostInput struct {
+	Title string `json:"title,omitempty"`
+	// Content is written as plain paragraphs, split at blank lines. Use
+	// AppendMarkdown for formatted content.
+	Content         string   `json:"content,omitempty"`
+	Status          string   `json:"status,omitempty"`
+	Week            int      `json:"week,omitempty"`
+	Pillar          string   `json:"pillar,omitempty"`
+	PublishDate     string   `json:"publish_date,omitempty"`
+	PublishedDate   string   `json:"published_date,omitempty"`
+	BlogURL         string   `json:"blog_url,omitempty"`
+	DistributedTo   []string `json:"distributed_to,omitempty"`
+	DistributedDate string   `json:"distributed_date,omitempty"`
+	LinkedInDraft   string   `json:"linkedin_draft,omitempty"`
+	TwitterThread   string   `json:"twitter_thread,omitempty"`
+	HNTitle         string   `json:"hn_title,omitempty"`
+	RedditTitle     string   `json:"reddit_title,omitempty"`
+	Hashtags        []string `json:"hashtags,omitempty"`
+}
+
+type Task struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Status    string   `json:"status"`
+	Priority  string   `json:"priority,omitempty"`
+	DueDate   string   `json:"due_date,omitempty"`
+	Category  string   `json:"category,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Notes     string   `json:"notes,omitempty"`
+	Repeat    string   `json:"repeat,omitempty"`
+	SeriesID  string   `json:"series_id,omitempty"`
+	NextID    string   `json:"next_id,omitempty"`
+	ParentID  string   `json:"parent_id,omitempty"`
+	BlockedBy []string `json:"blocked_by,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+	// ChecklistProgress counts the checked to-do blocks in the page body,
+	// as in "3/5". Reading it takes a request per page, so QueryTasks only
+	// fills it in with TaskQueryOptions.Checklist; TaskChecklist gives it
+	// for a single task.
+	ChecklistProgress string `json:"checklist_progress,omitempty"`
+	URL               string `json:"url"`
+	CreatedAt         string `json:"created_at"`
+	UpdatedAt         string `json:"updated_at"`
+}
+
+// ChecklistItem is a to-do block in the body of a task page
+type ChecklistItem struct {
+	Index   int    `json:"index"`
+	ID      string `json:"id"`
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+}
+
+// Checklist is the to-do list of a task, numbered in page order
+type Checklist struct {
+	TaskID   string          `json:"task_id"`
+	Progress string          `json:"progress,omitempty"`
+	Items    []ChecklistItem `json:"items"`
+}
+
+type TaskInput struct {
+	Title    string   `json:"title"`
+	Status   string   `json:"status,omitempty"`
+	Priority string   `json:"priority,omitempty"`
+	DueDate  string   `json:"due_date,omitempty"`
+	Category string   `json:"category,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Notes    string   `json:"notes,omitempty"`
+	Repeat   string   `json:"repeat,omitempty"`
+	SeriesID string   `json:"series_id,omitempty"`
+	ParentID string   `json:"parent_id,omitempty"`
+	// BlockedBy replaces the blocked-by relation when not nil
+	BlockedBy []string `json:"blocked_by,omitempty"`
+	// Assignees are user IDs, emails or names, or "me"
+	Assignees []string `json:"assignees,omitempty"`
+}
+
+type Event struct {
+	ID              string   `json:"id"`
+	Title           string   `json:"title"`
+	Date            string   `json:"date"`
+	Start           string   `json:"start,omitempty"`
+	End             string   `json:"end,omitempty"`
+	AllDay          bool     `json:"all_day"`
+	DurationMinutes int      `json:"duration_minutes,omitempty"`
+	Type            string   `json:"type,omitempty"`
+	Location        string   `json:"location,omitempty"`
+	Attendees       []string `json:"attendees,omitempty"`
+	Status          string   `json:"status,omitempty"`
+	Notes           string   `json:"notes,omitempty"`
+	Repeat          string   `json:"repeat,omitempty"`
+	SeriesID        string   `json:"series_id,omitempty"`
+	UID             string   `json:"uid,omitempty"`
+	URL             string   `json:"url"`
+	CreatedAt       string   `json:"created_at"`
+	UpdatedAt       string   `json:"updated_at"`
+}
+
+type EventInput struct {
+	Title     string   `json:"title"`
+	Date      string   `json:"date,omitempty"`
+	End       string   `json:"end,omitempty"`
+	Duration  string   `json:"duration,omitempty"`
+	AllDay    bool     `json:"all_day,omitempty"`
+	Type      string   `json:"type,omitempty"`
+	Location  string   `json:"location,omitempty"`
+	Attendees []string `json:"attendees,omitempty"`
+	Status    string   `json:"status,omitempty"`
+	Notes     string   `json:"notes,omitempty"`
+	Repeat    string   `json:"repeat,omitempty"`
+	SeriesID  string   `json:"series_id,omitempty"`
+	UID       string   `json:"uid,omitempty"`
+}
+
+type User struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+	Type  string `json:"type"`
+}
+
+type SearchResult struct {
+	ID         string `json:"id"`
+	Object     string `json:"object"`
+	Title      string `json:"title"`
+	ParentType string `json:"parent_type"`
+	ParentID   string `json:"parent_id,omitempty"`
+	Icon       string `json:"icon,omitempty"`
+	URL        string `json:"url"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+// BlockInfo is a block of page content with the blocks nested in it
+type BlockInfo struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	// Text is the plain text of the block, or the code of a code block
+	Text string `json:"text,omitempty"`
+	// Checked is set for to-do blocks
+	Checked  *bool       `json:"checked,omitempty"`
+	Archived bool        `json:"archived,omitempty"`
+	Children []BlockInfo `json:"children,omitempty"`
+}
+
+type DatabaseInfo struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// PropertyInfo describes a database property. Only the fields that apply
+// to its type are set.
+type PropertyInfo struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	// Options are the choices of a select, multi-select or status property
+	Options []OptionInfo `json:"options,omitempty"`
+	// Groups sort the options of a status property into To-do, In
+	// progress and Complete
+	Groups []StatusGroupInfo `json:"groups,omitempty"`
+	// Format is the display format of a number property, e.g. "percent"
+	Format     string        `json:"format,omitempty"`
+	Relation   *RelationInfo `json:"relation,omitempty"`
+	Rollup     *RollupInfo   `json:"rollup,omitempty"`
+	Expression string        `json:"expression,omitempty"`
+}
+
+type OptionInfo struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+}
+
+type StatusGroupInfo struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+	// Options are the names of the options in the group
+	Options []string `json:"options"`
+}
+
+type RelationInfo struct {
+	DatabaseID string `json:"database_id"`
+	// Type is single_property, or dual_property when the other database
+	// has a property relating back
+	Type           string `json:"type,omitempty"`
+	SyncedProperty string `json:"synced_property,omitempty"`
+}
+
+type RollupInfo struct {
+	// Relation is the relation property the rollup follows, Property the
+	// property it reads in the related pages
+	Relation string `json:"relation"`
+	Property string `json:"property"`
+	Function string `json:"function"`
+}
+
+// Schema describes a database: what it is, where it is and its properties
+type Schema struct {
+	ID          string                  `json:"id"`
+	Title       string                  `json:"title"`
+	Description string                  `json:"description,omitempty"`
+	Icon        string                  `json:"icon,omitempty"`
+	ParentType  string                  `json:"parent_type"`
+	ParentID    string                  `json:"parent_id,omitempty"`
+	URL         string                  `json:"url"`
+	Properties  map[string]PropertyInfo `json:"properties"`
+}
+
+// DatabaseSpec describes a database to create: its title, the page it goes
+// in and its properties by name. In a schema file ID says which existing
+// database it describes.
+type DatabaseSpec struct {
+	ID         string                  `json:"id,omitempty" yaml:"id,omitempty"`
+	Title      string                  `json:"title" yaml:"title"`
+	Parent     string                  `json:"parent,omitempty" yaml:"parent,omitempty"`
+	Properties map[string]PropertySpec `json:"properties" yaml:"properties"`
+}
+
+// PropertySpec describes a database property. Type is the API's name for
+// it, e.g. "rich_text" or "multi_select".
+type PropertySpec struct {
+	Type string `json:"type" yaml:"type"`
+	// Options are the choices of a select, multi-select or status property
+	Options []string `json:"options,omitempty" yaml:"options,omitempty"`
+	// Format is the display format of a number property, e.g. "percent"
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+	// Database is the ID of the database a relation points to. Empty
+	// means the database the property is in.
+	Database string `json:"database,omitempty" yaml:"database,omitempty"`
+	// Expression is the formula of a formula property
+	Expression string `json:"expression,omitempty" yaml:"expression,omitempty"`
+}
+
+type DatabaseCreated struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+	// ManualSteps lists what the API can't set up and has to be done in
+	// Notion, such as status options
+	ManualSteps []string `json:"manual_steps,omitempty"`
+}
+
+// SchemaFile is the content of a notion-schema.yaml: the databases a
+// workspace should have, by name
+type SchemaFile struct {
+	Databases map[string]DatabaseSpec `json:"databases" yaml:"databases"`
+}
+
+// SchemaChange is one difference between a schema file and a database.
+// Action is one of add_property, rename_property, change_type,
+// update_property, remove_property, add_option, remove_option or manual.
+type SchemaChange struct {
+	Database   string        `json:"database"`
+	DatabaseID string        `json:"database_id"`
+	Action     string        `json:"action"`
+	Property   string        `json:"property,omitempty"`
+	Option     string        `json:"option,omitempty"`
+	From       string        `json:"from,omitempty"`
+	To         string        `json:"to,omitempty"`
+	Spec       *PropertySpec `json:"spec,omitempty"`
+	// Destructive changes lose values on existing pages
+	Destructive bool `json:"destructive,omitempty"`
+	// Detail says what to do in Notion for a manual change
+	Detail string `json:"detail,omitempty"`
+}
+
+type SchemaApplied struct {
+	Applied     []SchemaChange `json:"applied"`
+	ManualSteps []string       `json:"manual_steps,omitempty"`
+}
@@ -0,0 +1,89 @@
+package notioncli
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jomei/notionapi"
+)
+
+// Option configures a Client created with New
+type Option func(*Client)
+
+// WithLocation sets the timezone used to resolve dates that carry no zone.
+// The default is the local timezone.
+func WithLocation(loc *time.Location) Option {
+	return func(c *Client) {
+		c.SetLocation(loc)
+	}
+}
+
+// WithSettings sets the workspace's property names and status groups.
+// Empty fields keep their defaults; see DefaultSettings.
+func WithSettings(s Settings) Option {
+	return func(c *Client) {
+		c.SetSettings(s)
+	}
+}
+
+// WithUserCache keeps the workspace user list in a file for ttl; see
+// Client.SetUserCache
+func WithUserCache(path string, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.SetUserCache(path, ttl)
+	}
+}
+
+// WithHTTPClient sends the client's requests through hc, e.g. to set a
+// timeout or a proxy
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithRetries sets how many times a rate limited request is sent again,
+// after the delay Notion asks for. The default is 3; 0 turns retries off.
+func WithRetries(n int) Option {
+	return func(c *Client) {
+		if n >= 0 {
+			c.retries = n
+		}
+	}
+}
+
+// WithVersion sets the Notion API version requests are sent with. The
+// default is 2022-06-28, the version notionapi is written against.
+func WithVersion(version string) Option {
+	return func(c *Client) {
+		if version != "" {
+			c.version = version
+		}
+	}
+}
+
+// WithBaseURL sends requests to u, such as a proxy, rather than to
+// https://api.notion.com/v1/. Paths like "pages" are resolved against it.
+func WithBaseURL(u *url.URL) Option {
+	return func(c *Client) {
+		if u != nil {
+			base := *u
+			if !strings.HasSuffix(base.Path, "/") {
+				base.Path += "/"
+			}
+			c.baseURL = &base
+		}
+	}
+}
+
+// WithAPIOptions passes options on to the underlying notionapi client. Use
+// WithHTTPClient, WithRetries and WithVersion rather than their notionapi
+// equivalents, which bypass the client's retry handling or leave the
+// requests this package sends itself on another version.
+func WithAPIOptions(opts ...notionapi.ClientOption) Option {
+	return func(c *Client) {
+		c.apiOptions = append(c.apiOptions, opts...)
+	}
+}
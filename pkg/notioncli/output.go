@@ -0,0 +1,56 @@
+package notioncli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteJSON writes v to w as indented JSON, the form the CLI prints
+func WriteJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// WriteTable writes rows to w in columns padded to their widest cell, under
+// a header line and a separator
+func WriteTable(w io.Writer, headers []string, rows [][]string) error {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = len(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(widths) && len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i, h := range headers {
+		fmt.Fprintf(&b, "%-*s", widths[i]+2, h)
+	}
+	b.WriteString("\n")
+	for _, w := range widths {
+		b.WriteString(strings.Repeat("-", w+2))
+	}
+	b.WriteString("\n")
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(widths) {
+				fmt.Fprintf(&b, "%-*s", widths[i]+2, cell)
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,34 @@
+package notioncli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteTable(t *testing.T) {
+	var b strings.Builder
+	err := WriteTable(&b, []string{"ID", "TITLE"}, [][]string{
+		{"1", "Write docs"},
+		{"22", "Ship"},
+	})
+	if err != nil {
+		t.Fatalf("WriteTable: %v", err)
+	}
+	want := "ID  TITLE       \n" +
+		"----------------\n" +
+		"1   Write docs  \n" +
+		"22  Ship        \n"
+	if b.String() != want {
+		t.Errorf("table:\n%q\nwant\n%q", b.String(), want)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var b strings.Builder
+	if err := WriteJSON(&b, Task{ID: "t1", Title: "Ship"}); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	if !strings.Contains(b.String(), "\n  \"title\": \"Ship\",\n") {
+		t.Errorf("JSON isn't indented: %s", b.String())
+	}
+}
@@ -0,0 +1,360 @@
+package notioncli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jomei/notionapi"
+	"github.com/jontk/notion-cli/internal/dateparse"
+)
+
+// parseDate resolves a date expression such as "2026-03-20", "tomorrow" or
+// "+3d" in the client's timezone
+func (c *Client) parseDate(dateStr string) (dateparse.Result, error) {
+	return dateparse.Parse(dateStr, time.Now().In(c.location))
+}
+
+// ParsedDate is a resolved date expression: the instant, and whether the
+// expression gave a time of day
+type ParsedDate = dateparse.Result
+
+// ParseDate resolves a date expression the same way date flags are read
+func (c *Client) ParseDate(dateStr string) (ParsedDate, error) {
+	return c.parseDate(dateStr)
+}
+
+// richText builds a Notion rich text slice from a plain string
+func richText(content string) []notionapi.RichText {
+	return []notionapi.RichText{
+		{Text: &notionapi.Text{Content: content}},
+	}
+}
+
+// formatTime renders a timestamp in the client's timezone as RFC 3339
+func (c *Client) formatTime(t time.Time) string {
+	return t.In(c.location).Format(time.RFC3339)
+}
+
+// formatDate renders a Notion date in the client's timezone. Date-only values
+// are kept as YYYY-MM-DD so they don't shift to the previous or next day.
+func (c *Client) formatDate(d *notionapi.Date, dateOnly bool) string {
+	t := time.Time(*d)
+	if dateOnly {
+		return t.Format(dateLayout)
+	}
+	return c.formatTime(t)
+}
+
+// dateProperty builds a Notion date property from a date expression, as a
+// calendar date unless the expression has a time of day
+func (c *Client) dateProperty(dateStr string) (dateValue, error) {
+	r, err := c.parseDate(dateStr)
+	if err != nil {
+		return dateValue{}, err
+	}
+	return dateValue{Start: r.Time, Dates: !r.HasTime}, nil
+}
+
+// dateBound resolves a date expression for use in a query filter
+func (c *Client) dateBound(dateStr string) (*notionapi.Date, error) {
+	r, err := c.parseDate(dateStr)
+	if err != nil {
+		return nil, err
+	}
+	d := notionapi.Date(r.Time)
+	return &d, nil
+}
+
+// multiSelect builds a Notion multi-select property from a string slice
+func multiSelect(values []string) notionapi.MultiSelectProperty {
+	opts := make([]notionapi.Option, 0, len(values))
+	for _, v := range values {
+		opts = append(opts, notionapi.Option{Name: v})
+	}
+	return notionapi.MultiSelectProperty{MultiSelect: opts}
+}
+
+// CreatePost creates a new post in the Notion database
+func (c *Client) CreatePost(ctx context.Context, input PostInput, databaseID string) (*Post, error) {
+	enc, err := c.encoder(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	properties, err := c.postProperties(enc, input)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &notionapi.PageCreateRequest{
+		Parent: notionapi.Parent{
+			Type:       notionapi.ParentTypeDatabaseID,
+			DatabaseID: notionapi.DatabaseID(databaseID),
+		},
+		Properties: properties,
+	}
+
+	if input.Content != "" {
+		req.Children = contentToBlocks(input.Content)
+	}
+
+	page, err := c.createPage(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create page: %w", err)
+	}
+
+	return c.pageToPost(ctx, page)
+}
+
+// GetPost retrieves a single post by ID
+func (c *Client) GetPost(ctx context.Context, pageID string) (*Post, error) {
+	page, err := c.getPage(ctx, pageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page: %w", err)
+	}
+
+	return c.pageToPost(ctx, page)
+}
+
+// UpdatePost updates an existing post
+func (c *Client) UpdatePost(ctx context.Context, pageID string, input PostInput) (*Post, error) {
+	enc, err := c.pageEncoder(ctx, pageID)
+	if err != nil {
+		return nil, err
+	}
+	properties, err := c.postProperties(enc, input)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &notionapi.PageUpdateRequest{
+		Properties: properties,
+	}
+
+	page, err := c.updatePage(ctx, pageID, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update page: %w", err)
+	}
+
+	if input.Content != "" {
+		blocks := contentToBlocks(input.Content)
+		for _, block := range blocks {
+			_, err := c.api.Block.AppendChildren(ctx, notionapi.BlockID(pageID), &notionapi.AppendBlockChildrenRequest{
+				Children: []notionapi.Block{block},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to append content blocks: %w", err)
+			}
+		}
+	}
+
+	return c.pageToPost(ctx, page)
+}
+
+// postProperties encodes the fields set in a post input
+func (c *Client) postProperties(enc *propertyEncoder, input PostInput) (notionapi.Properties, error) {
+	if input.Title != "" {
+		enc.title(input.Title)
+	}
+	if input.Status != "" {
+		enc.choice("Status", input.Status, kindStatus)
+	}
+	if input.Week > 0 {
+		enc.number("Week", float64(input.Week))
+	}
+	if input.Pillar != "" {
+		enc.choice("Pillar", input.Pillar, kindSelect)
+	}
+	if input.PublishDate != "" {
+		prop, err := c.dateProperty(input.PublishDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid publish date: %w", err)
+		}
+		enc.date("Publish Date", prop)
+	}
+	if input.PublishedDate != "" {
+		prop, err := c.dateProperty(input.PublishedDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid published date: %w", err)
+		}
+		enc.date("Published Date", prop)
+	}
+	if input.BlogURL != "" {
+		enc.url("Blog URL", input.BlogURL)
+	}
+	if len(input.DistributedTo) > 0 {
+		enc.choices("Distributed To", input.DistributedTo)
+	}
+	if input.DistributedDate != "" {
+		prop, err := c.dateProperty(input.DistributedDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid distributed date: %w", err)
+		}
+		enc.date("Distributed Date", prop)
+	}
+	if input.LinkedInDraft != "" {
+		enc.text("LinkedIn Draft", input.LinkedInDraft)
+	}
+	if input.TwitterThread != "" {
+		enc.text("Twitter Thread", input.TwitterThread)
+	}
+	if input.HNTitle != "" {
+		enc.text("HN Title", input.HNTitle)
+	}
+	if input.RedditTitle != "" {
+		enc.text("Reddit Title", input.RedditTitle)
+	}
+	if len(input.Hashtags) > 0 {
+		enc.choices("Hashtags", input.Hashtags)
+	}
+
+	return enc.result()
+}
+
+// ArchivePost archives a post
+func (c *Client) ArchivePost(ctx context.Context, pageID string) (*Post, error) {
+	req := &notionapi.PageUpdateRequest{
+		Archived:   true,
+		Properties: notionapi.Properties{},
+	}
+
+	page, err := c.updatePage(ctx, pageID, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive page: %w", err)
+	}
+
+	return c.pageToPost(ctx, page)
+}
+
+// QueryOptions holds options for querying posts
+type QueryOptions struct {
+	Status        string
+	Pillar        string
+	DistributedTo string
+	Sort          string
+	Order         string
+	Limit         int
+}
+
+// QueryPosts queries posts from a database with filters
+func (c *Client) QueryPosts(ctx context.Context, databaseID string, opts QueryOptions) ([]Post, error) {
+	filters, err := c.queryFilters(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Status != "" {
+		filters.is("Status", opts.Status, kindStatus)
+	}
+	if opts.Pillar != "" {
+		filters.is("Pillar", opts.Pillar, kindSelect)
+	}
+	if opts.DistributedTo != "" {
+		filters.is("Distributed To", opts.DistributedTo, kindMultiSelect)
+	}
+
+	filter, err := filters.result()
+	if err != nil {
+		return nil, err
+	}
+
+	sortField := opts.Sort
+	if sortField == "" {
+		sortField = "created_time"
+	}
+	sortOrder := notionapi.SortOrderDESC
+	if opts.Order == "ascending" {
+		sortOrder = notionapi.SortOrderASC
+	}
+
+	sorts := []notionapi.SortObject{
+		{Timestamp: notionapi.TimestampType(sortField), Direction: sortOrder},
+	}
+
+	var allPosts []Post
+	var cursor *string
+	limit := opts.Limit
+	if limit == 0 {
+		limit = 100
+	}
+
+	for {
+		pageSize := 100
+		if remaining := limit - len(allPosts); remaining < pageSize {
+			pageSize = remaining
+		}
+
+		req := &notionapi.DatabaseQueryRequest{
+			Filter:   filter,
+			Sorts:    sorts,
+			PageSize: pageSize,
+		}
+
+		if cursor != nil {
+			req.StartCursor = notionapi.Cursor(*cursor)
+		}
+
+		resp, err := c.queryDatabase(ctx, databaseID, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query database: %w", err)
+		}
+
+		for _, page := range resp.Results {
+			post, err := c.pageToPost(ctx, &page)
+			if err != nil {
+				return nil, err
+			}
+			allPosts = append(allPosts, *post)
+		}
+
+		if !resp.HasMore || len(allPosts) >= limit {
+			break
+		}
+		cursorStr := string(resp.NextCursor)
+		cursor = &cursorStr
+	}
+
+	return allPosts, nil
+}
+
+// pageToPost converts a Notion page to our Post model
+func (c *Client) pageToPost(ctx context.Context, page *notionapi.Page) (*Post, error) {
+	post := &Post{
+		ID:        string(page.ID),
+		URL:       page.URL,
+		CreatedAt: c.formatTime(page.CreatedTime),
+		UpdatedAt: c.formatTime(page.LastEditedTime),
+	}
+
+	post.Title = pageTitle(page)
+	post.Status = propertyValue(page, "Status")
+	if prop, ok := page.Properties["Week"].(*notionapi.NumberProperty); ok {
+		post.Week = int(prop.Number)
+	}
+	post.Pillar = propertyValue(page, "Pillar")
+	if prop, ok := pageDate(page, "Publish Date"); ok {
+		post.PublishDate = c.formatDate(prop.Date.Start, prop.DateOnly)
+	}
+	if prop, ok := pageDate(page, "Published Date"); ok {
+		post.PublishedDate = c.formatDate(prop.Date.Start, prop.DateOnly)
+	}
+	post.BlogURL = propertyValue(page, "Blog URL")
+	post.DistributedTo = propertyValues(page, "Distributed To")
+	if prop, ok := pageDate(page, "Distributed Date"); ok {
+		post.DistributedDate = c.formatDate(prop.Date.Start, prop.DateOnly)
+	}
+	post.LinkedInDraft = propertyValue(page, "LinkedIn Draft")
+	post.TwitterThread = propertyValue(page, "Twitter Thread")
+	post.HNTitle = propertyValue(page, "HN Title")
+	post.RedditTitle = propertyValue(page, "Reddit Title")
+	post.Hashtags = propertyValues(page, "Hashtags")
+
+	content, err := c.GetPageContent(ctx, string(page.ID))
+	if err != nil {
+		post.Content = ""
+	} else {
+		post.Content = content
+	}
+
+	return post, nil
+}
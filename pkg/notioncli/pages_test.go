@@ -0,0 +1,167 @@
+package notioncli
+
+import (
+	"context"
+	"github.com/jontk/notion-cli/internal/notiontest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCreatePost(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Posts", notiontest.PostsSchema())
+	ctx := context.Background()
+
+	post, err := client.CreatePost(ctx, PostInput{
+		Title:         "Testing against a fake",
+		Content:       "First paragraph.\n\nSecond paragraph.",
+		Status:        "Draft",
+		Week:          42,
+		Pillar:        "Go Tools",
+		PublishDate:   "2026-10-26",
+		BlogURL:       "https://example.com/fake",
+		DistributedTo: []string{},
+		Hashtags:      []string{"golang", "testing"},
+	}, db)
+	if err != nil {
+		t.Fatalf("CreatePost: %v", err)
+	}
+
+	want := Post{
+		ID:            post.ID,
+		Title:         "Testing against a fake",
+		Content:       "First paragraph.\nSecond paragraph.",
+		Status:        "Draft",
+		Week:          42,
+		Pillar:        "Go Tools",
+		PublishDate:   "2026-10-26",
+		BlogURL:       "https://example.com/fake",
+		DistributedTo: []string{},
+		Hashtags:      []string{"golang", "testing"},
+		URL:           post.URL,
+		CreatedAt:     post.CreatedAt,
+		UpdatedAt:     post.UpdatedAt,
+	}
+	if !reflect.DeepEqual(*post, want) {
+		t.Errorf("CreatePost returned %+v, want %+v", *post, want)
+	}
+}
+
+func TestPostContentIsPlainText(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Posts", notiontest.PostsSchema())
+	ctx := context.Background()
+
+	post, err := client.CreatePost(ctx, PostInput{Title: "Plain", Content: "## Not a heading\n\n- not a list"}, db)
+	if err != nil {
+		t.Fatalf("CreatePost: %v", err)
+	}
+	if _, err := client.UpdatePost(ctx, post.ID, PostInput{Content: "> not a quote"}); err != nil {
+		t.Fatalf("UpdatePost: %v", err)
+	}
+
+	blocks, err := client.ListBlocks(ctx, post.ID)
+	if err != nil {
+		t.Fatalf("ListBlocks: %v", err)
+	}
+	var got []string
+	for _, b := range blocks {
+		got = append(got, b.Type+": "+b.Text)
+	}
+	want := []string{"paragraph: ## Not a heading", "paragraph: - not a list", "paragraph: > not a quote"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("post blocks = %q, want %q", got, want)
+	}
+}
+
+func TestUpdatePost(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Posts", notiontest.PostsSchema())
+	id := srv.AddPage(db, map[string]any{"Title": "Draft post", "Status": "Draft"})
+	srv.AddBlocks(id, notiontest.Paragraph("Intro."))
+	ctx := context.Background()
+
+	post, err := client.UpdatePost(ctx, id, PostInput{
+		Status:        "Published",
+		DistributedTo: []string{"LinkedIn"},
+		Content:       "Outro.",
+	})
+	if err != nil {
+		t.Fatalf("UpdatePost: %v", err)
+	}
+	if post.Title != "Draft post" || post.Status != "Published" {
+		t.Errorf("UpdatePost returned %+v", *post)
+	}
+	if !reflect.DeepEqual(post.DistributedTo, []string{"LinkedIn"}) {
+		t.Errorf("distributed to %q, want [LinkedIn]", post.DistributedTo)
+	}
+	if post.Content != "Intro.\nOutro." {
+		t.Errorf("content = %q, want the new paragraph appended", post.Content)
+	}
+
+	if _, err := client.UpdatePost(ctx, id, PostInput{Status: "Retired"}); err == nil || !strings.Contains(err.Error(), "Retired") {
+		t.Errorf("UpdatePost with an unknown status: error = %v", err)
+	}
+}
+
+func TestArchivePost(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Posts", notiontest.PostsSchema())
+	id := srv.AddPage(db, map[string]any{"Title": "Old post"})
+	ctx := context.Background()
+
+	if _, err := client.ArchivePost(ctx, id); err != nil {
+		t.Fatalf("ArchivePost: %v", err)
+	}
+	posts, err := client.QueryPosts(ctx, db, QueryOptions{})
+	if err != nil {
+		t.Fatalf("QueryPosts: %v", err)
+	}
+	if len(posts) != 0 {
+		t.Errorf("QueryPosts returned %d posts, want archived ones left out", len(posts))
+	}
+}
+
+func TestQueryPosts(t *testing.T) {
+	client, srv := newTestClient(t)
+	srv.MaxPageSize = 2
+	db := srv.AddDatabase("Posts", notiontest.PostsSchema())
+	for _, p := range []map[string]any{
+		{"Title": "One", "Status": "Idea", "Pillar": "Go Tools"},
+		{"Title": "Two", "Status": "Draft", "Pillar": "Infrastructure", "Distributed To": []string{"Twitter"}},
+		{"Title": "Three", "Status": "Draft", "Pillar": "Go Tools", "Distributed To": []string{"LinkedIn", "Twitter"}},
+		{"Title": "Four", "Status": "Published", "Pillar": "Go Tools"},
+		{"Title": "Five", "Status": "Draft", "Pillar": "Go Tools"},
+	} {
+		srv.AddPage(db, p)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		opts QueryOptions
+		want []string
+	}{
+		{"newest first", QueryOptions{}, []string{"Five", "Four", "Three", "Two", "One"}},
+		{"ascending", QueryOptions{Order: "ascending"}, []string{"One", "Two", "Three", "Four", "Five"}},
+		{"status and pillar", QueryOptions{Status: "Draft", Pillar: "Go Tools"}, []string{"Five", "Three"}},
+		{"distributed to", QueryOptions{DistributedTo: "Twitter"}, []string{"Three", "Two"}},
+		{"limit", QueryOptions{Limit: 3}, []string{"Five", "Four", "Three"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posts, err := client.QueryPosts(ctx, db, tt.opts)
+			if err != nil {
+				t.Fatalf("QueryPosts: %v", err)
+			}
+			var titles []string
+			for _, post := range posts {
+				titles = append(titles, post.Title)
+			}
+			if !reflect.DeepEqual(titles, tt.want) {
+				t.Errorf("QueryPosts = %q, want %q", titles, tt.want)
+			}
+		})
+	}
+}
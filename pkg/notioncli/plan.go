@@ -0,0 +1,255 @@
+package notioncli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/jomei/notionapi"
+)
+
+// PlanSchema compares the databases of a schema file with the workspace and
+// returns the changes that would make them match. ids gives the ID of each
+// database by its name in the file. A relation whose database is the name
+// of another entry points at that entry.
+func (c *Client) PlanSchema(ctx context.Context, file SchemaFile, ids map[string]string) ([]SchemaChange, error) {
+	names := make([]string, 0, len(file.Databases))
+	for name := range file.Databases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	specs := make(map[string]DatabaseSpec, len(names))
+	for _, name := range names {
+		id := ids[name]
+		if id == "" {
+			return nil, fmt.Errorf("database %q has no ID", name)
+		}
+		spec := file.Databases[name]
+		properties := make(map[string]PropertySpec, len(spec.Properties))
+		for prop, p := range spec.Properties {
+			if p.Type == "relation" {
+				if target, ok := ids[p.Database]; ok {
+					p.Database = target
+				}
+			}
+			if _, err := propertyConfig(prop, p, id); err != nil {
+				return nil, fmt.Errorf("database %q: %w", name, err)
+			}
+			properties[prop] = p
+		}
+		spec.Properties = properties
+		specs[name] = spec
+	}
+
+	var changes []SchemaChange
+	for _, name := range names {
+		schema, err := c.GetSchema(ctx, ids[name])
+		if err != nil {
+			return nil, fmt.Errorf("database %q: %w", name, err)
+		}
+		changes = append(changes, diffSchema(name, ids[name], specs[name], schema)...)
+	}
+	return changes, nil
+}
+
+// diffSchema lists the changes that turn a database's schema into its spec.
+// Select options are only compared when the spec lists some, since pages
+// add options as they are written.
+func diffSchema(name, id string, spec DatabaseSpec, schema *Schema) []SchemaChange {
+	var changes []SchemaChange
+	change := func(action, property string) *SchemaChange {
+		changes = append(changes, SchemaChange{Database: name, DatabaseID: id, Action: action, Property: property})
+		return &changes[len(changes)-1]
+	}
+
+	current := make(map[string]PropertyInfo, len(schema.Properties))
+	for prop, info := range schema.Properties {
+		current[prop] = info
+	}
+
+	// The title property can't be added or removed, only renamed
+	want, have := titleName(spec.Properties), ""
+	for prop, info := range current {
+		if info.Type == "title" {
+			have = prop
+		}
+	}
+	if want != "" && have != "" && want != have {
+		ch := change("rename_property", have)
+		ch.From, ch.To = have, want
+		current[want] = current[have]
+		delete(current, have)
+	}
+
+	props := make([]string, 0, len(spec.Properties))
+	for prop := range spec.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+
+	for _, prop := range props {
+		p := spec.Properties[prop]
+		info, ok := current[prop]
+		if !ok || info.Type != p.Type {
+			target := p
+			if p.Type == "status" {
+				target.Options = nil
+			}
+			ch := change("add_property", prop)
+			ch.To, ch.Spec = p.Type, &target
+			if ok {
+				ch.Action, ch.From, ch.Destructive = "change_type", info.Type, true
+			}
+			if p.Type == "status" && len(p.Options) > 0 {
+				change("manual", prop).Detail = statusStep(prop, p.Options)
+			}
+			continue
+		}
+		if from, to := configDrift(id, p, info); from != to {
+			target := p
+			ch := change("update_property", prop)
+			ch.From, ch.To, ch.Spec = from, to, &target
+			// Pages lose their links when a relation points elsewhere
+			ch.Destructive = p.Type == "relation"
+		}
+		if len(p.Options) == 0 {
+			continue
+		}
+
+		existing := optionNames(info)
+		missing, extra := difference(p.Options, existing), difference(existing, p.Options)
+		if p.Type == "status" {
+			if len(missing) > 0 {
+				change("manual", prop).Detail = statusStep(prop, missing)
+			}
+			if len(extra) > 0 {
+				change("manual", prop).Detail = fmt.Sprintf("remove the options %s from the status property %q in Notion", quoteList(extra), prop)
+			}
+			continue
+		}
+		for _, option := range missing {
+			change("add_option", prop).Option = option
+		}
+		for _, option := range extra {
+			ch := change("remove_option", prop)
+			ch.Option, ch.Destructive = option, true
+		}
+	}
+
+	var extraProps []string
+	for prop := range current {
+		if _, ok := spec.Properties[prop]; !ok {
+			extraProps = append(extraProps, prop)
+		}
+	}
+	sort.Strings(extraProps)
+	for _, prop := range extraProps {
+		ch := change("remove_property", prop)
+		ch.From, ch.Destructive = current[prop].Type, true
+	}
+	return changes
+}
+
+// configDrift returns the current and wanted configuration of a number
+// format, relation target or formula that differs from its spec. A number
+// without a format in the spec keeps whatever format it has.
+func configDrift(databaseID string, p PropertySpec, info PropertyInfo) (string, string) {
+	switch p.Type {
+	case "number":
+		if p.Format != "" {
+			return info.Format, p.Format
+		}
+	case "relation":
+		want := p.Database
+		if want == "" {
+			want = databaseID
+		}
+		if info.Relation != nil && normalizeID(info.Relation.DatabaseID) != normalizeID(want) {
+			return info.Relation.DatabaseID, want
+		}
+	case "formula":
+		return info.Expression, p.Expression
+	}
+	return "", ""
+}
+
+// titleName returns the name of the title property in a spec
+func titleName(properties map[string]PropertySpec) string {
+	for name, p := range properties {
+		if p.Type == "title" {
+			return name
+		}
+	}
+	return ""
+}
+
+// optionNames returns the names of a property's options in order
+func optionNames(info PropertyInfo) []string {
+	var names []string
+	for _, opt := range info.Options {
+		names = append(names, opt.Name)
+	}
+	return names
+}
+
+// difference returns the values of a that aren't in b, in order
+func difference(a, b []string) []string {
+	var out []string
+	for _, v := range a {
+		if !contains(b, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// ApplySchema makes the changes of a plan in order. Changes that lose
+// values on existing pages are refused unless allowDestructive is set, and
+// manual changes are returned as steps to take in Notion.
+func (c *Client) ApplySchema(ctx context.Context, changes []SchemaChange, allowDestructive bool) (*SchemaApplied, error) {
+	if !allowDestructive {
+		var destructive []SchemaChange
+		for _, ch := range changes {
+			if ch.Destructive {
+				destructive = append(destructive, ch)
+			}
+		}
+		if len(destructive) > 0 {
+			return nil, &DestructiveError{Changes: destructive}
+		}
+	}
+
+	result := &SchemaApplied{Applied: []SchemaChange{}}
+	for _, ch := range changes {
+		var err error
+		switch ch.Action {
+		case "manual":
+			result.ManualSteps = append(result.ManualSteps, fmt.Sprintf("%s: %s", ch.Database, ch.Detail))
+			continue
+		case "add_property":
+			_, err = c.AddProperty(ctx, ch.DatabaseID, ch.Property, *ch.Spec)
+		case "change_type", "update_property":
+			var cfg propertyUpdate
+			if cfg, err = propertyConfig(ch.Property, *ch.Spec, ch.DatabaseID); err == nil {
+				_, err = c.updateDatabase(ctx, ch.DatabaseID, notionapi.PropertyConfigs{ch.Property: cfg})
+			}
+		case "rename_property":
+			_, err = c.RenameProperty(ctx, ch.DatabaseID, ch.From, ch.To)
+		case "remove_property":
+			_, err = c.updateDatabase(ctx, ch.DatabaseID, notionapi.PropertyConfigs{ch.Property: nil})
+		case "add_option":
+			_, err = c.AddOption(ctx, ch.DatabaseID, ch.Property, ch.Option, "")
+		case "remove_option":
+			_, err = c.RemoveOption(ctx, ch.DatabaseID, ch.Property, ch.Option)
+		default:
+			err = fmt.Errorf("unknown action %q", ch.Action)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("applied %d of %d changes; %s %s of %q failed: %w",
+				len(result.Applied), len(changes), ch.Action, ch.Property, ch.Database, err)
+		}
+		result.Applied = append(result.Applied, ch)
+	}
+	return result, nil
+}
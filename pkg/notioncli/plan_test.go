@@ -0,0 +1,196 @@
+package notioncli
+
+import (
+	"context"
+	"errors"
+	"github.com/jontk/notion-cli/internal/notiontest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// tasksSpec returns a spec matching notiontest.TasksSchema
+func tasksSpec() DatabaseSpec {
+	return DatabaseSpec{Properties: map[string]PropertySpec{
+		"Title":      {Type: "title"},
+		"Status":     {Type: "status", Options: []string{"Todo", "In Progress", "Blocked", "Done"}},
+		"Priority":   {Type: "select", Options: []string{"High", "Medium", "Low"}},
+		"Due Date":   {Type: "date"},
+		"Category":   {Type: "select"},
+		"Tags":       {Type: "multi_select"},
+		"Notes":      {Type: "rich_text"},
+		"Repeat":     {Type: "rich_text"},
+		"Series":     {Type: "rich_text"},
+		"Parent":     {Type: "relation"},
+		"Blocked By": {Type: "relation"},
+		"Assignee":   {Type: "people"},
+	}}
+}
+
+// summary reduces changes to "action property detail" lines
+func summary(changes []SchemaChange) []string {
+	var out []string
+	for _, ch := range changes {
+		line := ch.Action + " " + ch.Property
+		for _, detail := range []string{ch.Option, ch.From, ch.To} {
+			if detail != "" {
+				line += " " + detail
+			}
+		}
+		if ch.Destructive {
+			line += " !"
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+func TestPlanSchemaNoChanges(t *testing.T) {
+	client, srv := newTestClient(t)
+	tasks := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+
+	changes, err := client.PlanSchema(context.Background(), SchemaFile{
+		Databases: map[string]DatabaseSpec{"tasks": tasksSpec()},
+	}, map[string]string{"tasks": tasks})
+	if err != nil {
+		t.Fatalf("PlanSchema: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("PlanSchema found changes in a matching database: %q", summary(changes))
+	}
+}
+
+func TestPlanAndApplySchema(t *testing.T) {
+	client, srv := newTestClient(t)
+	tasks := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	projects := srv.AddDatabase("Projects", notiontest.Schema{"Name": notiontest.Title()})
+	srv.AddPage(tasks, map[string]any{"Title": "Old", "Priority": "Medium", "Notes": "kept?"})
+	ctx := context.Background()
+
+	spec := tasksSpec()
+	delete(spec.Properties, "Title")
+	delete(spec.Properties, "Series")
+	spec.Properties["Name"] = PropertySpec{Type: "title"}
+	spec.Properties["Status"] = PropertySpec{Type: "status", Options: []string{"Todo", "In Progress", "Blocked", "Done", "Waiting"}}
+	spec.Properties["Priority"] = PropertySpec{Type: "select", Options: []string{"High", "Low", "Urgent"}}
+	spec.Properties["Notes"] = PropertySpec{Type: "url"}
+	spec.Properties["Estimate"] = PropertySpec{Type: "number", Format: "percent"}
+	spec.Properties["Project"] = PropertySpec{Type: "relation", Database: "projects"}
+	file := SchemaFile{Databases: map[string]DatabaseSpec{
+		"tasks":    spec,
+		"projects": {Properties: map[string]PropertySpec{"Name": {Type: "title"}}},
+	}}
+	ids := map[string]string{"tasks": tasks, "projects": projects}
+
+	changes, err := client.PlanSchema(ctx, file, ids)
+	if err != nil {
+		t.Fatalf("PlanSchema: %v", err)
+	}
+	want := []string{
+		"rename_property Title Title Name",
+		"add_property Estimate number",
+		"change_type Notes rich_text url !",
+		"add_option Priority Urgent",
+		"remove_option Priority Medium !",
+		"add_property Project relation",
+		"manual Status",
+		"remove_property Series rich_text !",
+	}
+	if got := summary(changes); !reflect.DeepEqual(got, want) {
+		t.Fatalf("PlanSchema =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if changes[5].Spec.Database != projects {
+		t.Errorf("Project relation points at %q, want the projects database", changes[5].Spec.Database)
+	}
+
+	requests := len(srv.Requests())
+	var destructive *DestructiveError
+	if _, err := client.ApplySchema(ctx, changes, false); !errors.As(err, &destructive) || len(destructive.Changes) != 3 {
+		t.Errorf("ApplySchema without allowing destructive changes: %v", err)
+	}
+	if n := len(srv.Requests()) - requests; n != 0 {
+		t.Errorf("refused apply sent %d requests", n)
+	}
+
+	applied, err := client.ApplySchema(ctx, changes, true)
+	if err != nil {
+		t.Fatalf("ApplySchema: %v", err)
+	}
+	if len(applied.Applied) != 7 || len(applied.ManualSteps) != 1 || !strings.Contains(applied.ManualSteps[0], `"Waiting"`) {
+		t.Errorf("ApplySchema = %+v", applied)
+	}
+
+	changes, err = client.PlanSchema(ctx, file, ids)
+	if err != nil {
+		t.Fatalf("PlanSchema after apply: %v", err)
+	}
+	if got := summary(changes); !reflect.DeepEqual(got, []string{"manual Status"}) {
+		t.Errorf("PlanSchema after apply = %q, want only the status step", got)
+	}
+}
+
+func TestPlanSchemaInvalid(t *testing.T) {
+	client, srv := newTestClient(t)
+	tasks := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	ctx := context.Background()
+
+	spec := tasksSpec()
+	spec.Properties["Rating"] = PropertySpec{Type: "stars"}
+	_, err := client.PlanSchema(ctx, SchemaFile{
+		Databases: map[string]DatabaseSpec{"tasks": spec},
+	}, map[string]string{"tasks": tasks})
+	if err == nil || !strings.Contains(err.Error(), `unknown type "stars"`) {
+		t.Errorf("PlanSchema with an unknown type: %v", err)
+	}
+
+	_, err = client.PlanSchema(ctx, SchemaFile{
+		Databases: map[string]DatabaseSpec{"tasks": tasksSpec()},
+	}, map[string]string{})
+	if err == nil || !strings.Contains(err.Error(), "no ID") {
+		t.Errorf("PlanSchema without an ID: %v", err)
+	}
+}
+
+func TestPlanSchemaConfigs(t *testing.T) {
+	client, srv := newTestClient(t)
+	schema := notiontest.TasksSchema()
+	schema["Score"] = notiontest.Number()
+	schema["Label"] = notiontest.Formula(`prop("Title")`)
+	tasks := srv.AddDatabase("Tasks", schema)
+	projects := srv.AddDatabase("Projects", notiontest.Schema{"Name": notiontest.Title()})
+	ctx := context.Background()
+
+	spec := tasksSpec()
+	spec.Properties["Score"] = PropertySpec{Type: "number", Format: "percent"}
+	spec.Properties["Label"] = PropertySpec{Type: "formula", Expression: `upper(prop("Title"))`}
+	spec.Properties["Parent"] = PropertySpec{Type: "relation", Database: "projects"}
+	file := SchemaFile{Databases: map[string]DatabaseSpec{
+		"tasks":    spec,
+		"projects": {Properties: map[string]PropertySpec{"Name": {Type: "title"}}},
+	}}
+	ids := map[string]string{"tasks": tasks, "projects": projects}
+
+	changes, err := client.PlanSchema(ctx, file, ids)
+	if err != nil {
+		t.Fatalf("PlanSchema: %v", err)
+	}
+	want := []string{
+		`update_property Label prop("Title") upper(prop("Title"))`,
+		"update_property Parent " + tasks + " " + projects + " !",
+		"update_property Score number percent",
+	}
+	if got := summary(changes); !reflect.DeepEqual(got, want) {
+		t.Fatalf("PlanSchema =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if _, err := client.ApplySchema(ctx, changes, true); err != nil {
+		t.Fatalf("ApplySchema: %v", err)
+	}
+	changes, err = client.PlanSchema(ctx, file, ids)
+	if err != nil {
+		t.Fatalf("PlanSchema after apply: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("PlanSchema after apply = %q", summary(changes))
+	}
+}
@@ -0,0 +1,363 @@
+package notioncli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jomei/notionapi"
+)
+
+// propertyKind is the type of a database column, as far as writing values
+// to it is concerned
+type propertyKind int
+
+const (
+	kindUnknown propertyKind = iota
+	kindTitle
+	kindText
+	kindNumber
+	kindSelect
+	kindMultiSelect
+	kindStatus
+	kindDate
+	kindPeople
+	kindCheckbox
+	kindURL
+	kindEmail
+	kindPhone
+	kindRelation
+	kindComputed
+)
+
+// kindOf maps a property config onto its kind
+func kindOf(cfg notionapi.PropertyConfig) propertyKind {
+	switch cfg.(type) {
+	case *notionapi.TitlePropertyConfig:
+		return kindTitle
+	case *notionapi.RichTextPropertyConfig:
+		return kindText
+	case *notionapi.NumberPropertyConfig:
+		return kindNumber
+	case *notionapi.SelectPropertyConfig:
+		return kindSelect
+	case *notionapi.MultiSelectPropertyConfig:
+		return kindMultiSelect
+	case *notionapi.StatusPropertyConfig:
+		return kindStatus
+	case *notionapi.DatePropertyConfig:
+		return kindDate
+	case *notionapi.PeoplePropertyConfig:
+		return kindPeople
+	case *notionapi.CheckboxPropertyConfig:
+		return kindCheckbox
+	case *notionapi.URLPropertyConfig:
+		return kindURL
+	case *notionapi.EmailPropertyConfig:
+		return kindEmail
+	case *notionapi.PhoneNumberPropertyConfig:
+		return kindPhone
+	case *notionapi.RelationPropertyConfig:
+		return kindRelation
+	case *notionapi.FormulaPropertyConfig, *notionapi.RollupPropertyConfig:
+		return kindComputed
+	}
+	return kindUnknown
+}
+
+// databaseSchema returns the property configs of a database, fetched once
+// per client
+func (c *Client) databaseSchema(ctx context.Context, databaseID string) (notionapi.PropertyConfigs, error) {
+	key := normalizeID(databaseID)
+	c.mu.Lock()
+	schema, ok := c.schemas[key]
+	c.mu.Unlock()
+	if ok {
+		return schema, nil
+	}
+
+	db, err := c.api.Database.Get(ctx, notionapi.DatabaseID(databaseID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	c.mu.Lock()
+	c.schemas[key] = db.Properties
+	c.mu.Unlock()
+	return db.Properties, nil
+}
+
+// propertyEncoder builds page properties, writing each value as the type
+// its column has in the database. Columns missing from the schema get the
+// type of the documented schema, so Notion reports them by name.
+type propertyEncoder struct {
+	schema notionapi.PropertyConfigs
+	props  notionapi.Properties
+	errs   []string
+}
+
+// encoder returns a property encoder for pages of a database
+func (c *Client) encoder(ctx context.Context, databaseID string) (*propertyEncoder, error) {
+	schema, err := c.databaseSchema(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	return &propertyEncoder{schema: schema, props: notionapi.Properties{}}, nil
+}
+
+// pageEncoder returns a property encoder for an existing page, using the
+// schema of the database it belongs to
+func (c *Client) pageEncoder(ctx context.Context, pageID string) (*propertyEncoder, error) {
+	page, err := c.getPage(ctx, pageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get page: %w", err)
+	}
+	if page.Parent.DatabaseID == "" {
+		return &propertyEncoder{props: notionapi.Properties{}}, nil
+	}
+	return c.encoder(ctx, string(page.Parent.DatabaseID))
+}
+
+// kind returns the type of a column, or fallback when the schema doesn't
+// have it
+func (e *propertyEncoder) kind(name string, fallback propertyKind) propertyKind {
+	return schemaKind(e.schema, name, fallback)
+}
+
+// typeName names the type of a column for error messages
+func (e *propertyEncoder) typeName(name string) string {
+	return schemaTypeName(e.schema, name)
+}
+
+// schemaKind returns the type of a column in a schema, or fallback when the
+// schema doesn't have it
+func schemaKind(schema notionapi.PropertyConfigs, name string, fallback propertyKind) propertyKind {
+	if cfg, ok := schema[name]; ok {
+		if k := kindOf(cfg); k != kindUnknown {
+			return k
+		}
+	}
+	return fallback
+}
+
+// schemaTypeName names the type of a column for error messages
+func schemaTypeName(schema notionapi.PropertyConfigs, name string) string {
+	if cfg, ok := schema[name]; ok && cfg != nil {
+		return string(cfg.GetType())
+	}
+	return "unknown"
+}
+
+func (e *propertyEncoder) fail(format string, args ...any) {
+	e.errs = append(e.errs, fmt.Sprintf(format, args...))
+}
+
+// title sets the page title, whatever the title column is called
+func (e *propertyEncoder) title(value string) {
+	name := "Title"
+	for n, cfg := range e.schema {
+		if kindOf(cfg) == kindTitle {
+			name = n
+			break
+		}
+	}
+	e.props[name] = notionapi.TitleProperty{Title: richText(value)}
+}
+
+// text sets a free-text value, rich text unless the column says otherwise
+func (e *propertyEncoder) text(name, value string) {
+	e.values(name, []string{value}, kindText)
+}
+
+// choice sets a single option, as fallback when the column is unknown
+func (e *propertyEncoder) choice(name, value string, fallback propertyKind) {
+	e.values(name, []string{value}, fallback)
+}
+
+// choices sets a list of options, multi-select unless the column says
+// otherwise
+func (e *propertyEncoder) choices(name string, values []string) {
+	e.values(name, values, kindMultiSelect)
+}
+
+// values writes string values in the form the column's type takes
+func (e *propertyEncoder) values(name string, values []string, fallback propertyKind) {
+	kind := e.kind(name, fallback)
+	single := func() (string, bool) {
+		if len(values) > 1 {
+			e.fail("%s holds a single value, got %d", name, len(values))
+			return "", false
+		}
+		if len(values) == 0 {
+			return "", true
+		}
+		return values[0], true
+	}
+
+	switch kind {
+	case kindTitle:
+		e.props[name] = notionapi.TitleProperty{Title: richText(strings.Join(values, ", "))}
+	case kindText:
+		e.props[name] = notionapi.RichTextProperty{RichText: richText(strings.Join(values, ", "))}
+	case kindMultiSelect:
+		e.props[name] = multiSelect(values)
+	case kindSelect:
+		if v, ok := single(); ok {
+			e.props[name] = notionapi.SelectProperty{Select: notionapi.Option{Name: v}}
+		}
+	case kindStatus:
+		if v, ok := single(); ok {
+			e.props[name] = notionapi.StatusProperty{Status: notionapi.Status{Name: v}}
+		}
+	case kindURL:
+		if v, ok := single(); ok {
+			e.props[name] = notionapi.URLProperty{URL: v}
+		}
+	case kindEmail:
+		if v, ok := single(); ok {
+			e.props[name] = notionapi.EmailProperty{Email: v}
+		}
+	case kindPhone:
+		if v, ok := single(); ok {
+			e.props[name] = notionapi.PhoneNumberProperty{PhoneNumber: v}
+		}
+	case kindNumber:
+		if v, ok := single(); ok {
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				e.fail("%s is a number property, got %q", name, v)
+				return
+			}
+			e.props[name] = notionapi.NumberProperty{Number: n}
+		}
+	case kindCheckbox:
+		if v, ok := single(); ok {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				e.fail("%s is a checkbox property, got %q", name, v)
+				return
+			}
+			e.props[name] = notionapi.CheckboxProperty{Checkbox: b}
+		}
+	case kindComputed:
+		e.fail("%s is computed by Notion and can't be set", name)
+	default:
+		e.fail("%s is a %s property and can't hold text", name, e.typeName(name))
+	}
+}
+
+// number sets a numeric value
+func (e *propertyEncoder) number(name string, n float64) {
+	if e.kind(name, kindNumber) == kindNumber {
+		e.props[name] = notionapi.NumberProperty{Number: n}
+		return
+	}
+	e.values(name, []string{strconv.FormatFloat(n, 'f', -1, 64)}, kindNumber)
+}
+
+// url sets a link, as a URL property unless the column says otherwise
+func (e *propertyEncoder) url(name, value string) {
+	e.values(name, []string{value}, kindURL)
+}
+
+// date sets a date value. Text columns get the start date as text.
+func (e *propertyEncoder) date(name string, value dateValue) {
+	switch e.kind(name, kindDate) {
+	case kindDate:
+		e.props[name] = value
+	case kindText:
+		e.props[name] = notionapi.RichTextProperty{RichText: richText(value.format(value.Start))}
+	default:
+		e.fail("%s is a %s property and can't hold a date", name, e.typeName(name))
+	}
+}
+
+// relation sets the pages a relation points at
+func (e *propertyEncoder) relation(name string, ids []string) {
+	switch e.kind(name, kindRelation) {
+	case kindRelation:
+		e.props[name] = relationProperty(ids)
+	case kindText:
+		e.props[name] = notionapi.RichTextProperty{RichText: richText(strings.Join(ids, ", "))}
+	default:
+		e.fail("%s is a %s property and can't link pages", name, e.typeName(name))
+	}
+}
+
+// people sets the users of a people property
+func (e *propertyEncoder) people(name string, prop notionapi.PeopleProperty) {
+	if e.kind(name, kindPeople) != kindPeople {
+		e.fail("%s is a %s property and can't hold people", name, e.typeName(name))
+		return
+	}
+	e.props[name] = prop
+}
+
+// result returns the encoded properties, or the type mismatches found
+func (e *propertyEncoder) result() (notionapi.Properties, error) {
+	if len(e.errs) > 0 {
+		return nil, &PropertyError{Problems: e.errs}
+	}
+	return e.props, nil
+}
+
+// propertyValues reads the values of a property as strings, whatever type
+// the column has: the options of select, status and multi-select columns, or
+// the text of the others
+func propertyValues(page *notionapi.Page, name string) []string {
+	switch p := page.Properties[name].(type) {
+	case *notionapi.SelectProperty:
+		if p.Select.Name != "" {
+			return []string{p.Select.Name}
+		}
+	case *notionapi.StatusProperty:
+		if p.Status.Name != "" {
+			return []string{p.Status.Name}
+		}
+	case *notionapi.MultiSelectProperty:
+		values := make([]string, 0, len(p.MultiSelect))
+		for _, opt := range p.MultiSelect {
+			values = append(values, opt.Name)
+		}
+		return values
+	case *notionapi.RichTextProperty:
+		if s := extractRichText(p.RichText); s != "" {
+			return []string{s}
+		}
+	case *notionapi.TitleProperty:
+		if s := extractRichText(p.Title); s != "" {
+			return []string{s}
+		}
+	case *notionapi.URLProperty:
+		if p.URL != "" {
+			return []string{p.URL}
+		}
+	case *notionapi.EmailProperty:
+		if p.Email != "" {
+			return []string{p.Email}
+		}
+	case *notionapi.PhoneNumberProperty:
+		if p.PhoneNumber != "" {
+			return []string{p.PhoneNumber}
+		}
+	case *notionapi.NumberProperty:
+		return []string{strconv.FormatFloat(p.Number, 'f', -1, 64)}
+	}
+	return nil
+}
+
+// propertyValue reads a property as a single string; lists are joined
+func propertyValue(page *notionapi.Page, name string) string {
+	return strings.Join(propertyValues(page, name), ", ")
+}
+
+// pageTitle reads the title of a page, whatever the title column is called
+func pageTitle(page *notionapi.Page) string {
+	for _, prop := range page.Properties {
+		if p, ok := prop.(*notionapi.TitleProperty); ok {
+			return extractRichText(p.Title)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,472 @@
+package notioncli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jomei/notionapi"
+	"github.com/jontk/notion-cli/internal/recur"
+)
+
+// setRecurrence validates a recurrence rule and adds it, along with the
+// series link, to the properties being written
+func (c *Client) setRecurrence(enc *propertyEncoder, repeat, seriesID string) error {
+	if repeat != "" {
+		rule, err := recur.Parse(repeat)
+		if err != nil {
+			return fmt.Errorf("invalid repeat rule: %w", err)
+		}
+		enc.text(c.settings.RepeatProperty, rule.String())
+	}
+	if seriesID != "" {
+		enc.text(c.settings.SeriesProperty, seriesID)
+	}
+	return nil
+}
+
+// recurrence reads the recurrence rule and series link of a page
+func (c *Client) recurrence(page *notionapi.Page) (string, string) {
+	return propertyValue(page, c.settings.RepeatProperty), propertyValue(page, c.settings.SeriesProperty)
+}
+
+// RollTask makes sure the next instance of a recurring task exists. It
+// returns that instance and whether it was created by this call; rerunning
+// it finds the earlier instance instead of adding a duplicate. A nil task
+// means the series has ended.
+func (c *Client) RollTask(ctx context.Context, taskID string) (*Task, bool, error) {
+	page, err := c.getPage(ctx, taskID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get task: %w", err)
+	}
+	task, err := c.pageToTask(ctx, page)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if task.Repeat == "" {
+		return nil, false, fmt.Errorf("task %s has no repeat rule", taskID)
+	}
+	rule, err := recur.Parse(task.Repeat)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid repeat rule on task %s: %w", taskID, err)
+	}
+	if task.DueDate == "" {
+		return nil, false, fmt.Errorf("recurring task %s needs a due date", taskID)
+	}
+	due, err := c.parseDate(task.DueDate)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Anchor on the first task of the series so COUNT and UNTIL hold across
+	// instances
+	seriesID := task.SeriesID
+	dtstart := due.Time
+	if seriesID == "" {
+		seriesID = task.ID
+	} else if first, err := c.GetTask(ctx, seriesID); err == nil && first.DueDate != "" {
+		if r, err := c.parseDate(first.DueDate); err == nil {
+			dtstart = r.Time
+		}
+	}
+
+	next, ok := rule.Next(dtstart, due.Time)
+	if !ok {
+		return nil, false, nil
+	}
+
+	databaseID := string(page.Parent.DatabaseID)
+	existing, err := c.seriesPages(ctx, databaseID, seriesID)
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range existing {
+		other, err := c.pageToTask(ctx, &existing[i])
+		if err != nil || other.DueDate == "" {
+			continue
+		}
+		if r, err := c.parseDate(other.DueDate); err == nil && c.sameOccurrence(r.Time, next, due.HasTime) {
+			return other, false, nil
+		}
+	}
+
+	created, err := c.CreateTask(ctx, TaskInput{
+		Title:    task.Title,
+		Status:   c.settings.DefaultTaskStatus,
+		Priority: task.Priority,
+		Category: task.Category,
+		Tags:     task.Tags,
+		Notes:    task.Notes,
+		DueDate:  c.dateInput(next, due.HasTime),
+		Repeat:   task.Repeat,
+		SeriesID: seriesID,
+		ParentID: task.ParentID,
+	}, databaseID)
+	if err != nil {
+		return nil, false, err
+	}
+	return created, true, nil
+}
+
+// RollCompletedTasks creates the missing next instances for every completed
+// recurring task in a database and returns the tasks it created
+func (c *Client) RollCompletedTasks(ctx context.Context, databaseID string) ([]Task, error) {
+	groups, err := c.taskStatuses(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	filters, err := c.queryFilters(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	filters.anyOf("Status", groups.Done, kindStatus)
+	filters.add(notionapi.PropertyFilter{
+		Property: c.settings.RepeatProperty,
+		RichText: &notionapi.TextFilterCondition{IsNotEmpty: true},
+	})
+	filter, err := filters.result()
+	if err != nil {
+		return nil, err
+	}
+	pages, err := c.queryAllPages(ctx, databaseID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recurring tasks: %w", err)
+	}
+
+	var created []Task
+	for _, page := range pages {
+		next, isNew, err := c.RollTask(ctx, string(page.ID))
+		if err != nil {
+			return created, err
+		}
+		if isNew {
+			created = append(created, *next)
+		}
+	}
+	return created, nil
+}
+
+// ExpandEvent creates the occurrences of a recurring event from today until
+// the given date that don't exist yet, and returns the ones it created. The
+// event itself stands for its own start date.
+func (c *Client) ExpandEvent(ctx context.Context, eventID, until string) ([]Event, error) {
+	end, err := c.expandUntil(until)
+	if err != nil {
+		return nil, err
+	}
+	return c.expandEvent(ctx, eventID, end)
+}
+
+func (c *Client) expandEvent(ctx context.Context, eventID string, until time.Time) ([]Event, error) {
+	page, err := c.getPage(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	event, err := c.pageToEvent(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Repeat == "" {
+		return nil, fmt.Errorf("event %s has no repeat rule", eventID)
+	}
+	if event.SeriesID != "" {
+		return nil, fmt.Errorf("event %s is an occurrence of %s; expand that event instead", eventID, event.SeriesID)
+	}
+	rule, err := recur.Parse(event.Repeat)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repeat rule on event %s: %w", eventID, err)
+	}
+	start, _, ok := c.eventSpan(page)
+	if !ok {
+		return nil, fmt.Errorf("recurring event %s needs a date", eventID)
+	}
+
+	databaseID := string(page.Parent.DatabaseID)
+	have, err := c.seriesDays(ctx, databaseID, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := dayStart(time.Now().In(c.location))
+	if from.Before(start) {
+		from = start
+	}
+
+	var created []Event
+	for _, occ := range c.occurrenceStarts(rule, start, from, until, have) {
+		input := EventInput{
+			Title:     event.Title,
+			Date:      c.dateInput(occ, !event.AllDay),
+			AllDay:    event.AllDay,
+			Type:      event.Type,
+			Location:  event.Location,
+			Attendees: event.Attendees,
+			Status:    event.Status,
+			Notes:     event.Notes,
+			SeriesID:  event.ID,
+		}
+		if event.End != "" {
+			input.Duration = fmt.Sprintf("%dm", event.DurationMinutes)
+		}
+
+		occurrence, err := c.CreateEvent(ctx, input, databaseID)
+		if err != nil {
+			return created, err
+		}
+		created = append(created, *occurrence)
+	}
+	return created, nil
+}
+
+// ExpandEvents expands every recurring event in a database up to the given
+// date and returns the occurrences it created
+func (c *Client) ExpandEvents(ctx context.Context, databaseID, until string) ([]Event, error) {
+	end, err := c.expandUntil(until)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := c.seriesHeads(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	var created []Event
+	for _, page := range pages {
+		events, err := c.expandEvent(ctx, string(page.ID), end)
+		created = append(created, events...)
+		if err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
+
+// occurrenceStarts returns when a recurring event starting at start occurs
+// between from and until, leaving out the event itself and the days in have
+func (c *Client) occurrenceStarts(rule *recur.Rule, start, from, until time.Time, have map[string]bool) []time.Time {
+	var starts []time.Time
+	for _, occ := range rule.Between(start, from, until) {
+		if !occ.Equal(start) && !have[occ.In(c.location).Format("2006-01-02")] {
+			starts = append(starts, occ)
+		}
+	}
+	return starts
+}
+
+// pendingOccurrences returns the occurrences of recurring events between
+// from and until that have no page yet. Series with an invalid rule are
+// left out.
+func (c *Client) pendingOccurrences(ctx context.Context, databaseID string, from, until time.Time) ([]Event, error) {
+	pages, err := c.seriesHeads(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Event
+	for i := range pages {
+		event, err := c.pageToEvent(ctx, &pages[i])
+		if err != nil {
+			return nil, err
+		}
+		rule, err := recur.Parse(event.Repeat)
+		if err != nil {
+			continue
+		}
+		start, end, ok := c.eventSpan(&pages[i])
+		if !ok || !start.Before(until) {
+			continue
+		}
+		length := end.Sub(start)
+		if len(c.occurrenceStarts(rule, start, from.Add(-length), until, nil)) == 0 {
+			continue
+		}
+
+		have, err := c.seriesDays(ctx, databaseID, event.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, occ := range c.occurrenceStarts(rule, start, from.Add(-length), until, have) {
+			o := Event{
+				Title:           event.Title,
+				AllDay:          event.AllDay,
+				DurationMinutes: event.DurationMinutes,
+				Type:            event.Type,
+				Location:        event.Location,
+				Attendees:       event.Attendees,
+				Status:          event.Status,
+				Notes:           event.Notes,
+				SeriesID:        event.ID,
+			}
+			if event.AllDay {
+				o.Start = occ.Format(dateLayout)
+				if event.End != "" {
+					days := int(length.Round(24*time.Hour) / (24 * time.Hour))
+					o.End = occ.AddDate(0, 0, days-1).Format(dateLayout)
+				}
+			} else {
+				o.Start = c.formatTime(occ)
+				if event.End != "" {
+					o.End = c.formatTime(occ.Add(length))
+				}
+			}
+			o.Date = o.Start
+			pending = append(pending, o)
+		}
+	}
+	return pending, nil
+}
+
+// seriesHeads returns the recurring events of a database, leaving out the
+// occurrences created from them
+func (c *Client) seriesHeads(ctx context.Context, databaseID string) ([]notionapi.Page, error) {
+	compound := notionapi.AndCompoundFilter{
+		notionapi.PropertyFilter{
+			Property: c.settings.RepeatProperty,
+			RichText: &notionapi.TextFilterCondition{IsNotEmpty: true},
+		},
+		notionapi.PropertyFilter{
+			Property: c.settings.SeriesProperty,
+			RichText: &notionapi.TextFilterCondition{IsEmpty: true},
+		},
+	}
+	pages, err := c.queryAllPages(ctx, databaseID, &compound)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recurring events: %w", err)
+	}
+	return pages, nil
+}
+
+// SkipOccurrence adds an exception for one day to a recurring event and
+// archives the occurrence already created for that day, if any
+func (c *Client) SkipOccurrence(ctx context.Context, eventID, date string) (*Event, error) {
+	page, err := c.getPage(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	event, err := c.pageToEvent(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+	if event.Repeat == "" {
+		return nil, fmt.Errorf("event %s has no repeat rule", eventID)
+	}
+	if event.SeriesID != "" {
+		return nil, fmt.Errorf("event %s is an occurrence of %s; skip it on that event instead", eventID, event.SeriesID)
+	}
+
+	rule, err := recur.Parse(event.Repeat)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repeat rule on event %s: %w", eventID, err)
+	}
+	day, err := c.parseDate(date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date: %w", err)
+	}
+	skipDay := day.Time.Format("2006-01-02")
+
+	existing, err := c.seriesPages(ctx, string(page.Parent.DatabaseID), event.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range existing {
+		if s, _, ok := c.eventSpan(&existing[i]); ok && s.Format("2006-01-02") == skipDay {
+			_, err := c.updatePage(ctx, string(existing[i].ID), &notionapi.PageUpdateRequest{
+				Archived:   true,
+				Properties: notionapi.Properties{},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to archive skipped occurrence: %w", err)
+			}
+		}
+	}
+
+	return c.UpdateEvent(ctx, eventID, EventInput{Repeat: rule.Skip(day.Time).String()})
+}
+
+// expandUntil resolves the end of an expansion window; a date-only bound
+// includes that whole day
+func (c *Client) expandUntil(until string) (time.Time, error) {
+	r, err := c.parseDate(until)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid until date: %w", err)
+	}
+	if !r.HasTime {
+		return r.Time.AddDate(0, 0, 1), nil
+	}
+	return r.Time, nil
+}
+
+// seriesDays returns the days on which occurrences of a series have pages
+func (c *Client) seriesDays(ctx context.Context, databaseID, seriesID string) (map[string]bool, error) {
+	existing, err := c.seriesPages(ctx, databaseID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	have := make(map[string]bool, len(existing))
+	for i := range existing {
+		if s, _, ok := c.eventSpan(&existing[i]); ok {
+			have[s.Format("2006-01-02")] = true
+		}
+	}
+	return have, nil
+}
+
+// seriesPages returns the pages generated from the given series
+func (c *Client) seriesPages(ctx context.Context, databaseID, seriesID string) ([]notionapi.Page, error) {
+	filter := notionapi.PropertyFilter{
+		Property: c.settings.SeriesProperty,
+		RichText: &notionapi.TextFilterCondition{Equals: seriesID},
+	}
+	pages, err := c.queryAllPages(ctx, databaseID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query series %s: %w", seriesID, err)
+	}
+	return pages, nil
+}
+
+// queryAllPages runs a database query and collects every page of results
+func (c *Client) queryAllPages(ctx context.Context, databaseID string, filter notionapi.Filter) ([]notionapi.Page, error) {
+	var pages []notionapi.Page
+	var cursor notionapi.Cursor
+
+	for {
+		req := &notionapi.DatabaseQueryRequest{
+			Filter:      filter,
+			PageSize:    100,
+			StartCursor: cursor,
+		}
+
+		resp, err := c.queryDatabase(ctx, databaseID, req)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, resp.Results...)
+
+		if !resp.HasMore {
+			break
+		}
+		cursor = resp.NextCursor
+	}
+
+	return pages, nil
+}
+
+// sameOccurrence compares two occurrence times, by calendar day when the
+// series has no time of day
+func (c *Client) sameOccurrence(a, b time.Time, withTime bool) bool {
+	if withTime {
+		return a.Equal(b)
+	}
+	return a.In(c.location).Format("2006-01-02") == b.In(c.location).Format("2006-01-02")
+}
+
+// dateInput formats a time as a date expression the client accepts back
+func (c *Client) dateInput(t time.Time, withTime bool) string {
+	if withTime {
+		return t.In(c.location).Format(time.RFC3339)
+	}
+	return t.In(c.location).Format("2006-01-02")
+}
@@ -0,0 +1,168 @@
+package notioncli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jontk/notion-cli/internal/notiontest"
+)
+
+// createdPages counts the pages created on the fake server
+func createdPages(srv *notiontest.Server) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if r.Method == "POST" && r.Path == "pages" {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRollTask(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		due    string
+		repeat string
+		want   []string
+	}{
+		{"date", "2030-06-03", "FREQ=WEEKLY;COUNT=3", []string{"2030-06-10", "2030-06-17"}},
+		{"date and time", "2030-06-03T09:00:00Z", "FREQ=DAILY;BYDAY=MO,FR;COUNT=3", []string{"2030-06-07T09:00:00Z", "2030-06-10T09:00:00Z"}},
+		{"until", "2030-06-03", "FREQ=MONTHLY;UNTIL=20300803", []string{"2030-07-03", "2030-08-03"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client, srv := newTestClient(t)
+			db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+			first := srv.AddPage(db, map[string]any{"Title": "Water plants", "Status": "Done", "Priority": "Low",
+				"Due Date": tc.due, "Repeat": tc.repeat})
+			ctx := context.Background()
+
+			id := first
+			for _, want := range tc.want {
+				next, isNew, err := client.RollTask(ctx, id)
+				if err != nil {
+					t.Fatalf("RollTask: %v", err)
+				}
+				if !isNew || next.DueDate != want || next.SeriesID != first || next.Status != "Todo" || next.Priority != "Low" {
+					t.Fatalf("RollTask = %+v (new %v), want a Todo instance due %s in series %s", next, isNew, want, first)
+				}
+
+				created := createdPages(srv)
+				again, isNew, err := client.RollTask(ctx, id)
+				if err != nil || isNew || again.ID != next.ID {
+					t.Errorf("RollTask again = %+v (new %v), %v, want the instance already created", again, isNew, err)
+				}
+				if createdPages(srv) != created {
+					t.Error("rolling the same task again created a page")
+				}
+				id = next.ID
+			}
+		})
+	}
+}
+
+func TestRollTaskEnds(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	first := srv.AddPage(db, map[string]any{"Title": "Water plants", "Due Date": "2030-06-03", "Repeat": "FREQ=WEEKLY;COUNT=2"})
+	ctx := context.Background()
+
+	next, _, err := client.RollTask(ctx, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last, isNew, err := client.RollTask(ctx, next.ID)
+	if err != nil || last != nil || isNew {
+		t.Errorf("RollTask past COUNT = %+v, %v, %v, want no instance", last, isNew, err)
+	}
+
+	plain := srv.AddPage(db, map[string]any{"Title": "Once", "Due Date": "2030-06-03"})
+	if _, _, err := client.RollTask(ctx, plain); err == nil {
+		t.Error("RollTask of a task without a repeat rule succeeded")
+	}
+	undated := srv.AddPage(db, map[string]any{"Title": "Someday", "Repeat": "FREQ=WEEKLY"})
+	if _, _, err := client.RollTask(ctx, undated); err == nil {
+		t.Error("RollTask of a task without a due date succeeded")
+	}
+}
+
+func TestCompleteRecurringTask(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	task := srv.AddPage(db, map[string]any{"Title": "Water plants", "Status": "Todo", "Due Date": "2030-06-03", "Repeat": "FREQ=WEEKLY"})
+
+	done, err := client.CompleteTask(context.Background(), task, false)
+	if err != nil {
+		t.Fatalf("CompleteTask: %v", err)
+	}
+	next, err := client.GetTask(context.Background(), done.NextID)
+	if err != nil || next.DueDate != "2030-06-10" || next.Status != "Todo" {
+		t.Errorf("next instance = %+v, %v, want a Todo due 2030-06-10", next, err)
+	}
+}
+
+func TestExpandAndSkipEvent(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Events", notiontest.EventsSchema())
+	// Far enough ahead that the expansion starts at the event
+	series := srv.AddPage(db, map[string]any{"Title": "Sync", "Date": span("2030-06-03T14:00:00Z", "2030-06-03T15:00:00Z"),
+		"Attendees": []string{"Ada"}, "Repeat": "FREQ=WEEKLY;COUNT=4"})
+	ctx := context.Background()
+
+	starts := func(events []Event) []string {
+		var out []string
+		for _, e := range events {
+			out = append(out, e.Start)
+		}
+		return out
+	}
+
+	created, err := client.ExpandEvent(ctx, series, "2030-06-17")
+	if err != nil {
+		t.Fatalf("ExpandEvent: %v", err)
+	}
+	if got := starts(created); len(got) != 2 || got[0] != "2030-06-10T14:00:00Z" || got[1] != "2030-06-17T14:00:00Z" {
+		t.Fatalf("ExpandEvent created %q, want the occurrences on June 10 and 17", got)
+	}
+	if o := created[0]; o.SeriesID != series || o.End != "2030-06-10T15:00:00Z" || len(o.Attendees) != 1 || o.Repeat != "" {
+		t.Errorf("occurrence = %+v, want a copy of the series event", o)
+	}
+
+	created, err = client.ExpandEvent(ctx, series, "2030-12-31")
+	if err != nil {
+		t.Fatalf("ExpandEvent: %v", err)
+	}
+	if got := starts(created); len(got) != 1 || got[0] != "2030-06-24T14:00:00Z" {
+		t.Errorf("expanding again created %q, want only the last occurrence", got)
+	}
+
+	skipped, err := client.SkipOccurrence(ctx, series, "2030-06-10")
+	if err != nil {
+		t.Fatalf("SkipOccurrence: %v", err)
+	}
+	if want := "FREQ=WEEKLY;COUNT=4;EXDATE=20300610"; skipped.Repeat != want {
+		t.Errorf("repeat rule after skipping = %q, want %q", skipped.Repeat, want)
+	}
+	remaining, err := client.QueryEvents(ctx, db, EventQueryOptions{DateAfter: "2030-06-01", DateBefore: "2030-06-30"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := starts(remaining); len(got) != 3 || got[0] != "2030-06-03T14:00:00Z" || got[1] != "2030-06-17T14:00:00Z" {
+		t.Errorf("events after skipping June 10 = %q", got)
+	}
+
+	created, err = client.ExpandEvent(ctx, series, "2030-12-31")
+	if err != nil || len(created) != 0 {
+		t.Errorf("expanding after the skip created %q, %v, want nothing", starts(created), err)
+	}
+
+	if _, err := client.ExpandEvent(ctx, remaining[1].ID, "2030-12-31"); err == nil {
+		t.Error("ExpandEvent of an occurrence succeeded")
+	}
+	if _, err := client.SkipOccurrence(ctx, remaining[1].ID, "2030-06-24"); err == nil {
+		t.Error("SkipOccurrence on an occurrence succeeded")
+	}
+	plain := srv.AddPage(db, map[string]any{"Title": "Once", "Date": "2030-06-03T09:00:00Z"})
+	if _, err := client.SkipOccurrence(ctx, plain, "2030-06-03"); err == nil {
+		t.Error("SkipOccurrence on an event without a repeat rule succeeded")
+	}
+}
@@ -0,0 +1,212 @@
+package notioncli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jomei/notionapi"
+)
+
+// BlockedError is returned when completing a task whose blockers are still
+// open
+type BlockedError struct {
+	Blockers []Task
+}
+
+func (e *BlockedError) Error() string {
+	parts := make([]string, 0, len(e.Blockers))
+	for _, task := range e.Blockers {
+		parts = append(parts, fmt.Sprintf("%q (%s)", task.Title, task.Status))
+	}
+	return fmt.Sprintf("task is blocked by %s; use --force to complete it anyway", strings.Join(parts, ", "))
+}
+
+// TaskNode is a task with its sub-tasks
+type TaskNode struct {
+	Task
+	Children []*TaskNode `json:"children,omitempty"`
+}
+
+// setRelations adds the parent and blocked-by relations to the properties
+// being written
+func (c *Client) setRelations(enc *propertyEncoder, parentID string, blockedBy []string) {
+	if parentID != "" {
+		enc.relation(c.settings.ParentProperty, []string{parentID})
+	}
+	if blockedBy != nil {
+		enc.relation(c.settings.BlockedByProperty, blockedBy)
+	}
+}
+
+// relations reads the parent and blocked-by relations of a page
+func (c *Client) relations(page *notionapi.Page) (string, []string) {
+	var parent string
+	if ids := relationIDs(page, c.settings.ParentProperty); len(ids) > 0 {
+		parent = ids[0]
+	}
+	return parent, relationIDs(page, c.settings.BlockedByProperty)
+}
+
+// LinkBlocker records that blockerID blocks taskID. With remove set the link
+// is taken away instead. The updated blocked task is returned.
+func (c *Client) LinkBlocker(ctx context.Context, blockerID, taskID string, remove bool) (*Task, error) {
+	if sameID(blockerID, taskID) {
+		return nil, fmt.Errorf("a task cannot block itself")
+	}
+	task, err := c.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockedBy := make([]string, 0, len(task.BlockedBy)+1)
+	linked := false
+	for _, id := range task.BlockedBy {
+		if sameID(id, blockerID) {
+			linked = true
+			if remove {
+				continue
+			}
+		}
+		blockedBy = append(blockedBy, id)
+	}
+	if linked != remove {
+		return task, nil
+	}
+	if !remove {
+		blockedBy = append(blockedBy, blockerID)
+	}
+
+	return c.UpdateTask(ctx, taskID, TaskInput{BlockedBy: blockedBy})
+}
+
+// OpenBlockers returns the tasks blocking a task that are neither done nor
+// cancelled
+func (c *Client) OpenBlockers(ctx context.Context, task *Task, groups StatusGroups) ([]Task, error) {
+	var open []Task
+	for _, id := range task.BlockedBy {
+		blocker, err := c.GetTask(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check blocker %s: %w", id, err)
+		}
+		if !groups.IsClosed(blocker.Status) {
+			open = append(open, *blocker)
+		}
+	}
+	return open, nil
+}
+
+// TaskTree returns a task with its sub-tasks, found through the parent
+// relation, down to the leaves
+func (c *Client) TaskTree(ctx context.Context, databaseID, taskID string) (*TaskNode, error) {
+	task, err := c.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	root := &TaskNode{Task: *task}
+	seen := map[string]bool{normalizeID(task.ID): true}
+
+	queue := []*TaskNode{root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		pages, err := c.queryAllPages(ctx, databaseID, notionapi.PropertyFilter{
+			Property: c.settings.ParentProperty,
+			Relation: &notionapi.RelationFilterCondition{Contains: node.ID},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query sub-tasks: %w", err)
+		}
+		for i := range pages {
+			// A task reachable twice means the hierarchy has a cycle
+			if seen[normalizeID(string(pages[i].ID))] {
+				continue
+			}
+			seen[normalizeID(string(pages[i].ID))] = true
+
+			child, err := c.pageToTask(ctx, &pages[i])
+			if err != nil {
+				return nil, err
+			}
+			childNode := &TaskNode{Task: *child}
+			node.Children = append(node.Children, childNode)
+			queue = append(queue, childNode)
+		}
+	}
+
+	return root, nil
+}
+
+// WriteTree renders the hierarchy as an indented outline. Markdown output is
+// a nested checklist with closed tasks ticked.
+func (n *TaskNode) WriteTree(w io.Writer, groups StatusGroups, markdown bool) error {
+	var write func(node *TaskNode, prefix string, last, root bool) error
+	write = func(node *TaskNode, prefix string, last, root bool) error {
+		label := node.Title
+		if node.Status != "" {
+			label += " [" + node.Status + "]"
+		}
+		if node.DueDate != "" {
+			label += " due " + node.DueDate
+		}
+
+		var line, childPrefix string
+		switch {
+		case markdown:
+			box := "[ ]"
+			if groups.IsClosed(node.Status) {
+				box = "[x]"
+			}
+			line = prefix + "- " + box + " " + label
+			childPrefix = prefix + "  "
+		case root:
+			line = label
+		case last:
+			line = prefix + "└── " + label
+			childPrefix = prefix + "    "
+		default:
+			line = prefix + "├── " + label
+			childPrefix = prefix + "│   "
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+
+		for i, child := range node.Children {
+			if err := write(child, childPrefix, i == len(node.Children)-1, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return write(n, "", true, true)
+}
+
+// relationProperty builds a relation property value from page IDs
+func relationProperty(ids []string) notionapi.RelationProperty {
+	relation := make([]notionapi.Relation, 0, len(ids))
+	for _, id := range ids {
+		relation = append(relation, notionapi.Relation{ID: notionapi.PageID(id)})
+	}
+	return notionapi.RelationProperty{Relation: relation}
+}
+
+// relationIDs returns the page IDs in a relation property of a page
+func relationIDs(page *notionapi.Page, name string) []string {
+	prop, ok := page.Properties[name].(*notionapi.RelationProperty)
+	if !ok || len(prop.Relation) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(prop.Relation))
+	for _, rel := range prop.Relation {
+		ids = append(ids, string(rel.ID))
+	}
+	return ids
+}
+
+// normalizeID strips dashes and case from a Notion ID
+func normalizeID(id string) string {
+	return strings.ToLower(strings.ReplaceAll(id, "-", ""))
+}
@@ -0,0 +1,156 @@
+package notioncli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jontk/notion-cli/internal/notiontest"
+)
+
+func TestTaskTree(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	root := srv.AddPage(db, map[string]any{"Title": "Launch", "Status": "In Progress"})
+	docs := srv.AddPage(db, map[string]any{"Title": "Docs", "Status": "Done", "Parent": root})
+	srv.AddPage(db, map[string]any{"Title": "Site", "Status": "Todo", "Parent": root, "Due Date": "2026-11-02"})
+	srv.AddPage(db, map[string]any{"Title": "Guide", "Status": "Todo", "Parent": docs})
+	srv.AddPage(db, map[string]any{"Title": "Unrelated", "Status": "Todo"})
+	ctx := context.Background()
+
+	tree, err := client.TaskTree(ctx, db, root)
+	if err != nil {
+		t.Fatalf("TaskTree: %v", err)
+	}
+
+	groups := client.Settings().TaskStatuses
+	for _, tc := range []struct {
+		markdown bool
+		want     string
+	}{
+		{false, "Launch [In Progress]\n├── Docs [Done]\n│   └── Guide [Todo]\n└── Site [Todo] due 2026-11-02\n"},
+		{true, "- [ ] Launch [In Progress]\n  - [x] Docs [Done]\n    - [ ] Guide [Todo]\n  - [ ] Site [Todo] due 2026-11-02\n"},
+	} {
+		var buf bytes.Buffer
+		if err := tree.WriteTree(&buf, groups, tc.markdown); err != nil {
+			t.Fatalf("WriteTree: %v", err)
+		}
+		if buf.String() != tc.want {
+			t.Errorf("WriteTree(markdown %v) =\n%s\nwant\n%s", tc.markdown, buf.String(), tc.want)
+		}
+	}
+}
+
+func TestTaskTreeCycle(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	a := srv.AddPage(db, map[string]any{"Title": "A"})
+	b := srv.AddPage(db, map[string]any{"Title": "B", "Parent": a})
+	c := srv.AddPage(db, map[string]any{"Title": "C", "Parent": b})
+	// A is a sub-task of its own grandchild
+	if _, err := client.UpdateTask(context.Background(), a, TaskInput{ParentID: c}); err != nil {
+		t.Fatal(err)
+	}
+
+	tree, err := client.TaskTree(context.Background(), db, a)
+	if err != nil {
+		t.Fatalf("TaskTree: %v", err)
+	}
+	if len(tree.Children) != 1 || tree.Children[0].Title != "B" ||
+		len(tree.Children[0].Children) != 1 || tree.Children[0].Children[0].Title != "C" ||
+		len(tree.Children[0].Children[0].Children) != 0 {
+		t.Errorf("TaskTree with a cycle = %+v, want A > B > C", tree)
+	}
+}
+
+func TestLinkBlocker(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	blocker := srv.AddPage(db, map[string]any{"Title": "Design"})
+	other := srv.AddPage(db, map[string]any{"Title": "Budget"})
+	task := srv.AddPage(db, map[string]any{"Title": "Build"})
+	ctx := context.Background()
+
+	updates := func() int {
+		n := 0
+		for _, r := range srv.Requests() {
+			if r.Method == "PATCH" {
+				n++
+			}
+		}
+		return n
+	}
+
+	for _, tc := range []struct {
+		name    string
+		blocker string
+		remove  bool
+		want    []string
+		updates int
+	}{
+		{"link", blocker, false, []string{blocker}, 1},
+		{"link again", blocker, false, []string{blocker}, 1},
+		{"second blocker", other, false, []string{blocker, other}, 2},
+		{"remove", blocker, true, []string{other}, 3},
+		{"remove again", blocker, true, []string{other}, 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := client.LinkBlocker(ctx, tc.blocker, task, tc.remove)
+			if err != nil {
+				t.Fatalf("LinkBlocker: %v", err)
+			}
+			if !reflect.DeepEqual(got.BlockedBy, tc.want) {
+				t.Errorf("BlockedBy = %v, want %v", got.BlockedBy, tc.want)
+			}
+			if n := updates(); n != tc.updates {
+				t.Errorf("updated the task %d times, want %d", n, tc.updates)
+			}
+		})
+	}
+
+	if _, err := client.LinkBlocker(ctx, task, task, false); err == nil {
+		t.Error("LinkBlocker let a task block itself")
+	}
+}
+
+func TestCompleteBlockedTask(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	open := srv.AddPage(db, map[string]any{"Title": "Design", "Status": "In Progress"})
+	done := srv.AddPage(db, map[string]any{"Title": "Budget", "Status": "Done"})
+	task := srv.AddPage(db, map[string]any{"Title": "Build", "Status": "Todo", "Blocked By": []string{open, done}})
+	ctx := context.Background()
+
+	current, err := client.GetTask(ctx, task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockers, err := client.OpenBlockers(ctx, current, client.Settings().TaskStatuses)
+	if err != nil || len(blockers) != 1 || blockers[0].ID != open {
+		t.Errorf("OpenBlockers = %+v, %v, want only Design", blockers, err)
+	}
+
+	_, err = client.CompleteTask(ctx, task, false)
+	var blocked *BlockedError
+	if !errors.As(err, &blocked) || len(blocked.Blockers) != 1 || blocked.Blockers[0].Title != "Design" {
+		t.Fatalf("CompleteTask with an open blocker: %v, want a BlockedError naming Design", err)
+	}
+	if got, _ := client.GetTask(ctx, task); got.Status != "Todo" {
+		t.Errorf("status = %q after a refused completion, want Todo", got.Status)
+	}
+
+	completed, err := client.CompleteTask(ctx, task, true)
+	if err != nil || completed.Status != "Done" {
+		t.Errorf("CompleteTask with force = %+v, %v", completed, err)
+	}
+
+	again := srv.AddPage(db, map[string]any{"Title": "Ship", "Status": "Todo", "Blocked By": open})
+	if _, err := client.CompleteTask(ctx, open, false); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := client.CompleteTask(ctx, again, false); err != nil || got.Status != "Done" {
+		t.Errorf("CompleteTask once its blocker is done = %+v, %v", got, err)
+	}
+}
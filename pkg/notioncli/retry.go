@@ -0,0 +1,71 @@
+package notioncli
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultRetries is how many times a rate limited request is sent again
+const defaultRetries = 3
+
+// retryTransport sends a request again when Notion answers 429 Too Many
+// Requests, after the Retry-After delay. notionapi retries by sending the
+// same *http.Request, whose body was read by the first attempt, so the
+// retries happen here where the body can be replayed.
+type retryTransport struct {
+	base    http.RoundTripper
+	retries int
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		r := req.Clone(req.Context())
+		if req.Body != nil {
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			r.ContentLength = int64(len(body))
+		}
+		res, err := base.RoundTrip(r)
+		if err != nil || res.StatusCode != http.StatusTooManyRequests || attempt >= t.retries {
+			return res, err
+		}
+
+		wait := retryAfter(res)
+		io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryAfter returns the delay a rate limited response asks for, or a
+// second when it doesn't say
+func retryAfter(res *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(res.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return time.Second
+	}
+	return time.Duration(seconds) * time.Second
+}
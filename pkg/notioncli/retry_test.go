@@ -0,0 +1,54 @@
+package notioncli
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jomei/notionapi"
+	"github.com/jontk/notion-cli/internal/notiontest"
+)
+
+func TestRateLimitRetries(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		retries  int
+		failures int
+		wantErr  bool
+	}{
+		{"recovers", 3, 3, false},
+		{"gives up", 3, 4, true},
+		{"disabled", 0, 1, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := notiontest.NewServer()
+			srv.Token = testToken
+			client := New(testToken, WithHTTPClient(srv.HTTPClient()), WithLocation(time.UTC), WithRetries(tc.retries))
+			db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+			for i := 0; i < tc.failures; i++ {
+				srv.FailNext(http.MethodPost, "pages", http.StatusTooManyRequests, "rate_limited")
+			}
+
+			_, err := client.CreateTask(context.Background(), TaskInput{Title: "Retry me"}, db)
+			var limited *notionapi.RateLimitedError
+			if tc.wantErr && !errors.As(err, &limited) {
+				t.Errorf("err = %v, want a rate limit error", err)
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("CreateTask: %v", err)
+			}
+
+			creates := 0
+			for _, r := range srv.Requests() {
+				if r.Method == http.MethodPost && r.Path == "pages" {
+					creates++
+				}
+			}
+			if want := min(tc.failures+1, tc.retries+1); creates != want {
+				t.Errorf("sent %d create requests, want %d", creates, want)
+			}
+		})
+	}
+}
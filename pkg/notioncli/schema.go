@@ -0,0 +1,340 @@
+package notioncli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jomei/notionapi"
+)
+
+// propertyUpdate is a property configuration in the form the database
+// endpoints take it. notionapi's config types can't express renames or
+// single-property relations, so changes are built as plain JSON.
+type propertyUpdate map[string]any
+
+func (p propertyUpdate) GetType() notionapi.PropertyConfigType {
+	t, _ := p["type"].(string)
+	return notionapi.PropertyConfigType(t)
+}
+
+func (p propertyUpdate) GetID() notionapi.PropertyID {
+	return ""
+}
+
+// propertyTypes lists the property types a spec can create
+var propertyTypes = []string{
+	"title", "rich_text", "number", "select", "multi_select", "status",
+	"date", "people", "files", "checkbox", "url", "email", "phone_number",
+	"relation", "formula", "created_time", "created_by", "last_edited_time",
+	"last_edited_by",
+}
+
+// optionColors lists the colors Notion accepts for select options
+var optionColors = []string{
+	"default", "gray", "brown", "orange", "yellow", "green", "blue", "purple", "pink", "red",
+}
+
+// propertyConfig builds the configuration creating a property from its spec.
+// A relation without a target database points at databaseID.
+func propertyConfig(name string, spec PropertySpec, databaseID string) (propertyUpdate, error) {
+	if !contains(propertyTypes, spec.Type) {
+		return nil, fmt.Errorf("property %q has unknown type %q: use one of %s", name, spec.Type, strings.Join(propertyTypes, ", "))
+	}
+	if len(spec.Options) > 0 && spec.Type != "select" && spec.Type != "multi_select" && spec.Type != "status" {
+		return nil, fmt.Errorf("property %q is a %s property and can't have options", name, spec.Type)
+	}
+
+	body := map[string]any{}
+	switch spec.Type {
+	case "number":
+		format := spec.Format
+		if format == "" {
+			format = "number"
+		}
+		body["format"] = format
+	case "select", "multi_select":
+		options := make([]map[string]any, 0, len(spec.Options))
+		for _, option := range spec.Options {
+			if strings.Contains(option, ",") {
+				return nil, fmt.Errorf("option %q of %q can't contain a comma", option, name)
+			}
+			options = append(options, map[string]any{"name": option})
+		}
+		body["options"] = options
+	case "relation":
+		target := spec.Database
+		if target == "" {
+			target = databaseID
+		}
+		if target == "" {
+			return nil, fmt.Errorf("relation %q needs a database", name)
+		}
+		body["database_id"] = target
+		body["type"] = "single_property"
+		body["single_property"] = map[string]any{}
+	case "formula":
+		if spec.Expression == "" {
+			return nil, fmt.Errorf("formula %q needs an expression", name)
+		}
+		body["expression"] = spec.Expression
+	}
+	return propertyUpdate{"type": spec.Type, spec.Type: body}, nil
+}
+
+// statusStep is the manual step for status options the API can't set
+func statusStep(name string, options []string) string {
+	return fmt.Sprintf("add the options %s to the status property %q in Notion; the API can't set status options",
+		quoteList(options), name)
+}
+
+func quoteList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, fmt.Sprintf("%q", v))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// CreateDatabase creates a database in a page from a spec. Relations to the
+// database itself are added once it exists. Status properties get Notion's
+// default options; the options the spec asks for are returned as manual
+// steps.
+func (c *Client) CreateDatabase(ctx context.Context, spec DatabaseSpec) (*DatabaseCreated, error) {
+	if spec.Parent == "" {
+		return nil, fmt.Errorf("a parent page is required")
+	}
+	if spec.Title == "" {
+		return nil, fmt.Errorf("a title is required")
+	}
+
+	names := make([]string, 0, len(spec.Properties))
+	titles := 0
+	for name, prop := range spec.Properties {
+		names = append(names, name)
+		if prop.Type == "title" {
+			titles++
+		}
+	}
+	if titles != 1 {
+		return nil, fmt.Errorf("a database needs exactly one title property, the schema has %d", titles)
+	}
+	sort.Strings(names)
+
+	properties := notionapi.PropertyConfigs{}
+	selfRelations := notionapi.PropertyConfigs{}
+	var steps []string
+	for _, name := range names {
+		prop := spec.Properties[name]
+		if prop.Type == "relation" && prop.Database == "" {
+			// Checked now, added once the database has an ID
+			if _, err := propertyConfig(name, prop, "self"); err != nil {
+				return nil, err
+			}
+			selfRelations[name] = nil
+			continue
+		}
+		cfg, err := propertyConfig(name, prop, "")
+		if err != nil {
+			return nil, err
+		}
+		properties[name] = cfg
+		if prop.Type == "status" && len(prop.Options) > 0 {
+			steps = append(steps, statusStep(name, prop.Options))
+		}
+	}
+
+	db, err := c.api.Database.Create(ctx, &notionapi.DatabaseCreateRequest{
+		Parent: notionapi.Parent{
+			Type:   notionapi.ParentTypePageID,
+			PageID: notionapi.PageID(spec.Parent),
+		},
+		Title:      richText(spec.Title),
+		Properties: properties,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database: %w", err)
+	}
+
+	if len(selfRelations) > 0 {
+		for name := range selfRelations {
+			cfg, _ := propertyConfig(name, spec.Properties[name], string(db.ID))
+			selfRelations[name] = cfg
+		}
+		db, err = c.updateDatabase(ctx, string(db.ID), selfRelations)
+		if err != nil {
+			return nil, fmt.Errorf("database created but its relations were not: %w", err)
+		}
+	}
+
+	return &DatabaseCreated{
+		ID:          string(db.ID),
+		Title:       extractRichText(db.Title),
+		URL:         db.URL,
+		ManualSteps: steps,
+	}, nil
+}
+
+// updateDatabase sends property changes and refreshes the cached schema
+func (c *Client) updateDatabase(ctx context.Context, databaseID string, properties notionapi.PropertyConfigs) (*notionapi.Database, error) {
+	db, err := c.api.Database.Update(ctx, notionapi.DatabaseID(databaseID), &notionapi.DatabaseUpdateRequest{
+		Properties: properties,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update database: %w", err)
+	}
+
+	c.mu.Lock()
+	c.schemas[normalizeID(databaseID)] = db.Properties
+	delete(c.checkedDatabases, databaseID)
+	c.mu.Unlock()
+	return db, nil
+}
+
+// AddProperty adds a property to a database. Status properties get
+// Notion's default options, so a spec with status options is refused.
+func (c *Client) AddProperty(ctx context.Context, databaseID, name string, spec PropertySpec) (*Schema, error) {
+	db, err := c.api.Database.Get(ctx, notionapi.DatabaseID(databaseID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+	if _, ok := db.Properties[name]; ok {
+		return nil, fmt.Errorf("property %q already exists", name)
+	}
+	if spec.Type == "title" {
+		return nil, fmt.Errorf("a database has exactly one title property; rename the existing one instead")
+	}
+	if spec.Type == "status" && len(spec.Options) > 0 {
+		return nil, fmt.Errorf("the API can't set status options; add the property without options and add %s in Notion", quoteList(spec.Options))
+	}
+
+	cfg, err := propertyConfig(name, spec, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	db, err = c.updateDatabase(ctx, databaseID, notionapi.PropertyConfigs{name: cfg})
+	if err != nil {
+		return nil, err
+	}
+	return schemaInfo(db), nil
+}
+
+// RenameProperty renames a database property. Pages keep their values.
+func (c *Client) RenameProperty(ctx context.Context, databaseID, name, newName string) (*Schema, error) {
+	if newName == "" {
+		return nil, fmt.Errorf("a new name is required")
+	}
+	db, err := c.api.Database.Get(ctx, notionapi.DatabaseID(databaseID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+	if _, ok := db.Properties[name]; !ok {
+		return nil, fmt.Errorf("property %q %w", name, ErrNotFound)
+	}
+	if _, ok := db.Properties[newName]; ok && newName != name {
+		return nil, fmt.Errorf("property %q already exists", newName)
+	}
+
+	db, err = c.updateDatabase(ctx, databaseID, notionapi.PropertyConfigs{name: propertyUpdate{"name": newName}})
+	if err != nil {
+		return nil, err
+	}
+	return schemaInfo(db), nil
+}
+
+// AddOption adds an option to a select or multi-select property. An empty
+// color leaves the choice to Notion.
+func (c *Client) AddOption(ctx context.Context, databaseID, property, option, color string) (*Schema, error) {
+	if strings.Contains(option, ",") {
+		return nil, fmt.Errorf("option %q can't contain a comma", option)
+	}
+	if color != "" && !contains(optionColors, color) {
+		return nil, fmt.Errorf("unknown color %q: use one of %s", color, strings.Join(optionColors, ", "))
+	}
+	kind, options, err := c.propertyOptions(ctx, databaseID, property)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range options {
+		if o.Name == option {
+			return nil, fmt.Errorf("%q already has the option %q", property, option)
+		}
+	}
+
+	options = append(options, notionapi.Option{Name: option, Color: notionapi.Color(color)})
+	return c.setOptions(ctx, databaseID, property, kind, options)
+}
+
+// RemoveOption removes an option from a select or multi-select property.
+// Notion clears it from every page that had it.
+func (c *Client) RemoveOption(ctx context.Context, databaseID, property, option string) (*Schema, error) {
+	kind, options, err := c.propertyOptions(ctx, databaseID, property)
+	if err != nil {
+		return nil, err
+	}
+	kept := make([]notionapi.Option, 0, len(options))
+	for _, o := range options {
+		if o.Name != option {
+			kept = append(kept, o)
+		}
+	}
+	if len(kept) == len(options) {
+		return nil, fmt.Errorf("%q has no option %q", property, option)
+	}
+	return c.setOptions(ctx, databaseID, property, kind, kept)
+}
+
+// propertyOptions returns the type and options of a select or multi-select
+// property
+func (c *Client) propertyOptions(ctx context.Context, databaseID, property string) (string, []notionapi.Option, error) {
+	db, err := c.api.Database.Get(ctx, notionapi.DatabaseID(databaseID))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get database: %w", err)
+	}
+	switch p := db.Properties[property].(type) {
+	case *notionapi.SelectPropertyConfig:
+		return "select", p.Select.Options, nil
+	case *notionapi.MultiSelectPropertyConfig:
+		return "multi_select", p.MultiSelect.Options, nil
+	case *notionapi.StatusPropertyConfig:
+		return "", nil, fmt.Errorf("the API can't change status options; change the options of %q in Notion", property)
+	case nil:
+		return "", nil, fmt.Errorf("property %q %w", property, ErrNotFound)
+	default:
+		return "", nil, fmt.Errorf("property %q is a %s property, not a select or multi-select", property, p.GetType())
+	}
+}
+
+// setOptions replaces the options of a select or multi-select property.
+// Options not listed are deleted.
+func (c *Client) setOptions(ctx context.Context, databaseID, property, kind string, options []notionapi.Option) (*Schema, error) {
+	list := make([]map[string]any, 0, len(options))
+	for _, o := range options {
+		option := map[string]any{"name": o.Name}
+		if o.ID != "" {
+			option["id"] = o.ID
+		}
+		if o.Color != "" {
+			option["color"] = o.Color
+		}
+		list = append(list, option)
+	}
+	update := propertyUpdate{"type": kind, kind: map[string]any{"options": list}}
+
+	db, err := c.updateDatabase(ctx, databaseID, notionapi.PropertyConfigs{property: update})
+	if err != nil {
+		return nil, err
+	}
+	return schemaInfo(db), nil
+}
+
+// contains reports whether values contains s
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
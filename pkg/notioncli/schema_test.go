@@ -0,0 +1,197 @@
+package notioncli
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jomei/notionapi"
+	"github.com/jontk/notion-cli/internal/notiontest"
+)
+
+func TestCreateDatabase(t *testing.T) {
+	client, srv := newTestClient(t)
+	parent := srv.AddWorkspacePage("Team")
+	ctx := context.Background()
+
+	created, err := client.CreateDatabase(ctx, DatabaseSpec{
+		Title:  "Tasks",
+		Parent: parent,
+		Properties: map[string]PropertySpec{
+			"Title":    {Type: "title"},
+			"Status":   {Type: "status", Options: []string{"Todo", "Done"}},
+			"Priority": {Type: "select", Options: []string{"High", "Low"}},
+			"Effort":   {Type: "number", Format: "percent"},
+			"Parent":   {Type: "relation"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateDatabase: %v", err)
+	}
+	if created.Title != "Tasks" || created.URL == "" {
+		t.Errorf("CreateDatabase returned %+v", *created)
+	}
+	if len(created.ManualSteps) != 1 || !strings.Contains(created.ManualSteps[0], `"Todo", "Done"`) {
+		t.Errorf("manual steps = %q, want the status options", created.ManualSteps)
+	}
+
+	db, err := client.api.Database.Get(ctx, notionapi.DatabaseID(created.ID))
+	if err != nil {
+		t.Fatalf("get database: %v", err)
+	}
+	relation, ok := db.Properties["Parent"].(*notionapi.RelationPropertyConfig)
+	if !ok || normalizeID(string(relation.Relation.DatabaseID)) != normalizeID(created.ID) {
+		t.Errorf("Parent = %+v, want a relation to the database itself", db.Properties["Parent"])
+	}
+	if number, ok := db.Properties["Effort"].(*notionapi.NumberPropertyConfig); !ok || number.Number.Format != "percent" {
+		t.Errorf("Effort = %+v, want a percent number", db.Properties["Effort"])
+	}
+	if got := kindOf(db.Properties["Status"]); got != kindStatus {
+		t.Errorf("Status has kind %v, want a status", got)
+	}
+
+	task, err := client.CreateTask(ctx, TaskInput{Title: "First", Priority: "High"}, created.ID)
+	if err != nil {
+		t.Fatalf("CreateTask in the new database: %v", err)
+	}
+	if task.Priority != "High" {
+		t.Errorf("priority = %q, want High", task.Priority)
+	}
+}
+
+func TestCreateDatabaseInvalid(t *testing.T) {
+	client, srv := newTestClient(t)
+	parent := srv.AddWorkspacePage("Team")
+
+	tests := []struct {
+		name string
+		spec DatabaseSpec
+		want string
+	}{
+		{"no parent", DatabaseSpec{Title: "T", Properties: map[string]PropertySpec{"Name": {Type: "title"}}}, "parent"},
+		{"no title property", DatabaseSpec{Title: "T", Parent: parent, Properties: map[string]PropertySpec{"Notes": {Type: "rich_text"}}}, "title property"},
+		{"unknown type", DatabaseSpec{Title: "T", Parent: parent, Properties: map[string]PropertySpec{
+			"Name": {Type: "title"}, "Rating": {Type: "stars"},
+		}}, "unknown type"},
+		{"options on text", DatabaseSpec{Title: "T", Parent: parent, Properties: map[string]PropertySpec{
+			"Name": {Type: "title"}, "Notes": {Type: "rich_text", Options: []string{"a"}},
+		}}, "can't have options"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.CreateDatabase(context.Background(), tt.spec)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Errorf("sent %d requests for invalid specs", n)
+	}
+}
+
+func TestAddProperty(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	ctx := context.Background()
+
+	schema, err := client.AddProperty(ctx, db, "Estimate", PropertySpec{Type: "number"})
+	if err != nil {
+		t.Fatalf("AddProperty: %v", err)
+	}
+	if schema.Properties["Estimate"].Type != "number" {
+		t.Errorf("Estimate has type %q, want number", schema.Properties["Estimate"].Type)
+	}
+
+	if _, err := client.AddProperty(ctx, db, "Priority", PropertySpec{Type: "select"}); err == nil {
+		t.Error("AddProperty of an existing property succeeded")
+	}
+	if _, err := client.AddProperty(ctx, db, "Stage", PropertySpec{Type: "status", Options: []string{"New"}}); err == nil {
+		t.Error("AddProperty of a status with options succeeded")
+	}
+}
+
+func TestRenameProperty(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	page := srv.AddPage(db, map[string]any{"Title": "Keep me", "Notes": "still here"})
+	ctx := context.Background()
+
+	schema, err := client.RenameProperty(ctx, db, "Notes", "Details")
+	if err != nil {
+		t.Fatalf("RenameProperty: %v", err)
+	}
+	if _, ok := schema.Properties["Notes"]; ok {
+		t.Error("Notes still exists after the rename")
+	}
+	if schema.Properties["Details"].Type != "rich_text" {
+		t.Errorf("Details = %+v, want the rich text property", schema.Properties["Details"])
+	}
+
+	p, err := client.api.Page.Get(ctx, notionapi.PageID(page))
+	if err != nil {
+		t.Fatalf("get page: %v", err)
+	}
+	if got := propertyValue(p, "Details"); got != "still here" {
+		t.Errorf("Details = %q, want the value kept", got)
+	}
+
+	if _, err := client.RenameProperty(ctx, db, "Missing", "Other"); !IsNotFound(err) {
+		t.Error("RenameProperty of a missing property succeeded")
+	}
+	if _, err := client.RenameProperty(ctx, db, "Details", "Priority"); err == nil {
+		t.Error("RenameProperty onto an existing name succeeded")
+	}
+}
+
+func TestAddAndRemoveOption(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	page := srv.AddPage(db, map[string]any{"Title": "Low one", "Priority": "Low"})
+	ctx := context.Background()
+
+	schema, err := client.AddOption(ctx, db, "Priority", "Urgent", "red")
+	if err != nil {
+		t.Fatalf("AddOption: %v", err)
+	}
+	if got, want := optionNames(schema.Properties["Priority"]), []string{"High", "Medium", "Low", "Urgent"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("options = %v, want %v", got, want)
+	}
+
+	schema, err = client.RemoveOption(ctx, db, "Priority", "Low")
+	if err != nil {
+		t.Fatalf("RemoveOption: %v", err)
+	}
+	if got, want := optionNames(schema.Properties["Priority"]), []string{"High", "Medium", "Urgent"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("options = %v, want %v", got, want)
+	}
+	task, err := client.GetTask(ctx, page)
+	if err != nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	if task.Priority != "" {
+		t.Errorf("priority = %q, want the removed option cleared", task.Priority)
+	}
+
+	for _, tt := range []struct {
+		name             string
+		property, option string
+		add              bool
+	}{
+		{"duplicate", "Priority", "High", true},
+		{"status", "Status", "Waiting", true},
+		{"not a select", "Notes", "x", true},
+		{"missing option", "Priority", "Someday", false},
+	} {
+		var err error
+		if tt.add {
+			_, err = client.AddOption(ctx, db, tt.property, tt.option, "")
+		} else {
+			_, err = client.RemoveOption(ctx, db, tt.property, tt.option)
+		}
+		if err == nil {
+			t.Errorf("%s: changing %s option %q succeeded", tt.name, tt.property, tt.option)
+		}
+	}
+}
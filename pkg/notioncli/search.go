@@ -0,0 +1,155 @@
+package notioncli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jomei/notionapi"
+)
+
+// SearchOptions holds options for searching the workspace
+type SearchOptions struct {
+	// Query matches page and database titles
+	Query string
+	// Type limits the results to "page" or "database"
+	Type string
+	// Sort is "last_edited" for the most recently edited first. Left
+	// empty, results come in Notion's relevance order.
+	Sort string
+	// Limit caps the number of results; zero returns them all
+	Limit int
+}
+
+// Search finds the pages and databases shared with the integration whose
+// titles match a query
+func (c *Client) Search(ctx context.Context, opts SearchOptions) ([]SearchResult, error) {
+	var results []SearchResult
+	err := c.search(ctx, opts, func(obj notionapi.Object) bool {
+		if result, ok := c.searchResult(obj); ok {
+			results = append(results, result)
+		}
+		return opts.Limit == 0 || len(results) < opts.Limit
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// searchRequest is notionapi.SearchRequest with an optional filter. The
+// notionapi type always sends a filter, and the API rejects an empty one.
+type searchRequest struct {
+	Query       string                  `json:"query,omitempty"`
+	Sort        *notionapi.SortObject   `json:"sort,omitempty"`
+	Filter      *notionapi.SearchFilter `json:"filter,omitempty"`
+	StartCursor notionapi.Cursor        `json:"start_cursor,omitempty"`
+	PageSize    int                     `json:"page_size,omitempty"`
+}
+
+// search runs a workspace search, following the cursor until the results
+// run out or fn returns false
+func (c *Client) search(ctx context.Context, opts SearchOptions, fn func(notionapi.Object) bool) error {
+	req := &searchRequest{
+		Query:    opts.Query,
+		PageSize: 100,
+	}
+
+	switch opts.Type {
+	case "":
+		// pages and databases
+	case "page", "database":
+		req.Filter = &notionapi.SearchFilter{Property: "object", Value: opts.Type}
+	default:
+		return fmt.Errorf("invalid type %q: use page or database", opts.Type)
+	}
+
+	switch opts.Sort {
+	case "":
+		// relevance
+	case "last_edited", "last_edited_time":
+		req.Sort = &notionapi.SortObject{
+			Timestamp: notionapi.TimestampType("last_edited_time"),
+			Direction: notionapi.SortOrderDESC,
+		}
+	default:
+		return fmt.Errorf("invalid sort %q: use last_edited", opts.Sort)
+	}
+
+	for {
+		var resp notionapi.SearchResponse
+		if err := c.post(ctx, "search", req, &resp); err != nil {
+			return fmt.Errorf("failed to search: %w", err)
+		}
+
+		for _, obj := range resp.Results {
+			if !fn(obj) {
+				return nil
+			}
+		}
+
+		if !resp.HasMore {
+			return nil
+		}
+		req.StartCursor = resp.NextCursor
+	}
+}
+
+// searchResult summarises a page or database found by a search
+func (c *Client) searchResult(obj notionapi.Object) (SearchResult, bool) {
+	switch o := obj.(type) {
+	case *notionapi.Page:
+		parentType, parentID := parentRef(o.Parent)
+		return SearchResult{
+			ID:         string(o.ID),
+			Object:     "page",
+			Title:      pageTitle(o),
+			ParentType: parentType,
+			ParentID:   parentID,
+			Icon:       iconText(o.Icon),
+			URL:        o.URL,
+			UpdatedAt:  c.formatTime(o.LastEditedTime),
+		}, true
+	case *notionapi.Database:
+		parentType, parentID := parentRef(o.Parent)
+		return SearchResult{
+			ID:         string(o.ID),
+			Object:     "database",
+			Title:      extractRichText(o.Title),
+			ParentType: parentType,
+			ParentID:   parentID,
+			Icon:       iconText(o.Icon),
+			URL:        o.URL,
+			UpdatedAt:  c.formatTime(o.LastEditedTime),
+		}, true
+	}
+	return SearchResult{}, false
+}
+
+// parentRef returns the kind of object a page or database sits in and its
+// ID, which is empty for the workspace
+func parentRef(p notionapi.Parent) (string, string) {
+	switch p.Type {
+	case notionapi.ParentTypeDatabaseID:
+		return "database", string(p.DatabaseID)
+	case notionapi.ParentTypePageID:
+		return "page", string(p.PageID)
+	case notionapi.ParentTypeBlockID:
+		return "block", string(p.BlockID)
+	}
+	return "workspace", ""
+}
+
+// iconText returns an emoji icon itself, or the URL of an image icon
+func iconText(icon *notionapi.Icon) string {
+	switch {
+	case icon == nil:
+		return ""
+	case icon.Emoji != nil:
+		return string(*icon.Emoji)
+	case icon.External != nil:
+		return icon.External.URL
+	case icon.File != nil:
+		return icon.File.URL
+	}
+	return ""
+}
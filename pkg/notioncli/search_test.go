@@ -0,0 +1,74 @@
+package notioncli
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/jontk/notion-cli/internal/notiontest"
+)
+
+func TestSearch(t *testing.T) {
+	client, srv := newTestClient(t)
+	srv.MaxPageSize = 1
+	db := srv.AddDatabase("Roadmap", notiontest.TasksSchema())
+	srv.SetIcon(db, "🗺️")
+	item := srv.AddPage(db, map[string]any{"Title": "Roadmap review"})
+	page := srv.AddWorkspacePage("Roadmap notes")
+	srv.AddWorkspacePage("Groceries")
+	ctx := context.Background()
+
+	ids := func(results []SearchResult) []string {
+		var out []string
+		for _, r := range results {
+			out = append(out, r.ID)
+		}
+		return out
+	}
+
+	results, err := client.Search(ctx, SearchOptions{Query: "roadmap", Sort: "last_edited"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if got, want := ids(results), []string{page, item, db}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Search = %q, want %q", got, want)
+	}
+	for _, r := range srv.Requests() {
+		var body map[string]any
+		if err := json.Unmarshal(r.Body, &body); err != nil {
+			t.Fatalf("search body %s: %v", r.Body, err)
+		}
+		if _, ok := body["filter"]; ok {
+			t.Errorf("search without a type sent a filter: %s", r.Body)
+		}
+	}
+
+	wantDB := SearchResult{
+		ID:         db,
+		Object:     "database",
+		Title:      "Roadmap",
+		ParentType: "workspace",
+		Icon:       "🗺️",
+		URL:        results[2].URL,
+		UpdatedAt:  results[2].UpdatedAt,
+	}
+	if results[2] != wantDB {
+		t.Errorf("database result = %+v, want %+v", results[2], wantDB)
+	}
+	if r := results[1]; r.Object != "page" || r.Title != "Roadmap review" || r.ParentType != "database" || r.ParentID != db {
+		t.Errorf("page result = %+v, want a page in the database", r)
+	}
+
+	results, err = client.Search(ctx, SearchOptions{Query: "roadmap", Type: "page", Limit: 1, Sort: "last_edited"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if got, want := ids(results), []string{page}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Search for one page = %q, want %q", got, want)
+	}
+
+	if _, err := client.Search(ctx, SearchOptions{Type: "block"}); err == nil {
+		t.Error("Search with an unknown type succeeded")
+	}
+}
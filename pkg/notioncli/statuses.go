@@ -0,0 +1,151 @@
+package notioncli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jomei/notionapi"
+)
+
+// StatusGroups sorts the values of a database's Status property by meaning,
+// so workflows that say "Complete" or "Won't do" instead of "Done" and
+// "Cancelled" work the same way
+type StatusGroups struct {
+	Open       []string
+	InProgress []string
+	Done       []string
+	Cancelled  []string
+}
+
+// notionGroups maps each status group onto the group Notion files it under
+// for properties of the status type
+var notionGroups = []struct {
+	name   string
+	notion string
+	values func(StatusGroups) []string
+}{
+	{"open", "To-do", func(g StatusGroups) []string { return g.Open }},
+	{"in_progress", "In progress", func(g StatusGroups) []string { return g.InProgress }},
+	{"done", "Complete", func(g StatusGroups) []string { return g.Done }},
+	{"cancelled", "Complete", func(g StatusGroups) []string { return g.Cancelled }},
+}
+
+// Active returns the statuses of work that isn't finished: open and in
+// progress
+func (g StatusGroups) Active() []string {
+	return append(append([]string(nil), g.Open...), g.InProgress...)
+}
+
+// IsCancelled reports whether status is one of the cancelled statuses
+func (g StatusGroups) IsCancelled(status string) bool {
+	return containsFold(g.Cancelled, status)
+}
+
+// IsClosed reports whether status is done or cancelled
+func (g StatusGroups) IsClosed(status string) bool {
+	return containsFold(g.Done, status) || containsFold(g.Cancelled, status)
+}
+
+// withDefaults fills the groups left empty from defaults
+func (g StatusGroups) withDefaults(defaults StatusGroups) StatusGroups {
+	if len(g.Open) == 0 {
+		g.Open = defaults.Open
+	}
+	if len(g.InProgress) == 0 {
+		g.InProgress = defaults.InProgress
+	}
+	if len(g.Done) == 0 {
+		g.Done = defaults.Done
+	}
+	if len(g.Cancelled) == 0 {
+		g.Cancelled = defaults.Cancelled
+	}
+	return g
+}
+
+// ValidateStatusGroups checks status groups against the Status property of a
+// database. For properties of the status type every configured status must
+// be an option, and it must sit in the matching Notion group: open statuses
+// under To-do, in-progress ones under In progress, and done and cancelled
+// ones under Complete. Select and multi-select properties accept new values,
+// so they are not checked.
+func (c *Client) ValidateStatusGroups(ctx context.Context, databaseID string, groups StatusGroups) error {
+	schema, err := c.databaseSchema(ctx, databaseID)
+	if err != nil {
+		return err
+	}
+
+	prop, ok := schema["Status"].(*notionapi.StatusPropertyConfig)
+	if !ok {
+		return nil
+	}
+
+	optionGroup := make(map[string]string, len(prop.Status.Options))
+	optionNames := make([]string, 0, len(prop.Status.Options))
+	for _, opt := range prop.Status.Options {
+		optionNames = append(optionNames, opt.Name)
+		for _, group := range prop.Status.Groups {
+			for _, id := range group.OptionIDs {
+				if string(id) == string(opt.ID) {
+					optionGroup[strings.ToLower(opt.Name)] = group.Name
+				}
+			}
+		}
+	}
+
+	var problems []string
+	for _, g := range notionGroups {
+		for _, status := range g.values(groups) {
+			group, ok := optionGroup[strings.ToLower(status)]
+			switch {
+			case !containsFold(optionNames, status):
+				problems = append(problems, fmt.Sprintf("%q (%s) is not a Status option; options are %s", status, g.name, strings.Join(optionNames, ", ")))
+			case ok && !strings.EqualFold(group, g.notion):
+				problems = append(problems, fmt.Sprintf("%q is configured as %s but Notion lists it under %q", status, g.name, group))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("status groups don't match the database schema: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// taskStatuses returns the task status groups, checked against the schema
+// of the database the first time it is used
+func (c *Client) taskStatuses(ctx context.Context, databaseID string) (StatusGroups, error) {
+	return c.checkedStatuses(ctx, databaseID, c.settings.TaskStatuses)
+}
+
+// eventStatuses returns the event status groups, checked against the schema
+// of the database the first time it is used
+func (c *Client) eventStatuses(ctx context.Context, databaseID string) (StatusGroups, error) {
+	return c.checkedStatuses(ctx, databaseID, c.settings.EventStatuses)
+}
+
+func (c *Client) checkedStatuses(ctx context.Context, databaseID string, groups StatusGroups) (StatusGroups, error) {
+	c.mu.Lock()
+	checked := c.checkedDatabases[databaseID]
+	c.mu.Unlock()
+
+	if !checked {
+		if err := c.ValidateStatusGroups(ctx, databaseID, groups); err != nil {
+			return groups, err
+		}
+		c.mu.Lock()
+		c.checkedDatabases[databaseID] = true
+		c.mu.Unlock()
+	}
+	return groups, nil
+}
+
+// containsFold reports whether values contains s, ignoring case
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package notioncli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jontk/notion-cli/internal/notiontest"
+)
+
+// customTasksSchema is a tasks database with a workflow of its own
+func customTasksSchema() notiontest.Schema {
+	schema := notiontest.TasksSchema()
+	schema["Status"] = notiontest.Status([]string{"Backlog"}, []string{"Doing"}, []string{"Complete", "Won't do"})
+	return schema
+}
+
+func TestValidateStatusGroups(t *testing.T) {
+	client, srv := newTestClient(t)
+	tasks := srv.AddDatabase("Tasks", customTasksSchema())
+	events := srv.AddDatabase("Events", notiontest.EventsSchema())
+	ctx := context.Background()
+
+	valid := StatusGroups{Open: []string{"Backlog"}, InProgress: []string{"doing"}, Done: []string{"Complete"}, Cancelled: []string{"Won't do"}}
+	for _, tc := range []struct {
+		name   string
+		db     string
+		groups StatusGroups
+		err    string
+	}{
+		{"matching", tasks, valid, ""},
+		{"empty groups", tasks, StatusGroups{Done: []string{"Complete"}}, ""},
+		{"missing status", tasks, StatusGroups{Done: []string{"Shipped"}}, `"Shipped" (done) is not a Status option; options are Backlog, Doing, Complete, Won't do`},
+		{"wrong group", tasks, StatusGroups{Open: []string{"Complete"}}, `"Complete" is configured as open but Notion lists it under "Complete"`},
+		{"cancelled in progress", tasks, StatusGroups{Cancelled: []string{"Doing"}}, `"Doing" is configured as cancelled but Notion lists it under "In progress"`},
+		{"not a status property", events, StatusGroups{Done: []string{"Shipped"}}, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := client.ValidateStatusGroups(ctx, tc.db, tc.groups)
+			if tc.err == "" {
+				if err != nil {
+					t.Errorf("ValidateStatusGroups: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("ValidateStatusGroups = %v, want an error containing %s", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestConfiguredStatuses(t *testing.T) {
+	_, srv := newTestClient(t)
+	tasks := srv.AddDatabase("Tasks", customTasksSchema())
+	eventSchema := notiontest.EventsSchema()
+	eventSchema["Status"] = notiontest.MultiSelect("Planned", "Held", "Called off")
+	events := srv.AddDatabase("Events", eventSchema)
+	ctx := context.Background()
+
+	client := New(testToken, WithHTTPClient(srv.HTTPClient()), WithSettings(Settings{
+		DefaultTaskStatus: "Backlog",
+		TaskStatuses:      StatusGroups{Open: []string{"Backlog"}, InProgress: []string{"Doing"}, Done: []string{"Complete"}, Cancelled: []string{"Won't do"}},
+		EventStatuses:     StatusGroups{Open: []string{"Planned"}, Done: []string{"Held"}, Cancelled: []string{"Called off"}},
+	}))
+
+	task := srv.AddPage(tasks, map[string]any{"Title": "Write report", "Status": "Doing"})
+	done, err := client.CompleteTask(ctx, task, false)
+	if err != nil || done.Status != "Complete" {
+		t.Errorf("CompleteTask = %+v, %v, want status Complete", done, err)
+	}
+
+	event := srv.AddPage(events, map[string]any{"Title": "Planning", "Date": "2026-10-20T09:00:00Z", "Status": "Planned"})
+	cancelled, err := client.CancelEvent(ctx, event)
+	if err != nil || cancelled.Status != "Called off" {
+		t.Errorf("CancelEvent = %+v, %v, want status Called off", cancelled, err)
+	}
+
+	misconfigured := New(testToken, WithHTTPClient(srv.HTTPClient()), WithSettings(Settings{
+		TaskStatuses: StatusGroups{Open: []string{"Backlog"}, Done: []string{"Shipped"}},
+	}))
+	other := srv.AddPage(tasks, map[string]any{"Title": "Review", "Status": "Backlog"})
+	if _, err := misconfigured.CompleteTask(ctx, other, false); err == nil || !strings.Contains(err.Error(), `"Shipped" (done) is not a Status option`) {
+		t.Errorf("CompleteTask with a done status missing from the schema: %v", err)
+	}
+	if got, _ := client.GetTask(ctx, other); got.Status != "Backlog" {
+		t.Errorf("status = %q after a failed completion, want Backlog", got.Status)
+	}
+}
@@ -0,0 +1,385 @@
+package notioncli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jomei/notionapi"
+)
+
+// CreateTask creates a new task in the Notion database
+func (c *Client) CreateTask(ctx context.Context, input TaskInput, databaseID string) (*Task, error) {
+	enc, err := c.encoder(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	properties, err := c.taskProperties(ctx, enc, input)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &notionapi.PageCreateRequest{
+		Parent: notionapi.Parent{
+			Type:       notionapi.ParentTypeDatabaseID,
+			DatabaseID: notionapi.DatabaseID(databaseID),
+		},
+		Properties: properties,
+	}
+
+	page, err := c.createPage(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+
+	return c.pageToTask(ctx, page)
+}
+
+// GetTask retrieves a single task by ID
+func (c *Client) GetTask(ctx context.Context, taskID string) (*Task, error) {
+	page, err := c.getPage(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	return c.pageToTask(ctx, page)
+}
+
+// UpdateTask updates an existing task
+func (c *Client) UpdateTask(ctx context.Context, taskID string, input TaskInput) (*Task, error) {
+	enc, err := c.pageEncoder(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	properties, err := c.taskProperties(ctx, enc, input)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &notionapi.PageUpdateRequest{
+		Properties: properties,
+	}
+
+	page, err := c.updatePage(ctx, taskID, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return c.pageToTask(ctx, page)
+}
+
+// taskProperties encodes the fields set in a task input
+func (c *Client) taskProperties(ctx context.Context, enc *propertyEncoder, input TaskInput) (notionapi.Properties, error) {
+	if input.Title != "" {
+		enc.title(input.Title)
+	}
+	if input.Status != "" {
+		enc.choice("Status", input.Status, kindStatus)
+	}
+	if input.Priority != "" {
+		enc.choice("Priority", input.Priority, kindSelect)
+	}
+	if input.Category != "" {
+		enc.choice("Category", input.Category, kindSelect)
+	}
+	if len(input.Tags) > 0 {
+		enc.choices("Tags", input.Tags)
+	}
+	if input.DueDate != "" {
+		prop, err := c.dateProperty(input.DueDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due date: %w", err)
+		}
+		enc.date("Due Date", prop)
+	}
+	if input.Notes != "" {
+		enc.text("Notes", input.Notes)
+	}
+
+	if err := c.setRecurrence(enc, input.Repeat, input.SeriesID); err != nil {
+		return nil, err
+	}
+	c.setRelations(enc, input.ParentID, input.BlockedBy)
+
+	if len(input.Assignees) > 0 {
+		prop, err := c.peopleProperty(ctx, input.Assignees)
+		if err != nil {
+			return nil, err
+		}
+		enc.people(c.settings.AssigneeProperty, prop)
+	}
+
+	return enc.result()
+}
+
+// CompleteTask sets a task to the first of the done statuses. A task whose
+// blockers are still open is refused with a *BlockedError unless force is
+// set. For a recurring task the next instance is created as well and its ID
+// is reported in NextID.
+func (c *Client) CompleteTask(ctx context.Context, taskID string, force bool) (*Task, error) {
+	page, err := c.getPage(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+	groups, err := c.taskStatuses(ctx, string(page.Parent.DatabaseID))
+	if err != nil {
+		return nil, err
+	}
+	if len(groups.Done) == 0 {
+		return nil, fmt.Errorf("no done status is configured for tasks")
+	}
+
+	if !force {
+		current, err := c.pageToTask(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		blockers, err := c.OpenBlockers(ctx, current, groups)
+		if err != nil {
+			return nil, err
+		}
+		if len(blockers) > 0 {
+			return nil, &BlockedError{Blockers: blockers}
+		}
+	}
+
+	task, err := c.UpdateTask(ctx, taskID, TaskInput{Status: groups.Done[0]})
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Repeat != "" {
+		next, _, err := c.RollTask(ctx, taskID)
+		if err != nil {
+			return nil, fmt.Errorf("task completed but its next occurrence was not created: %w", err)
+		}
+		if next != nil {
+			task.NextID = next.ID
+		}
+	}
+
+	return task, nil
+}
+
+// TaskQueryOptions holds options for querying tasks. Due date bounds are
+// inclusive date expressions such as "today" or "2026-10-20".
+type TaskQueryOptions struct {
+	Status    string
+	Priority  string
+	Category  string
+	DueBefore string
+	DueAfter  string
+	DueOn     string
+	// Assignee limits the results to tasks assigned to a user, given by
+	// ID, email or name, or "me"
+	Assignee string
+	// Open limits the results to tasks whose status is in the open or
+	// in-progress group. It is ignored when Status is set.
+	Open bool
+	// Checklist fills in each task's ChecklistProgress, at the cost of
+	// reading every page's blocks
+	Checklist bool
+	Limit     int
+}
+
+// QueryTasks queries tasks from a database with filters
+func (c *Client) QueryTasks(ctx context.Context, databaseID string, opts TaskQueryOptions) ([]Task, error) {
+	filters, err := c.queryFilters(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Status != "" {
+		filters.is("Status", opts.Status, kindStatus)
+	} else if opts.Open {
+		groups, err := c.taskStatuses(ctx, databaseID)
+		if err != nil {
+			return nil, err
+		}
+		filters.anyOf("Status", groups.Active(), kindStatus)
+	}
+
+	if opts.Priority != "" {
+		filters.is("Priority", opts.Priority, kindSelect)
+	}
+
+	if opts.Category != "" {
+		filters.is("Category", opts.Category, kindSelect)
+	}
+
+	if opts.Assignee != "" {
+		user, err := c.ResolveUser(ctx, opts.Assignee)
+		if err != nil {
+			return nil, err
+		}
+		filters.add(notionapi.PropertyFilter{
+			Property: c.settings.AssigneeProperty,
+			People: &notionapi.PeopleFilterCondition{
+				Contains: user.ID,
+			},
+		})
+	}
+
+	dateFilters := []struct {
+		value     string
+		condition string
+	}{
+		{opts.DueOn, "equals"},
+		{opts.DueAfter, "on_or_after"},
+		{opts.DueBefore, "on_or_before"},
+	}
+	for _, df := range dateFilters {
+		if df.value == "" {
+			continue
+		}
+		day, err := c.dayBound(df.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due date: %w", err)
+		}
+		filters.add(dayFilter{
+			PropertyFilter: notionapi.PropertyFilter{Property: "Due Date"},
+			Condition:      df.condition,
+			Day:            day,
+		})
+	}
+
+	filter, err := filters.result()
+	if err != nil {
+		return nil, err
+	}
+
+	sorts := []notionapi.SortObject{
+		{
+			Property:  "Due Date",
+			Direction: notionapi.SortOrderASC,
+		},
+	}
+
+	var allTasks []Task
+	var cursor *string
+	limit := opts.Limit
+	if limit == 0 {
+		limit = 100
+	}
+
+	for {
+		pageSize := 100
+		remaining := limit - len(allTasks)
+		if remaining < pageSize {
+			pageSize = remaining
+		}
+
+		req := &notionapi.DatabaseQueryRequest{
+			Filter:   filter,
+			Sorts:    sorts,
+			PageSize: pageSize,
+		}
+
+		if cursor != nil {
+			req.StartCursor = notionapi.Cursor(*cursor)
+		}
+
+		resp, err := c.queryDatabase(ctx, databaseID, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query tasks: %w", err)
+		}
+
+		for _, page := range resp.Results {
+			task, err := c.pageToTask(ctx, &page)
+			if err != nil {
+				return nil, err
+			}
+			if opts.Checklist {
+				if err := c.taskChecklistProgress(ctx, task); err != nil {
+					return nil, err
+				}
+			}
+			allTasks = append(allTasks, *task)
+		}
+
+		if !resp.HasMore || len(allTasks) >= limit {
+			break
+		}
+		cursorStr := string(resp.NextCursor)
+		cursor = &cursorStr
+	}
+
+	return allTasks, nil
+}
+
+// GetTodaysTasks returns open tasks due today or earlier
+func (c *Client) GetTodaysTasks(ctx context.Context, databaseID string) ([]Task, error) {
+	return c.QueryTasks(ctx, databaseID, TaskQueryOptions{
+		Open:      true,
+		DueBefore: "today",
+		Limit:     100,
+	})
+}
+
+// GetOverdueTasks returns open tasks due before today
+func (c *Client) GetOverdueTasks(ctx context.Context, databaseID string) ([]Task, error) {
+	return c.QueryTasks(ctx, databaseID, TaskQueryOptions{
+		Open:      true,
+		DueBefore: "yesterday",
+		Limit:     100,
+	})
+}
+
+// GetUpcomingTasks returns open tasks due from today through the given number
+// of days ahead
+func (c *Client) GetUpcomingTasks(ctx context.Context, databaseID string, days int) ([]Task, error) {
+	return c.QueryTasks(ctx, databaseID, TaskQueryOptions{
+		Open:      true,
+		DueAfter:  "today",
+		DueBefore: fmt.Sprintf("+%dd", days),
+		Limit:     100,
+	})
+}
+
+// GetTasksDueOn returns the tasks due on a given day. Unless all is set,
+// only open tasks are included.
+func (c *Client) GetTasksDueOn(ctx context.Context, databaseID, date string, all bool) ([]Task, error) {
+	return c.QueryTasks(ctx, databaseID, TaskQueryOptions{
+		Open:  !all,
+		DueOn: date,
+		Limit: 100,
+	})
+}
+
+// dayBound resolves a date expression to the calendar day it falls on, for
+// filters on date-only properties
+func (c *Client) dayBound(dateStr string) (time.Time, error) {
+	r, err := c.parseDate(dateStr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return dayStart(r.Time.In(c.location)), nil
+}
+
+// pageToTask converts a Notion page to our Task model
+func (c *Client) pageToTask(ctx context.Context, page *notionapi.Page) (*Task, error) {
+	task := &Task{
+		ID:        string(page.ID),
+		URL:       page.URL,
+		CreatedAt: c.formatTime(page.CreatedTime),
+		UpdatedAt: c.formatTime(page.LastEditedTime),
+	}
+
+	task.Title = pageTitle(page)
+	task.Status = propertyValue(page, "Status")
+	task.Priority = propertyValue(page, "Priority")
+	task.Category = propertyValue(page, "Category")
+	task.Tags = propertyValues(page, "Tags")
+
+	// Extract due date
+	if dateProp, ok := pageDate(page, "Due Date"); ok {
+		task.DueDate = c.formatDate(dateProp.Date.Start, dateProp.DateOnly)
+	}
+
+	task.Notes = propertyValue(page, "Notes")
+	task.Repeat, task.SeriesID = c.recurrence(page)
+	task.ParentID, task.BlockedBy = c.relations(page)
+	task.Assignees = people(page, c.settings.AssigneeProperty)
+
+	return task, nil
+}
@@ -0,0 +1,318 @@
+package notioncli
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jomei/notionapi"
+	"github.com/jontk/notion-cli/internal/notiontest"
+)
+
+func TestCreateTask(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	srv.AddUser("Ada Lovelace", "ada@example.com")
+	ctx := context.Background()
+
+	task, err := client.CreateTask(ctx, TaskInput{
+		Title:     "Write report",
+		Status:    "In Progress",
+		Priority:  "High",
+		DueDate:   "2026-10-20",
+		Category:  "Work",
+		Tags:      []string{"urgent", "review"},
+		Notes:     "Quarterly numbers",
+		Assignees: []string{"ada@example.com"},
+	}, db)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+
+	want := Task{
+		ID:        task.ID,
+		Title:     "Write report",
+		Status:    "In Progress",
+		Priority:  "High",
+		DueDate:   "2026-10-20",
+		Category:  "Work",
+		Tags:      []string{"urgent", "review"},
+		Notes:     "Quarterly numbers",
+		Assignees: []string{"Ada Lovelace"},
+		URL:       task.URL,
+		CreatedAt: task.CreatedAt,
+		UpdatedAt: task.UpdatedAt,
+	}
+	if !reflect.DeepEqual(*task, want) {
+		t.Errorf("CreateTask returned %+v, want %+v", *task, want)
+	}
+
+	got, err := client.GetTask(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	if !reflect.DeepEqual(got, task) {
+		t.Errorf("GetTask returned %+v, want %+v", got, task)
+	}
+}
+
+func TestCreateTaskDefaultStatus(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+
+	task, err := client.CreateTask(context.Background(), TaskInput{Title: "Inbox zero"}, db)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	if task.Status != "Todo" {
+		t.Errorf("status = %q, want the first to-do option", task.Status)
+	}
+}
+
+func TestCreateTaskUnknownStatus(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+
+	_, err := client.CreateTask(context.Background(), TaskInput{Title: "Ship it", Status: "Shipped"}, db)
+	if err == nil {
+		t.Fatal("CreateTask with an unknown status succeeded")
+	}
+	var apiErr *notionapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusBadRequest || apiErr.Code != "validation_error" {
+		t.Errorf("error = %v, want a validation error from the API", err)
+	}
+}
+
+func TestCreateTaskRetriesRateLimit(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	srv.FailNext(http.MethodPost, "pages", http.StatusTooManyRequests, "rate_limited")
+
+	if _, err := client.CreateTask(context.Background(), TaskInput{Title: "Retry me"}, db); err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	creates := 0
+	for _, r := range srv.Requests() {
+		if r.Method == http.MethodPost && r.Path == "pages" {
+			creates++
+		}
+	}
+	if creates != 2 {
+		t.Errorf("sent %d create requests, want the rate limited one and a retry", creates)
+	}
+}
+
+func TestCreateTaskAdaptsToSchema(t *testing.T) {
+	client, srv := newTestClient(t)
+	// A hand-made database: the title is called Name, Status is a plain
+	// select and Priority is free text
+	db := srv.AddDatabase("Chores", notiontest.Schema{
+		"Name":     notiontest.Title(),
+		"Status":   notiontest.Select("Todo", "Done"),
+		"Priority": notiontest.Text(),
+		"Tags":     notiontest.Text(),
+	})
+
+	task, err := client.CreateTask(context.Background(), TaskInput{
+		Title:    "Water plants",
+		Status:   "Todo",
+		Priority: "Low",
+		Tags:     []string{"home"},
+	}, db)
+	if err != nil {
+		t.Fatalf("CreateTask: %v", err)
+	}
+	if task.Title != "Water plants" || task.Status != "Todo" || task.Priority != "Low" {
+		t.Errorf("CreateTask returned %+v", *task)
+	}
+	if !reflect.DeepEqual(task.Tags, []string{"home"}) {
+		t.Errorf("tags = %q, want [home]", task.Tags)
+	}
+
+	_, err = client.CreateTask(context.Background(), TaskInput{Title: "Both", DueDate: "today"}, db)
+	if err == nil || !strings.Contains(err.Error(), "Due Date") {
+		t.Errorf("CreateTask with a missing date property: error = %v, want it to name Due Date", err)
+	}
+
+	computed := srv.AddDatabase("Computed", notiontest.Schema{
+		"Name":     notiontest.Title(),
+		"Due Date": notiontest.Formula(`now()`),
+	})
+	_, err = client.CreateTask(context.Background(), TaskInput{Title: "Both", DueDate: "today"}, computed)
+	var propErr *PropertyError
+	if !errors.As(err, &propErr) || len(propErr.Problems) != 1 || !strings.Contains(propErr.Problems[0], "Due Date") {
+		t.Errorf("CreateTask writing to a formula: error = %v, want a PropertyError naming Due Date", err)
+	}
+}
+
+func TestUpdateTask(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	id := srv.AddPage(db, map[string]any{
+		"Title":    "Write report",
+		"Status":   "Todo",
+		"Priority": "Low",
+		"Tags":     []string{"review"},
+	})
+	ctx := context.Background()
+
+	task, err := client.UpdateTask(ctx, id, TaskInput{Priority: "High", DueDate: "2026-11-02"})
+	if err != nil {
+		t.Fatalf("UpdateTask: %v", err)
+	}
+	if task.Title != "Write report" || task.Status != "Todo" {
+		t.Errorf("UpdateTask changed fields it wasn't given: %+v", *task)
+	}
+	if task.Priority != "High" || task.DueDate != "2026-11-02" {
+		t.Errorf("UpdateTask returned %+v, want priority High due 2026-11-02", *task)
+	}
+	if !reflect.DeepEqual(task.Tags, []string{"review"}) {
+		t.Errorf("tags = %q, want them kept", task.Tags)
+	}
+
+	if _, err := client.UpdateTask(ctx, "0f5ae1d6-0000-4000-8000-00000000ffff", TaskInput{Priority: "High"}); err == nil {
+		t.Error("UpdateTask of a missing page succeeded")
+	}
+}
+
+func TestQueryTasks(t *testing.T) {
+	client, srv := newTestClient(t)
+	srv.MaxPageSize = 2
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	for _, task := range []struct {
+		title, priority, due string
+	}{
+		{"Third", "High", "2026-10-22"},
+		{"First", "High", "2026-10-20"},
+		{"Low one", "Low", "2026-10-19"},
+		{"Second", "High", "2026-10-21"},
+		{"Undated", "High", ""},
+		{"Fourth", "High", "2026-10-23"},
+	} {
+		values := map[string]any{"Title": task.title, "Priority": task.priority}
+		if task.due != "" {
+			values["Due Date"] = task.due
+		}
+		srv.AddPage(db, values)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		opts TaskQueryOptions
+		want []string
+	}{
+		{"all pages", TaskQueryOptions{}, []string{"Low one", "First", "Second", "Third", "Fourth", "Undated"}},
+		{"filter", TaskQueryOptions{Priority: "High"}, []string{"First", "Second", "Third", "Fourth", "Undated"}},
+		{"limit", TaskQueryOptions{Priority: "High", Limit: 3}, []string{"First", "Second", "Third"}},
+		{"due range", TaskQueryOptions{DueAfter: "2026-10-20", DueBefore: "2026-10-22"}, []string{"First", "Second", "Third"}},
+		{"due on", TaskQueryOptions{DueOn: "2026-10-21"}, []string{"Second"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := client.QueryTasks(ctx, db, tt.opts)
+			if err != nil {
+				t.Fatalf("QueryTasks: %v", err)
+			}
+			var titles []string
+			for _, task := range tasks {
+				titles = append(titles, task.Title)
+			}
+			if !reflect.DeepEqual(titles, tt.want) {
+				t.Errorf("QueryTasks = %q, want %q", titles, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryTasksDueOutsideUTC(t *testing.T) {
+	for _, loc := range []*time.Location{time.FixedZone("AEST", 10*60*60), time.FixedZone("PDT", -7*60*60)} {
+		t.Run(loc.String(), func(t *testing.T) {
+			srv := notiontest.NewServer()
+			srv.Token = testToken
+			client := New(testToken, WithHTTPClient(srv.HTTPClient()), WithLocation(loc))
+			db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+			today := time.Now().In(loc)
+			for title, days := range map[string]int{"Yesterday": -1, "Today": 0, "Tomorrow": 1} {
+				due := today.AddDate(0, 0, days).Format("2006-01-02")
+				srv.AddPage(db, map[string]any{"Title": title, "Status": "Todo", "Due Date": due})
+			}
+			ctx := context.Background()
+
+			for _, tc := range []struct {
+				name  string
+				query func() ([]Task, error)
+				want  []string
+			}{
+				{"today", func() ([]Task, error) { return client.GetTodaysTasks(ctx, db) }, []string{"Yesterday", "Today"}},
+				{"overdue", func() ([]Task, error) { return client.GetOverdueTasks(ctx, db) }, []string{"Yesterday"}},
+				{"due on", func() ([]Task, error) { return client.GetTasksDueOn(ctx, db, "today", false) }, []string{"Today"}},
+				{"upcoming", func() ([]Task, error) { return client.GetUpcomingTasks(ctx, db, 1) }, []string{"Today", "Tomorrow"}},
+			} {
+				tasks, err := tc.query()
+				if err != nil {
+					t.Fatalf("%s: %v", tc.name, err)
+				}
+				var titles []string
+				for _, task := range tasks {
+					titles = append(titles, task.Title)
+				}
+				if !reflect.DeepEqual(titles, tc.want) {
+					t.Errorf("%s = %q, want %q", tc.name, titles, tc.want)
+				}
+			}
+
+			bound := regexp.MustCompile(`"date":{"(?:equals|on_or_after|on_or_before)":"([^"]*)"`)
+			bounds := 0
+			for _, r := range srv.Requests() {
+				if !strings.HasSuffix(r.Path, "/query") {
+					continue
+				}
+				for _, m := range bound.FindAllStringSubmatch(string(r.Body), -1) {
+					bounds++
+					if _, err := time.Parse("2006-01-02", m[1]); err != nil {
+						t.Errorf("due date bound %q, want a calendar date", m[1])
+					}
+				}
+			}
+			if bounds != 5 {
+				t.Errorf("sent %d due date bounds, want 5", bounds)
+			}
+		})
+	}
+}
+
+func TestQueryTasksOpen(t *testing.T) {
+	client, srv := newTestClient(t)
+	db := srv.AddDatabase("Tasks", notiontest.TasksSchema())
+	srv.AddPage(db, map[string]any{"Title": "Todo", "Status": "Todo"})
+	srv.AddPage(db, map[string]any{"Title": "Blocked", "Status": "Blocked"})
+	srv.AddPage(db, map[string]any{"Title": "Done", "Status": "Done"})
+
+	tasks, err := client.QueryTasks(context.Background(), db, TaskQueryOptions{Open: true})
+	if err != nil {
+		t.Fatalf("QueryTasks: %v", err)
+	}
+	got := map[string]bool{}
+	for _, task := range tasks {
+		got[task.Title] = true
+	}
+	if !reflect.DeepEqual(got, map[string]bool{"Todo": true, "Blocked": true}) {
+		t.Errorf("open tasks = %v, want Todo and Blocked", got)
+	}
+}
+
+func TestQueryTasksUnknownDatabase(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	_, err := client.QueryTasks(context.Background(), "0f5ae1d6-0000-4000-8000-00000000ffff", TaskQueryOptions{})
+	var apiErr *notionapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != "object_not_found" {
+		t.Errorf("error = %v, want object_not_found", err)
+	}
+}
@@ -0,0 +1,212 @@
+package notioncli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jomei/notionapi"
+)
+
+// userCache is the on-disk form of the workspace user list
+type userCache struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Users     []User    `json:"users"`
+}
+
+// SetUserCache makes the client keep the workspace user list in a file for
+// ttl, so resolving assignees doesn't list every user on each run. An empty
+// path keeps the list in memory only.
+func (c *Client) SetUserCache(path string, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.userCachePath = path
+	c.userCacheTTL = ttl
+}
+
+// ListUsers returns the users of the workspace. With refresh set the cached
+// list is ignored and fetched again.
+func (c *Client) ListUsers(ctx context.Context, refresh bool) ([]User, error) {
+	c.mu.Lock()
+	users, path, ttl := c.users, c.userCachePath, c.userCacheTTL
+	c.mu.Unlock()
+
+	if !refresh {
+		if users != nil {
+			return users, nil
+		}
+		if cached, ok := readUserCache(path, ttl); ok {
+			c.mu.Lock()
+			c.users = cached
+			c.mu.Unlock()
+			return cached, nil
+		}
+	}
+
+	users = []User{}
+	var cursor notionapi.Cursor
+	for {
+		resp, err := c.api.User.List(ctx, &notionapi.Pagination{StartCursor: cursor, PageSize: 100})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users: %w", err)
+		}
+		for _, u := range resp.Results {
+			users = append(users, userToModel(u))
+		}
+		if !resp.HasMore {
+			break
+		}
+		cursor = resp.NextCursor
+	}
+
+	c.mu.Lock()
+	c.users = users
+	c.mu.Unlock()
+	// The cache only saves time; failing to write it is not an error
+	writeUserCache(path, users)
+
+	return users, nil
+}
+
+// ResolveUser finds a workspace user by ID, email or name. "me" stands for
+// the user configured in Settings.Me. A user missing from the cached list
+// is looked for again in a fresh one, in case they joined since.
+func (c *Client) ResolveUser(ctx context.Context, query string) (*User, error) {
+	query = strings.TrimSpace(query)
+	if strings.EqualFold(query, "me") {
+		if c.settings.Me == "" {
+			return nil, fmt.Errorf(`"me" needs your email or name set as 'me' in the config`)
+		}
+		query = c.settings.Me
+	}
+	if query == "" {
+		return nil, fmt.Errorf("user is empty")
+	}
+
+	users, err := c.ListUsers(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	user, byName := findUser(users, query)
+	if user == nil && len(byName) == 0 {
+		if users, err = c.ListUsers(ctx, true); err != nil {
+			return nil, err
+		}
+		user, byName = findUser(users, query)
+	}
+	if user != nil {
+		return user, nil
+	}
+
+	switch len(byName) {
+	case 1:
+		return &byName[0], nil
+	case 0:
+		if isPageID(query) {
+			// Guests are not listed; trust an ID as given
+			return &User{ID: query}, nil
+		}
+		return nil, fmt.Errorf("no user matches %q; see 'notion-cli users list'", query)
+	default:
+		emails := make([]string, 0, len(byName))
+		for _, u := range byName {
+			emails = append(emails, u.Email)
+		}
+		return nil, fmt.Errorf("%d users are named %q; use an email instead (%s)", len(byName), query, strings.Join(emails, ", "))
+	}
+}
+
+// findUser looks a user up by ID or email, or else returns the users with
+// the query as their name
+func findUser(users []User, query string) (*User, []User) {
+	var byName []User
+	for i, u := range users {
+		if sameID(u.ID, query) || (u.Email != "" && strings.EqualFold(u.Email, query)) {
+			return &users[i], nil
+		}
+		if strings.EqualFold(u.Name, query) {
+			byName = append(byName, u)
+		}
+	}
+	return nil, byName
+}
+
+// peopleProperty resolves users to a people property value
+func (c *Client) peopleProperty(ctx context.Context, queries []string) (notionapi.PeopleProperty, error) {
+	people := make([]notionapi.User, 0, len(queries))
+	for _, q := range queries {
+		user, err := c.ResolveUser(ctx, q)
+		if err != nil {
+			return notionapi.PeopleProperty{}, fmt.Errorf("invalid assignee: %w", err)
+		}
+		people = append(people, notionapi.User{ID: notionapi.UserID(user.ID)})
+	}
+	return notionapi.PeopleProperty{People: people}, nil
+}
+
+// people reads the names of the users in a people property of a page,
+// falling back to their email or ID
+func people(page *notionapi.Page, name string) []string {
+	prop, ok := page.Properties[name].(*notionapi.PeopleProperty)
+	if !ok || len(prop.People) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(prop.People))
+	for _, u := range prop.People {
+		user := userToModel(u)
+		switch {
+		case user.Name != "":
+			names = append(names, user.Name)
+		case user.Email != "":
+			names = append(names, user.Email)
+		default:
+			names = append(names, user.ID)
+		}
+	}
+	return names
+}
+
+func userToModel(u notionapi.User) User {
+	user := User{
+		ID:   string(u.ID),
+		Name: u.Name,
+		Type: string(u.Type),
+	}
+	if u.Person != nil {
+		user.Email = u.Person.Email
+	}
+	return user
+}
+
+func readUserCache(path string, ttl time.Duration) ([]User, bool) {
+	if path == "" || ttl <= 0 {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var cache userCache
+	if err := json.Unmarshal(data, &cache); err != nil || time.Since(cache.FetchedAt) > ttl {
+		return nil, false
+	}
+	return cache.Users, true
+}
+
+func writeUserCache(path string, users []User) {
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(userCache{FetchedAt: time.Now(), Users: users})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
@@ -0,0 +1,133 @@
+package notioncli
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jontk/notion-cli/internal/notiontest"
+)
+
+// userLists counts the requests listing the workspace users
+func userLists(srv *notiontest.Server) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if r.Method == "GET" && r.Path == "users" {
+			n++
+		}
+	}
+	return n
+}
+
+func TestResolveUser(t *testing.T) {
+	client, srv := newTestClient(t)
+	ada := srv.AddUser("Ada Lovelace", "ada@example.com")
+	srv.AddUser("Sam", "sam@example.com")
+	srv.AddUser("sam", "sam.other@example.com")
+	guest := "0f5ae1d6-0000-4000-8000-00000000abcd"
+	client.SetSettings(Settings{Me: "ADA@example.com"})
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		query string
+		want  string
+		err   string
+	}{
+		{ada, ada, ""},
+		{strings.ReplaceAll(ada, "-", ""), ada, ""},
+		{"Ada@Example.com", ada, ""},
+		{" ada lovelace ", ada, ""},
+		{"me", ada, ""},
+		{guest, guest, ""},
+		{"sam", "", "2 users are named"},
+		{"Grace", "", `no user matches "Grace"`},
+		{"", "", "user is empty"},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			user, err := client.ResolveUser(ctx, tc.query)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Errorf("ResolveUser(%q) = %v, %v, want error containing %q", tc.query, user, err, tc.err)
+				}
+				return
+			}
+			if err != nil || user.ID != tc.want {
+				t.Errorf("ResolveUser(%q) = %v, %v, want %s", tc.query, user, err, tc.want)
+			}
+		})
+	}
+
+	client.SetSettings(Settings{})
+	if _, err := client.ResolveUser(ctx, "me"); err == nil || !strings.Contains(err.Error(), "'me' in the config") {
+		t.Errorf(`ResolveUser("me") without Me set: %v`, err)
+	}
+}
+
+func TestResolveUserRefreshes(t *testing.T) {
+	client, srv := newTestClient(t)
+	srv.AddUser("Ada Lovelace", "ada@example.com")
+	ctx := context.Background()
+
+	if _, err := client.ResolveUser(ctx, "Ada Lovelace"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ResolveUser(ctx, "ada@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if n := userLists(srv); n != 1 {
+		t.Errorf("listed users %d times for two known users, want 1", n)
+	}
+
+	grace := srv.AddUser("Grace Hopper", "grace@example.com")
+	user, err := client.ResolveUser(ctx, "Grace Hopper")
+	if err != nil || user.ID != grace {
+		t.Fatalf("ResolveUser of a user added after listing = %v, %v, want %s", user, err, grace)
+	}
+	if n := userLists(srv); n != 2 {
+		t.Errorf("listed users %d times, want one refresh", n)
+	}
+
+	if _, err := client.ResolveUser(ctx, "Nobody"); err == nil {
+		t.Error("ResolveUser of an unknown user succeeded")
+	}
+	if n := userLists(srv); n != 3 {
+		t.Errorf("listed users %d times, want one refresh per miss", n)
+	}
+}
+
+func TestListUsersCache(t *testing.T) {
+	_, srv := newTestClient(t)
+	srv.AddUser("Ada Lovelace", "ada@example.com")
+	path := filepath.Join(t.TempDir(), "users.json")
+	ctx := context.Background()
+
+	newClient := func() *Client {
+		client := New(testToken, WithHTTPClient(srv.HTTPClient()))
+		client.SetUserCache(path, time.Hour)
+		return client
+	}
+	first, err := newClient().ListUsers(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The fake server lists its bot user as well
+	if len(first) != 2 {
+		t.Fatalf("ListUsers = %+v, want the bot and Ada", first)
+	}
+
+	srv.AddUser("Grace Hopper", "grace@example.com")
+	cached, err := newClient().ListUsers(ctx, false)
+	if err != nil || len(cached) != 2 {
+		t.Errorf("ListUsers from the cache file = %+v, %v, want the 2 cached users", cached, err)
+	}
+	if n := userLists(srv); n != 1 {
+		t.Errorf("listed users %d times, want the second client to read the cache", n)
+	}
+
+	fresh, err := newClient().ListUsers(ctx, true)
+	if err != nil || len(fresh) != 3 {
+		t.Errorf("ListUsers with refresh = %+v, %v, want 3 users", fresh, err)
+	}
+}